@@ -0,0 +1,37 @@
+// Package bom detects and skips a leading UTF-8 byte order mark.
+//
+// Spreadsheet tools such as Excel often prefix exported CSV files with the
+// UTF-8 BOM (EF BB BF). Left in place it becomes part of the first header
+// cell, so every parser entry point strips it through this package.
+package bom
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// UTF8 is the UTF-8 encoding of the byte order mark (U+FEFF).
+const UTF8 = "\xEF\xBB\xBF"
+
+// Strip returns data without a leading UTF-8 BOM.
+// A BOM anywhere else in data is left untouched.
+func Strip(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte(UTF8))
+}
+
+// StripString returns s without a leading UTF-8 BOM.
+func StripString(s string) string {
+	return strings.TrimPrefix(s, UTF8)
+}
+
+// NewReader returns a reader that yields the contents of r without a leading
+// UTF-8 BOM.
+func NewReader(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(UTF8)); err == nil && string(prefix) == UTF8 {
+		_, _ = br.Discard(len(UTF8))
+	}
+	return br
+}
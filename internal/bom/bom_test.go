@@ -0,0 +1,39 @@
+package bom
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"leading BOM", "\ufeffa,b", "a,b"},
+		{"no BOM", "a,b", "a,b"},
+		{"BOM in middle", "a,\ufeffb", "a,\ufeffb"},
+		{"only BOM", "\ufeff", ""},
+		{"partial BOM", "\xEF\xBB", "\xEF\xBB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Strip([]byte(tt.input))); got != tt.want {
+				t.Errorf("Strip() = %q, want %q", got, tt.want)
+			}
+			if got := StripString(tt.input); got != tt.want {
+				t.Errorf("StripString() = %q, want %q", got, tt.want)
+			}
+			got, err := io.ReadAll(NewReader(strings.NewReader(tt.input)))
+			if err != nil {
+				t.Fatalf("NewReader() read error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("NewReader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
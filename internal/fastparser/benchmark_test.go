@@ -1,6 +1,7 @@
 package fastparser
 
 import (
+	"runtime"
 	"testing"
 )
 
@@ -111,6 +112,19 @@ func BenchmarkParse_Medium(b *testing.B) {
 	}
 }
 
+// BenchmarkParse_Medium_GeneralPath benchmarks medium CSV on the general parser,
+// bypassing the no-quote fast path taken by BenchmarkParse_Medium
+func BenchmarkParse_Medium_GeneralPath(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := &parser{data: mediumCSV, length: len(mediumCSV)}
+		_, err := p.parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // BenchmarkParse_Large benchmarks parsing large CSV
 func BenchmarkParse_Large(b *testing.B) {
 	b.ReportAllocs()
@@ -173,6 +187,30 @@ func BenchmarkParse_QuotedWithEscapes(b *testing.B) {
 	}
 }
 
+// BenchmarkParse_SmallPayloads benchmarks calling Parse for many small inputs
+func BenchmarkParse_SmallPayloads(b *testing.B) {
+	payloads := [][]byte{smallCSV, []byte("id,name\n1,\"Smith, Jane\"\n"), generateCSV(5, 4, false)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(payloads[i%len(payloads)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReusableParser_SmallPayloads benchmarks the same inputs with a
+// ReusableParser, which reuses its buffers between calls
+func BenchmarkReusableParser_SmallPayloads(b *testing.B) {
+	payloads := [][]byte{smallCSV, []byte("id,name\n1,\"Smith, Jane\"\n"), generateCSV(5, 4, false)}
+	rp := NewReusableParser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := rp.Parse(payloads[i%len(payloads)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // BenchmarkFieldPool tests the field pool performance
 func BenchmarkFieldPool(b *testing.B) {
 	b.ReportAllocs()
@@ -1005,3 +1043,16 @@ func BenchmarkComparison_DFA_Quoted_DFA(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkParseParallel_VeryLarge benchmarks parallel parsing on the 10k-row
+// dataset used by BenchmarkParseChunked_VeryLarge
+func BenchmarkParseParallel_VeryLarge(b *testing.B) {
+	veryLargeCSV := generateCSV(10000, 10, false)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := ParseParallel(veryLargeCSV, runtime.GOMAXPROCS(0))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package fastparser
+
+import "strings"
+
+// ByteReader parses CSV data with a header row into ByteRecords that can be
+// accessed by column name as well as by index. Names are resolved through a
+// map built once from the header, so lookups keep the zero-copy benefit of
+// ByteRecord.
+//
+// Example usage:
+//
+//	reader, err := NewByteReader(data)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, record := range reader.Records() {
+//	    email := record.FieldBytesByName("email")
+//	    // Process email without allocating...
+//	}
+type ByteReader struct {
+	header  []string
+	names   map[string]int
+	records []*ByteRecord
+}
+
+// NewByteReader parses data with ParseByteRecords, taking the first record
+// as the header row and the rest as data records. Header names are matched
+// exactly first and then case-insensitively; if a name appears more than
+// once, the first occurrence is used. Empty input yields no header and no
+// records.
+func NewByteReader(data []byte) (*ByteReader, error) {
+	records, err := ParseByteRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ByteReader{names: make(map[string]int)}
+	if len(records) == 0 {
+		return r, nil
+	}
+
+	r.header = records[0].Fields()
+	for i, name := range r.header {
+		if _, ok := r.names[name]; !ok {
+			r.names[name] = i
+		}
+	}
+	for i, name := range r.header {
+		if folded := strings.ToLower(name); folded != name {
+			if _, ok := r.names[folded]; !ok {
+				r.names[folded] = i
+			}
+		}
+	}
+
+	r.records = records[1:]
+	for _, record := range r.records {
+		record.names = r.names
+	}
+	return r, nil
+}
+
+// Header returns the header row.
+func (r *ByteReader) Header() []string {
+	return r.header
+}
+
+// Records returns the data records, excluding the header row.
+func (r *ByteReader) Records() []*ByteRecord {
+	return r.records
+}
+
+// Index returns the index of the column with the given header name, matched
+// as by FieldByName, and whether it was found.
+func (r *ByteReader) Index(name string) (int, bool) {
+	return lookupName(r.names, name)
+}
+
+// lookupName finds name in a header index built by NewByteReader, trying an
+// exact match before a case-insensitive one.
+func lookupName(names map[string]int, name string) (int, bool) {
+	if i, ok := names[name]; ok {
+		return i, true
+	}
+	i, ok := names[strings.ToLower(name)]
+	return i, ok
+}
+
+// FieldByName returns the field in the column with the given header name,
+// matched exactly first and then case-insensitively. Returns empty string if
+// the name is not in the header, the record is too short to have the column,
+// or the record was not read by a ByteReader.
+func (r *ByteRecord) FieldByName(name string) string {
+	i, ok := lookupName(r.names, name)
+	if !ok {
+		return ""
+	}
+	return r.Field(i)
+}
+
+// FieldBytesByName is like FieldByName but returns the field as a []byte
+// slice without allocation, or nil if the field is not found.
+//
+// IMPORTANT: The returned slice must not be modified, as it shares memory
+// with the original CSV data.
+func (r *ByteRecord) FieldBytesByName(name string) []byte {
+	i, ok := lookupName(r.names, name)
+	if !ok {
+		return nil
+	}
+	return r.FieldBytes(i)
+}
@@ -0,0 +1,115 @@
+package fastparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestByteReader_FieldByName(t *testing.T) {
+	data := []byte("ID,Name,Email\n1,Alice,alice@example.com\n2,\"Smith, Bob\",bob@example.com\n")
+
+	reader, err := NewByteReader(data)
+	if err != nil {
+		t.Fatalf("NewByteReader() error = %v", err)
+	}
+	if want := []string{"ID", "Name", "Email"}; !reflect.DeepEqual(reader.Header(), want) {
+		t.Errorf("Header() = %v, want %v", reader.Header(), want)
+	}
+
+	records := reader.Records()
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	tests := []struct {
+		name   string
+		record int
+		column string
+		want   string
+	}{
+		{name: "exact name", record: 0, column: "Name", want: "Alice"},
+		{name: "quoted field", record: 1, column: "Name", want: "Smith, Bob"},
+		{name: "lower case", record: 0, column: "email", want: "alice@example.com"},
+		{name: "upper case", record: 1, column: "ID", want: "2"},
+		{name: "mixed case", record: 1, column: "eMaIl", want: "bob@example.com"},
+		{name: "missing name", record: 0, column: "phone", want: ""},
+		{name: "empty name", record: 0, column: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := records[tt.record]
+			if got := record.FieldByName(tt.column); got != tt.want {
+				t.Errorf("FieldByName(%q) = %q, want %q", tt.column, got, tt.want)
+			}
+			if got := string(record.FieldBytesByName(tt.column)); got != tt.want {
+				t.Errorf("FieldBytesByName(%q) = %q, want %q", tt.column, got, tt.want)
+			}
+		})
+	}
+
+	if got := records[0].FieldBytesByName("phone"); got != nil {
+		t.Errorf("FieldBytesByName(missing) = %q, want nil", got)
+	}
+}
+
+func TestByteReader_Index(t *testing.T) {
+	reader, err := NewByteReader([]byte("a,B,A,b\n1,2,3,4\n"))
+	if err != nil {
+		t.Fatalf("NewByteReader() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		want   int
+		wantOK bool
+	}{
+		{name: "a", want: 0, wantOK: true},
+		{name: "A", want: 2, wantOK: true}, // exact match wins over folded
+		{name: "B", want: 1, wantOK: true},
+		{name: "b", want: 3, wantOK: true},
+		{name: "c", want: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := reader.Index(tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Index(%q) = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if got := reader.Records()[0].FieldByName("A"); got != "3" {
+		t.Errorf("FieldByName(%q) = %q, want %q", "A", got, "3")
+	}
+}
+
+func TestByteReader_EdgeCases(t *testing.T) {
+	reader, err := NewByteReader(nil)
+	if err != nil {
+		t.Fatalf("NewByteReader(nil) error = %v", err)
+	}
+	if len(reader.Header()) != 0 || len(reader.Records()) != 0 {
+		t.Errorf("empty input gave header %v and %d records, want none", reader.Header(), len(reader.Records()))
+	}
+
+	// Short records return empty for columns they lack
+	reader, err = NewByteReader([]byte("a,b,c\n1\n"))
+	if err != nil {
+		t.Fatalf("NewByteReader() error = %v", err)
+	}
+	if got := reader.Records()[0].FieldByName("c"); got != "" {
+		t.Errorf("FieldByName on short record = %q, want empty", got)
+	}
+
+	// Records not read by a ByteReader have no header
+	records, err := ParseByteRecords([]byte("a,b\n1,2\n"))
+	if err != nil {
+		t.Fatalf("ParseByteRecords() error = %v", err)
+	}
+	if got := records[1].FieldByName("a"); got != "" {
+		t.Errorf("FieldByName without header = %q, want empty", got)
+	}
+
+	if _, err := NewByteReader([]byte("a,\"b\n")); err == nil {
+		t.Error("NewByteReader() on malformed input: error = nil, want error")
+	}
+}
@@ -1,8 +1,11 @@
 package fastparser
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+
+	"github.com/shapestone/shape-csv/internal/bom"
 )
 
 // ByteRecord represents a CSV record using the BurntSushi offset tracking pattern.
@@ -17,6 +20,14 @@ import (
 type ByteRecord struct {
 	data    []byte // The raw CSV record data
 	offsets []int  // Start position of each field in data
+
+	// Source position, set by ParseByteRecords
+	src         []byte // The record's source text, sharing the parsed input
+	fieldStarts []int  // Start of each field in src, at its opening quote if quoted
+	startLine   int    // 1-based line on which the record starts
+	startOffset int    // Byte offset of the record in the parsed input
+
+	names map[string]int // Header name to field index, set by ByteReader
 }
 
 // NewByteRecord creates a ByteRecord from raw data and field offsets.
@@ -86,6 +97,55 @@ func (r *ByteRecord) Fields() []string {
 	return fields
 }
 
+// StartLine returns the 1-based line on which the record starts in the input
+// passed to ParseByteRecords, or 0 if the record was not parsed from input.
+func (r *ByteRecord) StartLine() int {
+	return r.startLine
+}
+
+// StartOffset returns the byte offset at which the record starts in the input
+// passed to ParseByteRecords, counting any byte order mark.
+func (r *ByteRecord) StartOffset() int {
+	return r.startOffset
+}
+
+// FieldPos returns the 1-based line and byte column in the source input at
+// which the i-th field starts; for a quoted field this is its opening quote.
+// A leading byte order mark is not counted in the column.
+// A field that follows a line break inside an earlier quoted field is on a
+// later line than the record itself.
+// Returns 0, 0 if index is out of bounds or the record was not created by
+// ParseByteRecords.
+//
+// Example:
+//
+//	line, col := record.FieldPos(2)
+//	return fmt.Errorf("line %d, column %d: invalid amount", line, col)
+func (r *ByteRecord) FieldPos(i int) (line, col int) {
+	if i < 0 || i >= len(r.fieldStarts) {
+		return 0, 0
+	}
+
+	before := r.src[:r.fieldStarts[i]]
+	line = r.startLine + countLines(before)
+	if nl := bytes.LastIndexAny(before, "\r\n"); nl >= 0 {
+		return line, len(before) - nl
+	}
+	// Records start at the beginning of a line
+	return line, len(before) + 1
+}
+
+// countLines returns the number of line breaks (LF, CRLF or a lone CR) in b.
+func countLines(b []byte) int {
+	n := bytes.Count(b, []byte{'\n'})
+	for i, c := range b {
+		if c == '\r' && (i+1 == len(b) || b[i+1] != '\n') {
+			n++
+		}
+	}
+	return n
+}
+
 // ParseByteRecords parses CSV data into ByteRecords with offset tracking.
 // This is more memory-efficient than Parse() because it doesn't create
 // string copies for each field. Use this when you want to:
@@ -102,6 +162,8 @@ func (r *ByteRecord) Fields() []string {
 //
 // Returns a slice of ByteRecords.
 func ParseByteRecords(data []byte) ([]*ByteRecord, error) {
+	original := data
+	data = bom.Strip(data)
 	if len(data) == 0 {
 		return []*ByteRecord{}, nil
 	}
@@ -110,6 +172,8 @@ func ParseByteRecords(data []byte) ([]*ByteRecord, error) {
 		data:   data,
 		pos:    0,
 		length: len(data),
+		base:   len(original) - len(data),
+		line:   1,
 	}
 
 	return p.parse()
@@ -126,6 +190,10 @@ type byteRecordParser struct {
 
 	// Accumulator for building the current record's offsets
 	offsets []int
+
+	base    int // Length of the byte order mark stripped from the input
+	line    int // 1-based line at linePos
+	linePos int // Position up to which line breaks have been counted
 }
 
 // parse parses the entire CSV file into ByteRecords.
@@ -169,10 +237,17 @@ func (p *byteRecordParser) parseRecord(capacityHint int) (*ByteRecord, error) {
 		p.offsets = make([]int, 0, 8)
 	}
 
+	// Record the source position
+	p.line += countLines(p.data[p.linePos:p.pos])
+	p.linePos = p.pos
+	recordStart := p.pos
+	fieldStarts := make([]int, 0, cap(p.offsets))
+
 	for {
 		// Mark the start of this field
 		fieldStart := len(p.recordData)
 		p.offsets = append(p.offsets, fieldStart)
+		fieldStarts = append(fieldStarts, p.pos-recordStart)
 
 		// Parse the field and append to recordData
 		err := p.parseField()
@@ -184,7 +259,7 @@ func (p *byteRecordParser) parseRecord(capacityHint int) (*ByteRecord, error) {
 		if p.pos >= p.length {
 			// End of file - add final offset marker
 			p.offsets = append(p.offsets, len(p.recordData))
-			return NewByteRecord(p.recordData, p.offsets), nil
+			return p.newRecord(recordStart, fieldStarts), nil
 		}
 
 		c := p.data[p.pos]
@@ -196,15 +271,27 @@ func (p *byteRecordParser) parseRecord(capacityHint int) (*ByteRecord, error) {
 
 		if c == '\r' || c == '\n' {
 			// End of record - add final offset marker
-			p.skipNewline()
 			p.offsets = append(p.offsets, len(p.recordData))
-			return NewByteRecord(p.recordData, p.offsets), nil
+			record := p.newRecord(recordStart, fieldStarts)
+			p.skipNewline()
+			return record, nil
 		}
 
 		return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
 	}
 }
 
+// newRecord creates a ByteRecord from the accumulated data and offsets for the
+// record whose source runs from recordStart to the current position.
+func (p *byteRecordParser) newRecord(recordStart int, fieldStarts []int) *ByteRecord {
+	record := NewByteRecord(p.recordData, p.offsets)
+	record.src = p.data[recordStart:p.pos]
+	record.fieldStarts = fieldStarts
+	record.startLine = p.line
+	record.startOffset = p.base + recordStart
+	return record
+}
+
 // parseField parses a single CSV field and appends its data to recordData.
 func (p *byteRecordParser) parseField() error {
 	if p.pos >= p.length {
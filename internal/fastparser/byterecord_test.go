@@ -572,3 +572,80 @@ func TestByteRecord_SkipNewlineEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestByteRecord_FieldPos(t *testing.T) {
+	type pos struct{ line, col int }
+	tests := []struct {
+		name        string
+		data        string
+		startLines  []int
+		startOffset []int
+		want        [][]pos
+	}{
+		{
+			name:        "single line records",
+			data:        "a,bb,c\nddd,e,\n",
+			startLines:  []int{1, 2},
+			startOffset: []int{0, 7},
+			want:        [][]pos{{{1, 1}, {1, 3}, {1, 6}}, {{2, 1}, {2, 5}, {2, 7}}},
+		},
+		{
+			name:        "field after multi-line quoted field",
+			data:        "id,\"line1\nline2\nline3\",next\n2,x,y\n",
+			startLines:  []int{1, 4},
+			startOffset: []int{0, 28},
+			want:        [][]pos{{{1, 1}, {1, 4}, {3, 8}}, {{4, 1}, {4, 3}, {4, 5}}},
+		},
+		{
+			name:        "crlf with embedded crlf and blank lines",
+			data:        "\"a\r\nb\",c\r\n\r\n\"\"\"q\"\"\",\"x\r\n\",z\r\n",
+			startLines:  []int{1, 4},
+			startOffset: []int{0, 12},
+			want:        [][]pos{{{1, 1}, {2, 4}}, {{4, 1}, {4, 9}, {5, 3}}},
+		},
+		{
+			name:        "byte order mark",
+			data:        "\xEF\xBB\xBFa,b\nc,d",
+			startLines:  []int{1, 2},
+			startOffset: []int{3, 7},
+			want:        [][]pos{{{1, 1}, {1, 3}}, {{2, 1}, {2, 3}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := ParseByteRecords([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseByteRecords() error = %v", err)
+			}
+			if len(records) != len(tt.want) {
+				t.Fatalf("ParseByteRecords() got %d records, want %d", len(records), len(tt.want))
+			}
+			for r, record := range records {
+				if got := record.StartLine(); got != tt.startLines[r] {
+					t.Errorf("record %d StartLine() = %d, want %d", r, got, tt.startLines[r])
+				}
+				if got := record.StartOffset(); got != tt.startOffset[r] {
+					t.Errorf("record %d StartOffset() = %d, want %d", r, got, tt.startOffset[r])
+				}
+				for i, want := range tt.want[r] {
+					line, col := record.FieldPos(i)
+					if line != want.line || col != want.col {
+						t.Errorf("record %d FieldPos(%d) = (%d, %d), want (%d, %d)", r, i, line, col, want.line, want.col)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("out of bounds and unparsed records", func(t *testing.T) {
+		records, _ := ParseByteRecords([]byte("a,b\n"))
+		if line, col := records[0].FieldPos(2); line != 0 || col != 0 {
+			t.Errorf("FieldPos(2) = (%d, %d), want (0, 0)", line, col)
+		}
+		record := NewByteRecord([]byte("ab"), []int{0, 1, 2})
+		if line, col := record.FieldPos(0); line != 0 || col != 0 {
+			t.Errorf("FieldPos(0) on NewByteRecord = (%d, %d), want (0, 0)", line, col)
+		}
+	})
+}
@@ -2,7 +2,6 @@ package fastparser
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 )
 
@@ -66,8 +65,13 @@ func (p *chunkedParser) parse() ([][]string, error) {
 			continue
 		}
 
+		start := p.pos
 		record, err := p.parseRecord(capacityHint)
 		if err != nil {
+			if syn, ok := err.(*SyntaxError); ok {
+				syn.Record = len(records) + 1
+				syn.RecordStart = start
+			}
 			return nil, err
 		}
 
@@ -126,7 +130,7 @@ func (p *chunkedParser) parseRecord(capacityHint int) ([]string, error) {
 		if capacityHint == 0 {
 			putFieldSlice(fields)
 		}
-		return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
+		return nil, &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf("unexpected character '%c'", c)}
 	}
 }
 
@@ -190,7 +194,7 @@ func (p *chunkedParser) parseUnquotedFieldChunked() (string, error) {
 
 			// Quote in middle of unquoted field is an error
 			if c == '"' {
-				return "", fmt.Errorf("quote character in unquoted field at position %d", p.pos)
+				return "", &SyntaxError{Offset: p.pos, Msg: "quote character in unquoted field"}
 			}
 
 			p.pos++
@@ -208,7 +212,7 @@ func (p *chunkedParser) parseUnquotedFieldChunked() (string, error) {
 
 		// Quote in middle of unquoted field is an error
 		if c == '"' {
-			return "", fmt.Errorf("quote character in unquoted field at position %d", p.pos)
+			return "", &SyntaxError{Offset: p.pos, Msg: "quote character in unquoted field"}
 		}
 
 		p.pos++
@@ -220,6 +224,7 @@ func (p *chunkedParser) parseUnquotedFieldChunked() (string, error) {
 // parseQuotedFieldChunked parses a quoted CSV field handling chunk boundaries.
 func (p *chunkedParser) parseQuotedFieldChunked() (string, error) {
 	// Skip opening quote
+	quoteStart := p.pos
 	p.pos++
 
 	// Get a buffer from the pool
@@ -252,7 +257,7 @@ func (p *chunkedParser) parseQuotedFieldChunked() (string, error) {
 		p.pos++
 	}
 
-	return "", errors.New("unclosed quoted field")
+	return "", &SyntaxError{Offset: quoteStart, Msg: "unclosed quoted field"}
 }
 
 // isNewline checks if current position is at a newline.
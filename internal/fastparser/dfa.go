@@ -24,11 +24,6 @@
 // For production use, prefer Parse() over ParseDFA().
 package fastparser
 
-import (
-	"errors"
-	"fmt"
-)
-
 // charClass represents character classes for DFA state machine
 type charClass uint8
 
@@ -161,6 +156,10 @@ func ParseDFA(data []byte) ([][]string, error) {
 	state := stateStart
 	pos := 0
 	length := len(data)
+	recordStart, quoteStart := 0, 0
+	syntaxError := func(offset int, msg string) error {
+		return &SyntaxError{Record: len(records) + 1, RecordStart: recordStart, Offset: offset, Msg: msg}
+	}
 
 	// Helper to save current field
 	saveField := func() {
@@ -197,6 +196,7 @@ func ParseDFA(data []byte) ([][]string, error) {
 			if len(currentRecord) == 0 && len(currentField) == 0 {
 				// Empty line, skip it
 				pos++
+				recordStart = pos
 				continue
 			}
 		}
@@ -211,12 +211,15 @@ func ParseDFA(data []byte) ([][]string, error) {
 				putFieldSlice(currentRecord)
 			}
 			if state == stateInUnquotedField && class == classQuote {
-				return nil, fmt.Errorf("quote character in unquoted field at position %d", pos)
+				return nil, syntaxError(pos, "quote character in unquoted field")
 			}
 			if state == stateAfterQuote && class == classOther {
-				return nil, fmt.Errorf("invalid character after closing quote at position %d", pos)
+				return nil, syntaxError(pos, "invalid character after closing quote")
 			}
-			return nil, fmt.Errorf("parse error at position %d", pos)
+			return nil, syntaxError(pos, "parse error")
+		}
+		if state == stateStart && trans.nextState == stateInQuotedField {
+			quoteStart = pos
 		}
 
 		// Execute action
@@ -257,6 +260,7 @@ func ParseDFA(data []byte) ([][]string, error) {
 			}
 			saveRecord()
 			state = stateStart
+			recordStart = pos
 			// Initialize next record
 			if pos < length {
 				if capacityHint > 0 {
@@ -274,7 +278,7 @@ func ParseDFA(data []byte) ([][]string, error) {
 		if capacityHint == 0 && currentRecord != nil {
 			putFieldSlice(currentRecord)
 		}
-		return nil, errors.New("unclosed quoted field")
+		return nil, syntaxError(quoteStart, "unclosed quoted field")
 	}
 
 	// Save final field and record if any
@@ -0,0 +1,68 @@
+package fastparser
+
+import "errors"
+
+// ErrMappedFileClosed is returned by MappedFile methods called after Close.
+var ErrMappedFileClosed = errors.New("csv: mapped file is closed")
+
+// MappedFile holds the records of a memory-mapped CSV file. The fields point
+// into the mapping, so they stay valid only until Close is called.
+type MappedFile struct {
+	records [][][]byte
+	cleanup func()
+	closed  bool
+}
+
+// ParseFile memory-maps the file at path and parses it with ParseZeroCopy,
+// so large files are parsed without first being read onto the heap. Unquoted
+// fields and quoted fields without escaped quotes point directly into the
+// mapped region. On platforms without mmap the file is read into memory.
+//
+// Example usage:
+//
+//	f, err := ParseFile("large.csv")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//
+//	records, err := f.Records()
+//	// Process records...
+//
+// IMPORTANT: Copy any field that must outlive Close; using a field slice
+// after Close may crash the program.
+func ParseFile(path string) (*MappedFile, error) {
+	data, cleanup, err := MmapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := ParseZeroCopy(data)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	return &MappedFile{records: records, cleanup: cleanup}, nil
+}
+
+// Records returns the parsed records. It returns ErrMappedFileClosed after
+// Close.
+func (f *MappedFile) Records() ([][][]byte, error) {
+	if f.closed {
+		return nil, ErrMappedFileClosed
+	}
+	return f.records, nil
+}
+
+// Close unmaps the file. Records returned earlier must not be used
+// afterwards. Calling Close again returns ErrMappedFileClosed.
+func (f *MappedFile) Close() error {
+	if f.closed {
+		return ErrMappedFileClosed
+	}
+	f.closed = true
+	f.records = nil
+	f.cleanup()
+	return nil
+}
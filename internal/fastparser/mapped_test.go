@@ -0,0 +1,106 @@
+package fastparser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.csv")
+
+	content := []byte("name,note\nAlice,\"says \"\"hi\"\"\"\nBob,\"multi\nline\"\n")
+	if err := os.WriteFile(testFile, content, 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	f, err := ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	defer f.Close()
+
+	records, err := f.Records()
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+
+	expectedRecords := [][]string{
+		{"name", "note"},
+		{"Alice", `says "hi"`},
+		{"Bob", "multi\nline"},
+	}
+
+	if len(records) != len(expectedRecords) {
+		t.Fatalf("got %d records, want %d", len(records), len(expectedRecords))
+	}
+	for i, record := range records {
+		if len(record) != len(expectedRecords[i]) {
+			t.Fatalf("record[%d] has %d fields, want %d", i, len(record), len(expectedRecords[i]))
+		}
+		for j, field := range record {
+			if got, want := string(field), expectedRecords[i][j]; got != want {
+				t.Errorf("record[%d][%d] = %q, want %q", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestParseFile_EmptyFile(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "empty.csv")
+	if err := os.WriteFile(testFile, []byte{}, 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	f, err := ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	defer f.Close()
+
+	records, err := f.Records()
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("got %d records for empty file, want 0", len(records))
+	}
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	if _, err := ParseFile("/nonexistent/file.csv"); err == nil {
+		t.Error("ParseFile() on nonexistent file: error = nil, want error")
+	}
+
+	testFile := filepath.Join(t.TempDir(), "bad.csv")
+	if err := os.WriteFile(testFile, []byte("a,\"unclosed\n"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if _, err := ParseFile(testFile); err == nil {
+		t.Error("ParseFile() on malformed file: error = nil, want error")
+	}
+}
+
+func TestParseFile_UseAfterClose(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.csv")
+	if err := os.WriteFile(testFile, []byte("a,b\nc,d\n"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	f, err := ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if records, err := f.Records(); !errors.Is(err, ErrMappedFileClosed) || records != nil {
+		t.Errorf("Records() after Close = %v, %v; want nil, ErrMappedFileClosed", records, err)
+	}
+	if err := f.Close(); !errors.Is(err, ErrMappedFileClosed) {
+		t.Errorf("second Close() error = %v, want ErrMappedFileClosed", err)
+	}
+}
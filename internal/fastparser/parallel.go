@@ -0,0 +1,101 @@
+package fastparser
+
+import (
+	"bytes"
+	"sync"
+)
+
+// minParallelChunk is the smallest range handed to a worker. Smaller inputs are
+// parsed sequentially since goroutine overhead would outweigh the gain.
+const minParallelChunk = 64 * 1024
+
+// ParseParallel parses CSV data like Parse, splitting the input into up to
+// workers ranges that are parsed concurrently. Results are concatenated in
+// input order, so the output is identical to Parse.
+//
+// Ranges always end at a record boundary: the quote parity up to each
+// candidate split point is computed, and the split moves forward to the first
+// newline outside a quoted field. A quoted field is therefore never broken,
+// even when it contains newlines.
+//
+// If workers is less than 2 or the input is small, ParseParallel falls back to
+// Parse. Errors report positions relative to the range being parsed.
+func ParseParallel(data []byte, workers int) ([][]string, error) {
+	if maxWorkers := len(data) / minParallelChunk; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers < 2 {
+		return Parse(data)
+	}
+
+	bounds := splitRecords(data, workers)
+	results := make([][][]string, len(bounds)-1)
+	errs := make([]error, len(bounds)-1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(bounds)-1; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = Parse(data[bounds[i]:bounds[i+1]])
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for i, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+		total += len(results[i])
+	}
+
+	records := make([][]string, 0, total)
+	for _, r := range results {
+		records = append(records, r...)
+	}
+	return records, nil
+}
+
+// splitRecords returns the offsets at which data is split into at most n
+// ranges, including 0 and len(data). Every interior offset follows a newline
+// that is outside a quoted field.
+func splitRecords(data []byte, n int) []int {
+	bounds := []int{0}
+	target := len(data) / n
+	inQuotes := false
+	prev := 0
+
+	for i := 1; i < n; i++ {
+		candidate := i * target
+		if candidate <= prev {
+			continue
+		}
+
+		// Quote parity from the previous boundary to the candidate. Escaped
+		// quotes ("") toggle twice and so leave the parity unchanged.
+		if bytes.Count(data[prev:candidate], []byte{'"'})%2 == 1 {
+			inQuotes = !inQuotes
+		}
+
+		// Scan forward to the first newline outside a quoted field
+		pos := candidate
+		for pos < len(data) {
+			c := data[pos]
+			if c == '"' {
+				inQuotes = !inQuotes
+			} else if c == '\n' && !inQuotes {
+				break
+			}
+			pos++
+		}
+		if pos >= len(data) {
+			break
+		}
+
+		prev = pos + 1
+		bounds = append(bounds, prev)
+	}
+
+	return append(bounds, len(data))
+}
@@ -0,0 +1,60 @@
+package fastparser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseParallel_MatchesParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "unquoted", data: generateCSV(20000, 10, false)},
+		{name: "quoted", data: generateCSV(20000, 10, true)},
+		{name: "mixed", data: generateMixedCSV(20000, 8)},
+		{name: "multi-line quoted fields", data: []byte(strings.Repeat("id,\"line one\nline two, \"\"quoted\"\"\r\nline three\",tail\r\n", 20000))},
+		{name: "small input", data: []byte("a,b\nc,d\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for _, workers := range []int{0, 1, 2, 3, 8} {
+				got, err := ParseParallel(tt.data, workers)
+				if err != nil {
+					t.Fatalf("ParseParallel(%d) error = %v", workers, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("ParseParallel(%d) got %d records, differs from Parse (%d records)", workers, len(got), len(want))
+				}
+			}
+		})
+	}
+}
+
+func TestParseParallel_Error(t *testing.T) {
+	data := append(generateCSV(20000, 10, false), []byte("x,\"unclosed\n")...)
+	if _, err := ParseParallel(data, 4); err == nil {
+		t.Error("ParseParallel() expected error for unclosed quote")
+	}
+}
+
+func TestSplitRecords(t *testing.T) {
+	// Every split must land right after a newline outside quotes, so each
+	// range starts with the first field of a record
+	data := []byte(strings.Repeat("\"a\nb\",c\n", 1000))
+	bounds := splitRecords(data, 7)
+	if bounds[0] != 0 || bounds[len(bounds)-1] != len(data) {
+		t.Fatalf("splitRecords() bounds = %v, want to start at 0 and end at %d", bounds, len(data))
+	}
+	for _, b := range bounds[1 : len(bounds)-1] {
+		if data[b-1] != '\n' || data[b] != '"' {
+			t.Errorf("split at %d is not a record boundary", b)
+		}
+	}
+}
@@ -10,8 +10,12 @@
 package fastparser
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+
+	"github.com/shapestone/shape-csv/internal/bom"
+	csvparser "github.com/shapestone/shape-csv/internal/parser"
 )
 
 // Parse parses CSV data directly from bytes to [][]string without AST construction.
@@ -23,9 +27,14 @@ import (
 //   - Fields may be quoted with double quotes
 //   - Quoted fields may contain commas, newlines, and escaped quotes ("")
 //   - Empty lines are skipped
+//   - A leading UTF-8 byte order mark is skipped
+//
+// Input without any quote characters takes a faster path that splits fields
+// without tracking quote state.
 //
 // Returns a slice of records, where each record is a slice of field values.
 func Parse(data []byte) ([][]string, error) {
+	data = bom.Strip(data)
 	if len(data) == 0 {
 		return [][]string{}, nil
 	}
@@ -36,28 +45,177 @@ func Parse(data []byte) ([][]string, error) {
 		length: len(data),
 	}
 
+	if !containsQuote(data) {
+		return p.parseNoQuotes()
+	}
 	return p.parse()
 }
 
+// ParseWithMaxRecords is like Parse but returns an error as soon as the input
+// holds more than maxRecords records, without parsing the rest. A maxRecords
+// of 0 means no limit.
+func ParseWithMaxRecords(data []byte, maxRecords int) ([][]string, error) {
+	data = bom.Strip(data)
+	if len(data) == 0 {
+		return [][]string{}, nil
+	}
+
+	p := &parser{
+		data:       data,
+		pos:        0,
+		length:     len(data),
+		maxRecords: maxRecords,
+	}
+
+	if !containsQuote(data) {
+		return p.parseNoQuotes()
+	}
+	return p.parse()
+}
+
+// containsQuote reports whether data contains a double quote, scanning 8 bytes
+// at a time using SWAR.
+func containsQuote(data []byte) bool {
+	i := 0
+	for ; i+8 <= len(data); i += 8 {
+		if hasDelimiter(binary.LittleEndian.Uint64(data[i:i+8]), '"') {
+			return true
+		}
+	}
+	for ; i < len(data); i++ {
+		if data[i] == '"' {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWithLines is like Parse but also returns the 1-based line number on which
+// each record starts. Line breaks inside quoted fields are counted.
+func ParseWithLines(data []byte) ([][]string, []int, error) {
+	records, meta, err := parseWithMeta(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, meta.lines(), nil
+}
+
+// recordMeta holds per-record information gathered by parseWithMeta.
+type recordMeta struct {
+	// quoted reports, for each field, whether it was quoted in the input.
+	// This lets callers tell a quoted empty string ("") apart from an empty cell.
+	quoted [][]bool
+
+	// offsets holds the byte offset at which each record starts.
+	offsets []int64
+
+	// ends holds the byte offset at which each record's content ends, before
+	// its line terminator.
+	ends []int64
+
+	// data is the parsed input, used to derive line numbers on demand.
+	data []byte
+}
+
+// parseWithMeta is like Parse but also returns per-record metadata.
+func parseWithMeta(data []byte) ([][]string, *recordMeta, error) {
+	meta := &recordMeta{data: data}
+	stripped := bom.Strip(data)
+	if len(stripped) == 0 {
+		return [][]string{}, meta, nil
+	}
+
+	p := &parser{
+		data:   stripped,
+		pos:    0,
+		length: len(stripped),
+		meta:   meta,
+	}
+
+	records, err := p.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Report offsets relative to the original input, including any BOM
+	if shift := int64(len(data) - len(stripped)); shift > 0 {
+		for i := range meta.offsets {
+			meta.offsets[i] += shift
+			meta.ends[i] += shift
+		}
+	}
+	return records, meta, nil
+}
+
+// raw returns the source bytes of record i, excluding its line terminator.
+func (m *recordMeta) raw(i int) []byte {
+	return m.data[m.offsets[i]:m.ends[i]]
+}
+
+// lines converts record start offsets into 1-based line numbers, counting
+// LF, CRLF and lone CR line breaks in data, including those inside quoted
+// fields.
+func (m *recordMeta) lines() []int {
+	lines := make([]int, len(m.offsets))
+	line, last := 1, int64(0)
+	for i, off := range m.offsets {
+		line += countLines(m.data[last:off])
+		lines[i] = line
+		last = off
+	}
+	return lines
+}
+
 // parser implements a high-performance CSV parser.
 type parser struct {
 	data   []byte
 	pos    int
 	length int
+
+	// meta, if not nil, collects per-record metadata during parsing.
+	meta *recordMeta
+
+	// maxRecords, if positive, is the maximum number of records to parse.
+	maxRecords int
+
+	// backing and records, if not nil, are reused to hold the parsed fields
+	// and records. After parsing they hold the slices that were returned.
+	backing []string
+	records [][]string
 }
 
-// parse parses the entire CSV file using a single backing array for all fields.
-func (p *parser) parse() ([][]string, error) {
+// buffers returns empty slices to hold the fields and records of the input,
+// reusing p.backing and p.records when set.
+func (p *parser) buffers() ([]string, [][]string) {
+	if p.backing != nil {
+		return p.backing[:0], p.records[:0]
+	}
+
 	// Estimate capacity based on data size
 	// Assume average field size of 8 bytes + 1 comma = 9 bytes per field
 	estimatedFields := p.length / 9
 	if estimatedFields < 64 {
 		estimatedFields = 64
 	}
+	return make([]string, 0, estimatedFields), make([][]string, 0, estimatedFields/8)
+}
+
+// checkRecordLimit returns an error if another record, starting at pos, would
+// exceed maxRecords.
+func (p *parser) checkRecordLimit(count, pos int) error {
+	if p.maxRecords > 0 && count >= p.maxRecords {
+		return fmt.Errorf("record at position %d: %w (maximum of %d records)", pos, csvparser.ErrTooManyRecords, p.maxRecords)
+	}
+	return nil
+}
 
+// parse parses the entire CSV file using a single backing array for all fields.
+func (p *parser) parse() ([][]string, error) {
 	// Pre-allocate backing array and records slice
-	backingArray := make([]string, 0, estimatedFields)
-	records := make([][]string, 0, estimatedFields/8)
+	backingArray, records := p.buffers()
+
+	// Quoted flags, parallel to backingArray (only used when meta is set)
+	var quotedBacking []bool
 
 	// Track field count from first record
 	fieldsPerRecord := 0
@@ -70,10 +228,20 @@ func (p *parser) parse() ([][]string, error) {
 			continue
 		}
 
+		if err := p.checkRecordLimit(len(records), p.pos); err != nil {
+			return nil, err
+		}
+
 		recordStart = len(backingArray)
+		if p.meta != nil {
+			p.meta.offsets = append(p.meta.offsets, int64(p.pos))
+		}
 
 		// Parse all fields in this record
 		for {
+			if p.meta != nil {
+				quotedBacking = append(quotedBacking, p.pos < p.length && p.data[p.pos] == '"')
+			}
 			field, err := p.parseField()
 			if err != nil {
 				return nil, err
@@ -92,16 +260,22 @@ func (p *parser) parse() ([][]string, error) {
 			}
 
 			if c == '\r' || c == '\n' {
-				p.skipNewline()
 				break
 			}
 
 			return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
 		}
+		if p.meta != nil {
+			p.meta.ends = append(p.meta.ends, int64(p.pos))
+		}
+		p.skipNewline()
 
 		// Add the record as a slice of the backing array
 		recordEnd := len(backingArray)
 		records = append(records, backingArray[recordStart:recordEnd:recordEnd])
+		if p.meta != nil {
+			p.meta.quoted = append(p.meta.quoted, quotedBacking[recordStart:recordEnd:recordEnd])
+		}
 
 		// Use first record's field count for capacity hints
 		if fieldsPerRecord == 0 {
@@ -109,6 +283,50 @@ func (p *parser) parse() ([][]string, error) {
 		}
 	}
 
+	p.backing, p.records = backingArray, records
+	return records, nil
+}
+
+// parseNoQuotes parses input known to contain no quote characters. It produces
+// the same records as parse, but only looks for field and record separators.
+func (p *parser) parseNoQuotes() ([][]string, error) {
+	backingArray, records := p.buffers()
+	data := p.data
+
+	for p.pos < p.length {
+		// Skip empty lines
+		if c := data[p.pos]; c == '\r' || c == '\n' {
+			p.skipNewline()
+			continue
+		}
+
+		if err := p.checkRecordLimit(len(records), p.pos); err != nil {
+			return nil, err
+		}
+
+		recordStart := len(backingArray)
+		start := p.pos
+		for p.pos < p.length {
+			c := data[p.pos]
+			if c == ',' {
+				backingArray = append(backingArray, unsafeString(data[start:p.pos]))
+				p.pos++
+				start = p.pos
+				continue
+			}
+			if c == '\r' || c == '\n' {
+				break
+			}
+			p.pos++
+		}
+		backingArray = append(backingArray, unsafeString(data[start:p.pos]))
+		p.skipNewline()
+
+		recordEnd := len(backingArray)
+		records = append(records, backingArray[recordStart:recordEnd:recordEnd])
+	}
+
+	p.backing, p.records = backingArray, records
 	return records, nil
 }
 
@@ -1,8 +1,11 @@
 package fastparser
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+
+	csvparser "github.com/shapestone/shape-csv/internal/parser"
 )
 
 func TestFastParser_BasicParsing(t *testing.T) {
@@ -275,3 +278,134 @@ zzz,yyy,xxx`,
 		})
 	}
 }
+
+func TestParseWithLines(t *testing.T) {
+	input := "\ufeffa,b\n\n\"multi\nline\",x\r\nc,d\n"
+	records, lines, err := ParseWithLines([]byte(input))
+	if err != nil {
+		t.Fatalf("ParseWithLines() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("ParseWithLines() got %d records, want 3", len(records))
+	}
+	want := []int{1, 3, 5}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("ParseWithLines() lines = %v, want %v", lines, want)
+	}
+}
+
+func TestParse_NoQuotesFastPath(t *testing.T) {
+	// Inputs without quotes must parse the same on the fast path as on the
+	// general path
+	inputs := []string{
+		"a",
+		",",
+		"a,b,c\nd,e,f",
+		"a,b\r\nc,d\r\n",
+		"a,,c\n,,\n",
+		"a,b\n\n\r\n\nc,d\n",
+		"trailing,comma,\nx",
+		"lone\rcr,x\rnext",
+		string(mediumCSV),
+	}
+
+	for _, input := range inputs {
+		data := []byte(input)
+		if containsQuote(data) {
+			t.Fatalf("containsQuote(%q) = true, want false", input)
+		}
+		general := &parser{data: data, length: len(data)}
+		want, err := general.parse()
+		if err != nil {
+			t.Fatalf("parse(%q) error = %v", input, err)
+		}
+		fast := &parser{data: data, length: len(data)}
+		if got, _ := fast.parseNoQuotes(); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseNoQuotes(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestParse_QuoteFallback(t *testing.T) {
+	// A quote anywhere in the input, including past the first 8-byte block or in
+	// the trailing bytes, must fall back to the general parser
+	tests := []struct {
+		name    string
+		input   string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:  "quote in first block",
+			input: "\"a,b\",c",
+			want:  [][]string{{"a,b", "c"}},
+		},
+		{
+			name:  "quote after several blocks",
+			input: "aaaa,bbbb,cccc,dddd\n\"x\ny\",z",
+			want:  [][]string{{"aaaa", "bbbb", "cccc", "dddd"}, {"x\ny", "z"}},
+		},
+		{
+			name:    "quote in trailing bytes",
+			input:   "abcdefgh,ab\"",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !containsQuote([]byte(tt.input)) {
+				t.Fatalf("containsQuote(%q) = false, want true", tt.input)
+			}
+			got, err := Parse([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithMaxRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		maxRecords int
+		want       int
+		wantErr    bool
+	}{
+		{name: "unlimited", input: "a\nb\nc\n", maxRecords: 0, want: 3},
+		{name: "exactly at limit", input: "a\nb\nc\n", maxRecords: 3, want: 3},
+		{name: "blank lines do not count", input: "a\n\nb\n\n", maxRecords: 2, want: 2},
+		{name: "one over limit", input: "a\nb\nc\n", maxRecords: 2, wantErr: true},
+		{name: "quoted input at limit", input: "\"a\nx\"\nb\n", maxRecords: 2, want: 2},
+		{name: "quoted input over limit", input: "\"a\",1\n\"b\",2\n", maxRecords: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWithMaxRecords([]byte(tt.input), tt.maxRecords)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWithMaxRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, csvparser.ErrTooManyRecords) {
+				t.Errorf("ParseWithMaxRecords() error = %v, want ErrTooManyRecords", err)
+			}
+			if !tt.wantErr && len(got) != tt.want {
+				t.Errorf("ParseWithMaxRecords() got %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	// The Scanner stops with an error once the limit is exceeded
+	s := NewScanner([]byte("a\nb\nc\n"), ScannerOptions{MaxRecords: 2})
+	n := 0
+	for s.Scan() {
+		n++
+	}
+	if n != 2 || !errors.Is(s.Err(), csvparser.ErrTooManyRecords) {
+		t.Errorf("Scanner scanned %d records with error %v, want 2 records and a limit error", n, s.Err())
+	}
+}
@@ -0,0 +1,81 @@
+package fastparser
+
+import (
+	"github.com/shapestone/shape-csv/internal/bom"
+)
+
+// ReusableParser parses a series of inputs with the same rules as Parse, but
+// keeps the slices that hold fields and records between calls instead of
+// allocating new ones for every input. Services that parse many small
+// payloads can keep one ReusableParser per goroutine, or pool them with
+// sync.Pool, so that steady-state parsing allocates only for fields that
+// contain escaped quotes.
+//
+// The records returned by Parse share memory with the parser: they are valid
+// only until the next call to Parse or Reset. Copy any records you need to
+// keep. A ReusableParser must not be used by multiple goroutines at once.
+//
+// Example:
+//
+//	rp := fastparser.NewReusableParser()
+//	for _, payload := range payloads {
+//	    records, err := rp.Parse(payload)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    handle(records)
+//	}
+type ReusableParser struct {
+	backing []string   // fields of every record, retained across calls
+	records [][]string // records slicing backing, retained across calls
+}
+
+// NewReusableParser creates a ReusableParser with small initial buffers, which
+// grow to fit the largest input parsed.
+func NewReusableParser() *ReusableParser {
+	return &ReusableParser{
+		backing: make([]string, 0, 64),
+		records: make([][]string, 0, 8),
+	}
+}
+
+// Parse parses data like Parse, reusing the parser's buffers. The returned
+// records are invalidated by the next call to Parse or Reset.
+func (rp *ReusableParser) Parse(data []byte) ([][]string, error) {
+	data = bom.Strip(data)
+	if len(data) == 0 {
+		return rp.records[:0], nil
+	}
+
+	p := &parser{
+		data:    data,
+		pos:     0,
+		length:  len(data),
+		backing: rp.backing,
+		records: rp.records,
+	}
+
+	var records [][]string
+	var err error
+	if !containsQuote(data) {
+		records, err = p.parseNoQuotes()
+	} else {
+		records, err = p.parse()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rp.backing, rp.records = p.backing, p.records
+	return records, nil
+}
+
+// Reset drops the parser's references to the last input and its records,
+// keeping the capacity of its buffers, so that a parser held in a pool does
+// not keep a large payload alive.
+func (rp *ReusableParser) Reset() {
+	clear(rp.backing[:cap(rp.backing)])
+	clear(rp.records[:cap(rp.records)])
+	rp.backing = rp.backing[:0]
+	rp.records = rp.records[:0]
+}
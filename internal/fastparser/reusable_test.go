@@ -0,0 +1,91 @@
+package fastparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReusableParser_SequentialInputs(t *testing.T) {
+	inputs := []string{
+		"a,b,c\n1,2,3\n",
+		"\"quoted, field\",\"say \"\"hi\"\"\"\r\nx,y\r\n",
+		"",
+		"single",
+		"\xEF\xBB\xBFh1,h2\n\nv1,v2",
+		string(largeCSV),
+		"p,q\n",
+		"\"multi\nline\",z\n",
+	}
+
+	rp := NewReusableParser()
+	for i, input := range inputs {
+		want, err := Parse([]byte(input))
+		if err != nil {
+			t.Fatalf("input %d: Parse() error = %v", i, err)
+		}
+		got, err := rp.Parse([]byte(input))
+		if err != nil {
+			t.Fatalf("input %d: ReusableParser.Parse() error = %v", i, err)
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("input %d: ReusableParser.Parse() = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestReusableParser_ErrorThenReuse(t *testing.T) {
+	rp := NewReusableParser()
+	if _, err := rp.Parse([]byte("a,\"unclosed\n")); err == nil {
+		t.Fatal("Parse() with an unclosed quote: expected error")
+	}
+
+	got, err := rp.Parse([]byte("a,b\nc,d\n"))
+	if err != nil {
+		t.Fatalf("Parse() after error: error = %v", err)
+	}
+	if want := [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() after error = %q, want %q", got, want)
+	}
+}
+
+func TestReusableParser_Reset(t *testing.T) {
+	rp := NewReusableParser()
+	if _, err := rp.Parse(mediumCSV); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	capBefore := cap(rp.backing)
+
+	rp.Reset()
+	if cap(rp.backing) != capBefore {
+		t.Errorf("Reset() changed capacity from %d to %d", capBefore, cap(rp.backing))
+	}
+	for i, field := range rp.backing[:cap(rp.backing)] {
+		if field != "" {
+			t.Fatalf("Reset() left field %d = %q referencing the last input", i, field)
+		}
+	}
+
+	got, err := rp.Parse([]byte("x,y\n"))
+	if err != nil {
+		t.Fatalf("Parse() after Reset error = %v", err)
+	}
+	if want := [][]string{{"x", "y"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() after Reset = %q, want %q", got, want)
+	}
+}
+
+func TestReusableParser_NoAllocsWhenWarm(t *testing.T) {
+	rp := NewReusableParser()
+	if _, err := rp.Parse(mediumCSV); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := rp.Parse(mediumCSV); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("warm ReusableParser.Parse() allocated %v times per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,37 @@
+package fastparser
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// SyntaxError reports malformed CSV found by ParseDFA or ParseChunked, with
+// byte offsets into the parsed data so callers can report a line and column.
+type SyntaxError struct {
+	// Record is the number of the record containing the error (1-indexed).
+	Record int
+	// RecordStart is the byte offset where that record starts.
+	RecordStart int
+	// Offset is the byte offset of the error.
+	Offset int
+	// Msg describes the error.
+	Msg string
+}
+
+// Error returns the message with its byte offset.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Offset)
+}
+
+// Position returns the 1-indexed line where the record containing the error
+// starts, and the line and byte column of the error, in data.
+func (e *SyntaxError) Position(data []byte) (startLine, line, column int) {
+	startLine = 1 + countLines(data[:e.RecordStart])
+	before := data[:e.Offset]
+	line = 1 + countLines(before)
+	column = len(before) + 1
+	if nl := bytes.LastIndexAny(before, "\r\n"); nl >= 0 {
+		column = len(before) - nl
+	}
+	return startLine, line, column
+}
@@ -1,11 +1,14 @@
 package fastparser
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 )
 
 // fieldSetter is a pre-computed function that sets a field value from a string.
@@ -14,31 +17,97 @@ type fieldSetter func(field reflect.Value, value string, rowIdx, colIdx int) err
 
 // structInfo holds cached metadata about a struct type for a specific header layout.
 type structInfo struct {
-	// fieldMap maps column index to struct field index
-	fieldMap map[int]int
+	// fieldMap maps column index to the index path of a struct field, which
+	// has more than one element for fields of embedded structs
+	fieldMap map[int][]int
 
 	// setters maps column index to a pre-computed setter function
 	setters map[int]fieldSetter
+
+	// offsetField and lineField are the index paths of the fields tagged
+	// ",offset" and ",line", or nil if there are none
+	offsetField []int
+	lineField   []int
+
+	// rawField is the index path of the field tagged ",raw", which receives
+	// the record's source bytes, or nil if there is none
+	rawField []int
+
+	// positional is set when fields are bound by column index tags, such as
+	// `csv:"3"`, rather than by header name
+	positional bool
+
+	// required lists, in column order, the columns of fields tagged
+	// "required", which must not be empty or null in any row
+	required []requiredColumn
+
+	// err reports an invalid combination of struct tags, or a required
+	// column missing from the header
+	err error
+}
+
+// requiredColumn is a column bound to a field tagged "required".
+type requiredColumn struct {
+	col   int
+	field string // struct field name, for error messages
 }
 
-// cacheKey uniquely identifies a struct type + header combination
+// check returns an error if the column is missing, empty or one of the
+// opts.NullValues tokens in row, the 0-based data row rowIdx.
+func (rc requiredColumn) check(row []string, rowIdx int, opts *DecodeOptions) error {
+	if rc.col >= len(row) || row[rc.col] == "" {
+		return fmt.Errorf("csv: required field %s is empty at row %d, column %d", rc.field, rowIdx+1, rc.col)
+	}
+	if len(opts.NullValues) > 0 && opts.isNull(row[rc.col]) {
+		return fmt.Errorf("csv: required field %s is null at row %d, column %d", rc.field, rowIdx+1, rc.col)
+	}
+	return nil
+}
+
+// cacheKey uniquely identifies a struct type + header + decode options combination
 type cacheKey struct {
 	typ        reflect.Type
 	headerHash string
+	opts       optionsKey
+}
+
+// optionsKey is the comparable subset of DecodeOptions that affects the
+// cached field map and setters. Null handling is applied per call.
+type optionsKey struct {
+	decimalSeparator   rune
+	thousandsSeparator rune
+	currencySymbol     string
+	strictNumeric      bool
+	fallbackTag        string
+	caseSensitive      bool
 }
 
 // Global cache for struct metadata
 var (
-	typeCache sync.Map // map[cacheKey]*structInfo
+	typeCache sync.Map // map[cacheKey]*structInfo, map[writeColumnsKey][]WriteColumn
 )
 
 // getStructInfo retrieves or computes struct metadata for the given type and headers.
 // Results are cached for performance.
 func getStructInfo(structType reflect.Type, headers []string) *structInfo {
+	return getStructInfoWithOptions(structType, headers, DecodeOptions{})
+}
+
+// getStructInfoWithOptions is like getStructInfo but builds setters that honor
+// the given decode options.
+func getStructInfoWithOptions(structType reflect.Type, headers []string, opts DecodeOptions) *structInfo {
 	// Generate cache key
 	key := cacheKey{
 		typ:        structType,
 		headerHash: hashHeaders(headers),
+		opts: optionsKey{
+			decimalSeparator:   opts.DecimalSeparator,
+			thousandsSeparator: opts.ThousandsSeparator,
+			currencySymbol:     opts.CurrencySymbol,
+			strictNumeric:      opts.StrictNumeric,
+			fallbackTag:        opts.FallbackTag,
+			caseSensitive:      opts.CaseSensitiveHeaders,
+		},
 	}
 
 	// Check cache first
@@ -47,7 +116,7 @@ func getStructInfo(structType reflect.Type, headers []string) *structInfo {
 	}
 
 	// Compute struct info
-	info := computeStructInfo(structType, headers)
+	info := computeStructInfo(structType, headers, opts)
 
 	// Store in cache
 	typeCache.Store(key, info)
@@ -56,15 +125,234 @@ func getStructInfo(structType reflect.Type, headers []string) *structInfo {
 }
 
 // computeStructInfo builds the field map and setters for a struct type.
-func computeStructInfo(structType reflect.Type, headers []string) *structInfo {
+func computeStructInfo(structType reflect.Type, headers []string, opts DecodeOptions) *structInfo {
 	info := &structInfo{
-		fieldMap: make(map[int]int),
+		fieldMap: make(map[int][]int),
 		setters:  make(map[int]fieldSetter),
 	}
 
-	// Build a map of CSV column names to struct field indices
-	csvNameToFieldIdx := make(map[string]int)
+	b, err := bindStruct(structType, opts)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	fields := b.fields
+	info.offsetField = b.offsetField
+	info.lineField = b.lineField
+	info.rawField = b.rawField
+
+	// Index-tagged structs bind to fixed positions regardless of the header
+	if len(b.byIndex) > 0 {
+		info.positional = true
+		for colIdx, i := range b.byIndex {
+			info.fieldMap[colIdx] = fields[i].index
+			info.setters[colIdx] = createSetter(fields[i].field.Type, fields[i].opts, opts)
+			if fields[i].opts.required {
+				info.required = append(info.required, requiredColumn{col: colIdx, field: fields[i].field.Name})
+			}
+		}
+		sort.Slice(info.required, func(a, b int) bool {
+			return info.required[a].col < info.required[b].col
+		})
+		return info
+	}
+
+	// Match headers to fields and create setters. When several columns match
+	// a field, such as a name and its alias, the first one wins.
+	matched := make(map[int]bool)
+	for colIdx, header := range headers {
+		if i, ok := b.byName[opts.headerKey(header)]; ok && !matched[i] {
+			matched[i] = true
+
+			// Map column to field
+			info.fieldMap[colIdx] = fields[i].index
+
+			// Create pre-computed setter for this field
+			info.setters[colIdx] = createSetter(fields[i].field.Type, fields[i].opts, opts)
+
+			if fields[i].opts.required {
+				info.required = append(info.required, requiredColumn{col: colIdx, field: fields[i].field.Name})
+			}
+		}
+	}
+
+	// Required fields must have a column in the header
+	for i := range fields {
+		if name, ok := b.columnNames[i]; ok && fields[i].opts.required && !matched[i] {
+			info.err = fmt.Errorf("csv: required column %q for field %s is missing from the header", name, fields[i].field.Name)
+			return info
+		}
+	}
+
+	return info
+}
+
+// boundField is a field found by collectFields with its parsed tag options.
+type boundField struct {
+	structField
+	opts fieldOptions
+}
+
+// structBinding records how the fields of a struct type receive their
+// values, independent of any header.
+type structBinding struct {
+	fields []boundField
+
+	// byName maps the header key of each column name and alias to a
+	// position in fields; a shallower field wins over an embedded one
+	byName map[string]int
+
+	// byIndex maps the column index of each index-tagged field to a
+	// position in fields
+	byIndex map[int]int
+
+	// columnNames holds the column name of each field that byName maps a
+	// name to, including the prefix of any "recurse" field
+	columnNames map[int]string
+
+	offsetField []int
+	lineField   []int
+	rawField    []int
+}
+
+// bindStruct collects the fields of structType and works out how each is
+// bound: by column name, by column index, or to the record's position or
+// source. It returns an error for an invalid combination of tags.
+func bindStruct(structType reflect.Type, opts DecodeOptions) (*structBinding, error) {
+	collected := collectFields(structType, nil, "", opts, nil, map[reflect.Type]bool{structType: true})
+
+	b := &structBinding{
+		fields:      make([]boundField, len(collected)),
+		byName:      make(map[string]int),
+		byIndex:     make(map[int]int),
+		columnNames: make(map[int]string),
+	}
+
+	for i, sf := range collected {
+		field := sf.field
+		b.fields[i].structField = sf
+
+		// Get CSV column name from tag or field name
+		csvName := field.Name
+		if sf.tag != "" {
+			// Handle "name,option1,option2" format
+			name, fopts := parseFieldTag(sf.tag)
+			if name != "" {
+				csvName = name
+			}
+			b.fields[i].opts = fopts
+
+			// A numeric name binds the field to a column index
+			if colIdx, ok := parseColumnIndex(name); ok {
+				if prev, dup := b.byIndex[colIdx]; dup {
+					return nil, fmt.Errorf("csv: %s: fields %s and %s are both tagged with column index %d",
+						structType, collected[prev].field.Name, field.Name, colIdx)
+				}
+				b.byIndex[colIdx] = i
+				continue
+			}
+
+			// Position fields are populated from the parser, not a column
+			if isIntKind(field.Type.Kind()) {
+				if fopts.offset {
+					b.offsetField = sf.index
+					continue
+				}
+				if fopts.line {
+					b.lineField = sf.index
+					continue
+				}
+			}
+			if fopts.raw && isRawType(field.Type) {
+				b.rawField = sf.index
+				continue
+			}
+		}
 
+		// Store the name and any aliases with lowercase for case-insensitive
+		// matching unless CaseSensitiveHeaders is set. A shallower field wins
+		// over one promoted from an embedded struct.
+		for j, name := range append([]string{csvName}, b.fields[i].opts.aliases...) {
+			key := opts.headerKey(sf.prefix + name)
+			if prev, dup := b.byName[key]; dup && len(collected[prev].index) < len(sf.index) {
+				continue
+			}
+			b.byName[key] = i
+			if j == 0 {
+				b.columnNames[i] = sf.prefix + csvName
+			}
+		}
+	}
+
+	if len(b.byIndex) > 0 && len(b.byName) > 0 {
+		return nil, fmt.Errorf("csv: %s mixes column index and column name tags", structType)
+	}
+	return b, nil
+}
+
+// StructColumn is a column that a struct field is read from when decoding.
+type StructColumn struct {
+	Field   string   // Go field name
+	Name    string   // column name, prefixed by the names of enclosing "recurse" fields
+	Aliases []string // other column names read into the field, with the same prefix
+}
+
+// StructColumns returns, in field order, the columns that Unmarshal matches
+// against the header when decoding into structType, following the same
+// embedding, "recurse", alias and FallbackTag rules. A field shadowed by a
+// shallower one with the same column name is not listed. positional reports
+// that the fields are bound by column index tags, such as `csv:"3"`, instead
+// of by name, in which case no columns are returned. It returns an error for
+// an invalid combination of tags.
+func StructColumns(structType reflect.Type, opts DecodeOptions) (columns []StructColumn, positional bool, err error) {
+	b, err := bindStruct(structType, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(b.byIndex) > 0 {
+		return nil, true, nil
+	}
+
+	for i, f := range b.fields {
+		name, ok := b.columnNames[i]
+		if !ok {
+			continue
+		}
+		col := StructColumn{Field: f.field.Name, Name: name}
+		for _, alias := range f.opts.aliases {
+			col.Aliases = append(col.Aliases, f.prefix+alias)
+		}
+		columns = append(columns, col)
+	}
+	return columns, false, nil
+}
+
+// WriteColumn is a column that a struct field is written to when encoding.
+type WriteColumn struct {
+	Name      string // column name from the csv tag, or the Go field name
+	Index     int    // index of the field in the struct
+	OmitEmpty bool   // tagged "omitempty"
+	Percent   bool   // tagged "percent"
+	Split     string // separator joining the values of a slice field
+}
+
+// writeColumnsKey is the typeCache key for the columns written for a struct
+// type, which do not depend on a header or decode options.
+type writeColumnsKey struct {
+	typ reflect.Type
+}
+
+// WriteColumns returns the columns that Marshal writes for structType, sorted
+// by name for deterministic output. Every exported field is a column except
+// those tagged "-", "offset", "line" or "raw"; embedded structs are not
+// flattened. The result is cached and must not be modified.
+func WriteColumns(structType reflect.Type) []WriteColumn {
+	key := writeColumnsKey{typ: structType}
+	if cached, ok := typeCache.Load(key); ok {
+		return cached.([]WriteColumn)
+	}
+
+	var columns []WriteColumn
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
 
@@ -73,41 +361,250 @@ func computeStructInfo(structType reflect.Type, headers []string) *structInfo {
 			continue
 		}
 
-		// Get CSV column name from tag or field name
-		csvName := field.Name
 		tag := field.Tag.Get("csv")
-		if tag != "" && tag != "-" {
-			// Handle "name,omitempty" format
-			if idx := strings.IndexByte(tag, ','); idx >= 0 {
-				csvName = tag[:idx]
-			} else {
-				csvName = tag
+		if tag == "-" {
+			continue
+		}
+		name, opts := parseFieldTag(tag)
+		if opts.offset || opts.line || opts.raw {
+			// Populated from the record's position or source when decoding
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		split := opts.split
+		if split == "" {
+			split = defaultSplitSeparator
+		}
+
+		columns = append(columns, WriteColumn{
+			Name:      name,
+			Index:     i,
+			OmitEmpty: opts.omitEmpty,
+			Percent:   opts.percent,
+			Split:     split,
+		})
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Name < columns[j].Name
+	})
+
+	typeCache.Store(key, columns)
+	return columns
+}
+
+// structField is a field that can receive a column, found by collectFields.
+type structField struct {
+	field  reflect.StructField
+	index  []int  // index path from the decoded struct
+	tag    string // csv tag, or the FallbackTag when there is no csv tag
+	prefix string // dotted column name prefix of a nested "recurse" field
+}
+
+// collectFields appends the exported fields of structType to fields in
+// declaration order. The fields of an embedded struct, or pointer to struct,
+// without a csv name are flattened in place of the embedded field, at any
+// depth. The fields of a struct tagged "recurse" are flattened too, with its
+// column name and a dot prefixed to theirs, as FlattenStruct names them.
+// index and prefix are the path and column name prefix of structType, and
+// visiting guards against embedding cycles.
+func collectFields(structType reflect.Type, index []int, prefix string, opts DecodeOptions, fields []structField, visiting map[reflect.Type]bool) []structField {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag, ok := field.Tag.Lookup("csv")
+		if !ok && opts.FallbackTag != "" {
+			tag = field.Tag.Get(opts.FallbackTag)
+		}
+		if tag == "-" {
+			// Always ignore this field
+			continue
+		}
+
+		path := make([]int, len(index)+1)
+		copy(path, index)
+		path[len(index)] = i
+
+		if field.Anonymous {
+			t := field.Type
+			isPtr := t.Kind() == reflect.Ptr
+			if isPtr {
+				t = t.Elem()
+			}
+			name, _ := parseFieldTag(tag)
+			if t.Kind() == reflect.Struct && name == "" {
+				// An unexported embedded pointer cannot be allocated
+				if isPtr && field.PkgPath != "" || visiting[t] {
+					continue
+				}
+				visiting[t] = true
+				fields = collectFields(t, path, prefix, opts, fields, visiting)
+				delete(visiting, t)
+				continue
+			}
+		}
+
+		// Skip unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name, fopts := parseFieldTag(tag); fopts.recurse {
+			t := field.Type
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Struct && !visiting[t] {
+				if name == "" {
+					name = field.Name
+				}
+				visiting[t] = true
+				fields = collectFields(t, path, prefix+name+".", opts, fields, visiting)
+				delete(visiting, t)
+				continue
 			}
 		}
+		fields = append(fields, structField{field: field, index: path, tag: tag, prefix: prefix})
+	}
+	return fields
+}
+
+// fieldOptions holds the csv tag options that change how a field is decoded.
+type fieldOptions struct {
+	// percent decodes "45%" as 0.45 into a float field
+	percent bool
+
+	// currency decodes "$1,234.56" as 1234.56 into a float field
+	currency bool
+
+	// intbool decodes any integer into a bool field, nonzero meaning true
+	intbool bool
+
+	// offset and line populate an integer field with the record's starting
+	// byte offset or line number instead of a column value
+	offset bool
+	line   bool
+
+	// raw populates a string or []byte field with the record's source bytes
+	raw bool
+
+	// recurse maps the fields of a nested struct to dotted columns, such as
+	// "address.city"
+	recurse bool
+
+	// split is the separator between the values of a slice field, set with
+	// the "split=" option; empty means defaultSplitSeparator
+	split string
+
+	// aliases are other column names matched to the field, set with the
+	// "alias=" option as a semicolon-separated list
+	aliases []string
+
+	// required makes decoding fail when the field's column is missing from
+	// the header or empty in a row
+	required bool
 
-		// Store with lowercase for case-insensitive matching
-		csvNameToFieldIdx[strings.ToLower(csvName)] = i
+	// omitEmpty writes an empty cell for a zero value when encoding
+	omitEmpty bool
+}
+
+// defaultSplitSeparator separates the values of a slice field without a
+// "split=" tag option. It matches csv.MultiValueSeparator.
+const defaultSplitSeparator = "|"
+
+// isIntKind reports whether k is a signed integer kind.
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
 	}
+	return false
+}
 
-	// Match headers to fields and create setters
-	for colIdx, header := range headers {
-		headerLower := strings.ToLower(header)
-		if fieldIdx, ok := csvNameToFieldIdx[headerLower]; ok {
-			// Map column to field
-			info.fieldMap[colIdx] = fieldIdx
+// isRawType reports whether t can hold a raw record: string or []byte.
+func isRawType(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
 
-			// Create pre-computed setter for this field
-			field := structType.Field(fieldIdx)
-			info.setters[colIdx] = createSetter(field.Type)
+// parseColumnIndex reports whether a tag name is a column index such as "3".
+func parseColumnIndex(name string) (int, bool) {
+	if name == "" {
+		return 0, false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] < '0' || name[i] > '9' {
+			return 0, false
 		}
 	}
+	idx, err := strconv.Atoi(name)
+	return idx, err == nil
+}
 
-	return info
+// parseFieldTag splits a csv struct tag into its column name and decode options.
+// Format: "name" or "name,option1,option2". Unknown options are ignored.
+func parseFieldTag(tag string) (string, fieldOptions) {
+	var opts fieldOptions
+	parts := strings.Split(tag, ",")
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if sep, ok := strings.CutPrefix(part, "split="); ok {
+			opts.split = sep
+			continue
+		}
+		if list, ok := strings.CutPrefix(part, "alias="); ok {
+			for _, alias := range strings.Split(list, ";") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					opts.aliases = append(opts.aliases, alias)
+				}
+			}
+			continue
+		}
+		switch part {
+		case "percent":
+			opts.percent = true
+		case "currency":
+			opts.currency = true
+		case "intbool":
+			opts.intbool = true
+		case "offset":
+			opts.offset = true
+		case "line":
+			opts.line = true
+		case "raw":
+			opts.raw = true
+		case "recurse":
+			opts.recurse = true
+		case "required":
+			opts.required = true
+		case "omitempty":
+			opts.omitEmpty = true
+		}
+	}
+	return parts[0], opts
 }
 
 // createSetter returns a pre-computed setter function for the given field type.
 // This avoids the need for a switch statement on every field set operation.
-func createSetter(fieldType reflect.Type) fieldSetter {
+func createSetter(fieldType reflect.Type, opts fieldOptions, dopts DecodeOptions) fieldSetter {
+	if k := fieldType.Kind(); k == reflect.Float32 || k == reflect.Float64 {
+		if opts.percent {
+			return createPercentSetter(dopts)
+		}
+		if opts.currency {
+			return createCurrencySetter(dopts)
+		}
+	}
+	if fieldType.Kind() == reflect.Bool && opts.intbool {
+		return createIntBoolSetter(dopts)
+	}
+
+	// Types implementing encoding.TextUnmarshaler decode themselves
+	if fieldType.Kind() != reflect.Ptr && reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+		return createTextUnmarshalerSetter()
+	}
+
 	switch fieldType.Kind() {
 	case reflect.String:
 		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
@@ -117,6 +614,10 @@ func createSetter(fieldType reflect.Type) fieldSetter {
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+			value, err := numericValue(value, dopts, rowIdx, colIdx)
+			if err != nil {
+				return err
+			}
 			if value == "" {
 				field.SetInt(0)
 				return nil
@@ -134,6 +635,10 @@ func createSetter(fieldType reflect.Type) fieldSetter {
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+			value, err := numericValue(value, dopts, rowIdx, colIdx)
+			if err != nil {
+				return err
+			}
 			if value == "" {
 				field.SetUint(0)
 				return nil
@@ -151,11 +656,15 @@ func createSetter(fieldType reflect.Type) fieldSetter {
 
 	case reflect.Float32, reflect.Float64:
 		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+			value, err := numericValue(value, dopts, rowIdx, colIdx)
+			if err != nil {
+				return err
+			}
 			if value == "" {
 				field.SetFloat(0)
 				return nil
 			}
-			f, err := strconv.ParseFloat(value, 64)
+			f, err := strconv.ParseFloat(normalizeDecimal(value, dopts.DecimalSeparator, dopts.ThousandsSeparator), 64)
 			if err != nil {
 				return fmt.Errorf("csv: cannot parse %q as float at row %d, column %d: %v", value, rowIdx+1, colIdx, err)
 			}
@@ -166,6 +675,23 @@ func createSetter(fieldType reflect.Type) fieldSetter {
 			return nil
 		}
 
+	case reflect.Ptr:
+		elemType := fieldType.Elem()
+		elemSetter := createSetter(elemType, opts, dopts)
+		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+			ptr := reflect.New(elemType)
+			if err := elemSetter(ptr.Elem(), value, rowIdx, colIdx); err != nil {
+				return err
+			}
+			field.Set(ptr)
+			return nil
+		}
+
+	case reflect.Slice:
+		if fieldType.Elem().Kind() != reflect.Uint8 {
+			return createSliceSetter(fieldType, opts, dopts)
+		}
+
 	case reflect.Bool:
 		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
 			if value == "" {
@@ -179,14 +705,209 @@ func createSetter(fieldType reflect.Type) fieldSetter {
 			field.SetBool(b)
 			return nil
 		}
+	}
 
-	default:
-		return func(field reflect.Value, value string, rowIdx, colIdx int) error {
-			return fmt.Errorf("csv: unsupported field type %s at row %d, column %d", field.Type(), rowIdx+1, colIdx)
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		return fmt.Errorf("csv: unsupported field type %s at row %d, column %d", field.Type(), rowIdx+1, colIdx)
+	}
+}
+
+// createSliceSetter returns a setter for a slice field that splits the value
+// on opts.split, or defaultSplitSeparator, and decodes each element with the
+// setter for the element type. An empty value gives an empty, non-nil slice.
+func createSliceSetter(fieldType reflect.Type, opts fieldOptions, dopts DecodeOptions) fieldSetter {
+	sep := opts.split
+	if sep == "" {
+		sep = defaultSplitSeparator
+	}
+	elemSetter := createSetter(fieldType.Elem(), fieldOptions{}, dopts)
+
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		if value == "" {
+			field.Set(reflect.MakeSlice(fieldType, 0, 0))
+			return nil
+		}
+		parts := strings.Split(value, sep)
+		slice := reflect.MakeSlice(fieldType, len(parts), len(parts))
+		for i, part := range parts {
+			if err := elemSetter(slice.Index(i), part, rowIdx, colIdx); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+}
+
+// textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// createTextUnmarshalerSetter returns a setter that decodes a field by calling
+// its UnmarshalText method. An empty cell leaves the field at its zero value.
+// The field must be addressable, which holds for fields of the struct values
+// built during Unmarshal.
+func createTextUnmarshalerSetter() fieldSetter {
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		if value == "" {
+			// Empty cells leave the zero value, as for other field types
+			return nil
+		}
+		u := field.Addr().Interface().(encoding.TextUnmarshaler)
+		if err := u.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("csv: cannot unmarshal %q into %s at row %d, column %d: %w", value, field.Type(), rowIdx+1, colIdx, err)
+		}
+		return nil
+	}
+}
+
+// createPercentSetter returns a setter for float fields tagged with the "percent"
+// option. A trailing '%' is optional and the value is divided by 100, so "45%"
+// decodes to 0.45.
+func createPercentSetter(dopts DecodeOptions) fieldSetter {
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		value, err := numericValue(value, dopts, rowIdx, colIdx)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			field.SetFloat(0)
+			return nil
+		}
+		f, err := parsePercent(value)
+		if err != nil {
+			return fmt.Errorf("csv: cannot parse %q as percent at row %d, column %d: %v", value, rowIdx+1, colIdx, err)
+		}
+		if field.OverflowFloat(f) {
+			return fmt.Errorf("csv: value %v overflows %s at row %d, column %d", f, field.Type(), rowIdx+1, colIdx)
+		}
+		field.SetFloat(f)
+		return nil
+	}
+}
+
+// createIntBoolSetter returns a setter for bool fields tagged with the
+// "intbool" option, as used for tinyint flags in SQL exports. The cell must be
+// an integer: 0 decodes to false and any other value, such as 2 or -1, to true.
+func createIntBoolSetter(dopts DecodeOptions) fieldSetter {
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		value, err := numericValue(value, dopts, rowIdx, colIdx)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			field.SetBool(false)
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("csv: cannot parse %q as intbool at row %d, column %d: %v", value, rowIdx+1, colIdx, err)
 		}
+		field.SetBool(i != 0)
+		return nil
 	}
 }
 
+// parsePercent parses a percentage such as "45%" or "12.5" into a fraction.
+// The division by 100 is done in decimal where possible so that "45%" yields
+// exactly the same float64 as parsing "0.45".
+func parsePercent(value string) (float64, error) {
+	s := strings.TrimSuffix(value, "%")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.ContainsAny(s, "eExXpP") {
+		if scaled, err := strconv.ParseFloat(s+"e-2", 64); err == nil {
+			return scaled, nil
+		}
+	}
+	return f / 100, nil
+}
+
+// numericValue checks a numeric cell before parsing. With StrictNumeric any
+// whitespace is an error; otherwise the value is returned unchanged.
+func numericValue(value string, dopts DecodeOptions, rowIdx, colIdx int) (string, error) {
+	if dopts.StrictNumeric && strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("csv: numeric value %q contains whitespace at row %d, column %d", value, rowIdx+1, colIdx)
+	}
+	return value, nil
+}
+
+// createCurrencySetter returns a setter for float fields tagged with the
+// "currency" option. The currency symbol, surrounding whitespace and thousands
+// separators are removed before parsing, so "$1,234.56" decodes to 1234.56.
+// With StrictNumeric, whitespace anywhere in the cell is an error instead.
+func createCurrencySetter(dopts DecodeOptions) fieldSetter {
+	return func(field reflect.Value, value string, rowIdx, colIdx int) error {
+		value, err := numericValue(value, dopts, rowIdx, colIdx)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			field.SetFloat(0)
+			return nil
+		}
+		f, err := parseCurrency(value, dopts)
+		if err != nil {
+			return fmt.Errorf("csv: cannot parse %q as currency at row %d, column %d: %v", value, rowIdx+1, colIdx, err)
+		}
+		if field.OverflowFloat(f) {
+			return fmt.Errorf("csv: value %v overflows %s at row %d, column %d", f, field.Type(), rowIdx+1, colIdx)
+		}
+		field.SetFloat(f)
+		return nil
+	}
+}
+
+// parseCurrency parses a currency amount such as "$1,234.56", "-$5" or
+// "1.234,56 €" using the separators and symbol configured in dopts.
+func parseCurrency(value string, dopts DecodeOptions) (float64, error) {
+	s := value
+	if dopts.CurrencySymbol != "" {
+		s = strings.ReplaceAll(s, dopts.CurrencySymbol, "")
+	} else {
+		s = strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Sc, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	thousands := dopts.ThousandsSeparator
+	if thousands == 0 {
+		thousands = ','
+		if dopts.DecimalSeparator == ',' {
+			thousands = '.'
+		}
+	}
+	return strconv.ParseFloat(normalizeDecimal(s, dopts.DecimalSeparator, thousands), 64)
+}
+
+// normalizeDecimal rewrites a locale-formatted number into the form accepted by
+// strconv.ParseFloat by removing thousands separators and replacing the decimal
+// separator with '.'. Zero separators leave the value unchanged.
+func normalizeDecimal(value string, decimal, thousands rune) string {
+	if (decimal == 0 || decimal == '.') && thousands == 0 {
+		return value
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case thousands != 0 && r == thousands:
+			return -1
+		case decimal != 0 && r == decimal:
+			return '.'
+		}
+		return r
+	}, value)
+}
+
 // hashHeaders creates a stable hash string from headers for cache keying.
 // This ensures different header orderings produce different cache keys.
 func hashHeaders(headers []string) string {
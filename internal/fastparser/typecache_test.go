@@ -1,8 +1,12 @@
 package fastparser
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestGetStructInfo tests basic struct info retrieval
@@ -38,12 +42,12 @@ func TestGetStructInfo(t *testing.T) {
 	}
 
 	// Check that column 0 maps to field index 0 (Name)
-	if fieldIdx, ok := info1.fieldMap[0]; !ok || fieldIdx != 0 {
+	if fieldIdx, ok := info1.fieldMap[0]; !ok || !reflect.DeepEqual(fieldIdx, []int{0}) {
 		t.Errorf("fieldMap[0] = %v, want 0", fieldIdx)
 	}
 
 	// Check that column 1 maps to field index 1 (Age)
-	if fieldIdx, ok := info1.fieldMap[1]; !ok || fieldIdx != 1 {
+	if fieldIdx, ok := info1.fieldMap[1]; !ok || !reflect.DeepEqual(fieldIdx, []int{1}) {
 		t.Errorf("fieldMap[1] = %v, want 1", fieldIdx)
 	}
 
@@ -140,11 +144,36 @@ func TestStructInfoCaseInsensitive(t *testing.T) {
 		t.Errorf("fieldMap has %d entries, want 2", len(info.fieldMap))
 	}
 
-	if fieldIdx, ok := info.fieldMap[0]; !ok || fieldIdx != 0 {
+	if fieldIdx, ok := info.fieldMap[0]; !ok || !reflect.DeepEqual(fieldIdx, []int{0}) {
 		t.Errorf("fieldMap[0] = %v, want 0 (case-insensitive match failed)", fieldIdx)
 	}
 }
 
+// TestStructInfoAliases tests that alias tag names map to the field and that
+// the first matching column wins
+func TestStructInfoAliases(t *testing.T) {
+	type Contact struct {
+		Name  string `csv:"name"`
+		Email string `csv:"email,alias=e-mail; email_address"`
+	}
+	structType := reflect.TypeOf(Contact{})
+
+	for _, header := range []string{"email", "E-Mail", "EMAIL_ADDRESS"} {
+		info := getStructInfo(structType, []string{"name", header})
+		if fieldIdx, ok := info.fieldMap[1]; !ok || !reflect.DeepEqual(fieldIdx, []int{1}) {
+			t.Errorf("header %q: fieldMap[1] = %v, want [1]", header, fieldIdx)
+		}
+	}
+
+	info := getStructInfo(structType, []string{"e-mail", "name", "email"})
+	if fieldIdx, ok := info.fieldMap[0]; !ok || !reflect.DeepEqual(fieldIdx, []int{1}) {
+		t.Errorf("fieldMap[0] = %v, want [1]", fieldIdx)
+	}
+	if _, ok := info.fieldMap[2]; ok {
+		t.Error("fieldMap[2] is set; the first matching column should win")
+	}
+}
+
 // TestStructInfoUnexportedFields tests that unexported fields are skipped
 func TestStructInfoUnexportedFields(t *testing.T) {
 	type Record struct {
@@ -169,7 +198,7 @@ func TestStructInfoUnexportedFields(t *testing.T) {
 
 	// Column 1 (age) should NOT map to unexported field
 	if fieldIdx, ok := info.fieldMap[1]; ok {
-		t.Errorf("fieldMap[1] = %d, want no mapping for unexported field", fieldIdx)
+		t.Errorf("fieldMap[1] = %v, want no mapping for unexported field", fieldIdx)
 	}
 }
 
@@ -194,7 +223,7 @@ func TestStructInfoMissingColumns(t *testing.T) {
 
 	// City field should not be in the map
 	for colIdx, fieldIdx := range info.fieldMap {
-		if fieldIdx == 2 { // Field index 2 is City
+		if fieldIdx[0] == 2 { // Field index 2 is City
 			t.Errorf("fieldMap[%d] maps to City field, should not be mapped", colIdx)
 		}
 	}
@@ -240,11 +269,11 @@ func TestStructInfoNoTags(t *testing.T) {
 		t.Errorf("fieldMap has %d entries, want 2", len(info.fieldMap))
 	}
 
-	if fieldIdx, ok := info.fieldMap[0]; !ok || fieldIdx != 0 {
+	if fieldIdx, ok := info.fieldMap[0]; !ok || !reflect.DeepEqual(fieldIdx, []int{0}) {
 		t.Errorf("fieldMap[0] = %v, want 0", fieldIdx)
 	}
 
-	if fieldIdx, ok := info.fieldMap[1]; !ok || fieldIdx != 1 {
+	if fieldIdx, ok := info.fieldMap[1]; !ok || !reflect.DeepEqual(fieldIdx, []int{1}) {
 		t.Errorf("fieldMap[1] = %v, want 1", fieldIdx)
 	}
 }
@@ -292,7 +321,7 @@ func TestStructInfoDifferentHeaders(t *testing.T) {
 	}
 
 	// Verify the field maps are different
-	if info1.fieldMap[0] == info2.fieldMap[0] {
+	if info1.fieldMap[0][0] == info2.fieldMap[0][0] {
 		t.Error("fieldMap[0] should differ for different header orders")
 	}
 }
@@ -334,7 +363,7 @@ func TestStructInfoIntegerTypes(t *testing.T) {
 	for _, tt := range tests {
 		setter := info.setters[tt.colIdx]
 		fieldIdx := info.fieldMap[tt.colIdx]
-		field := val.Field(fieldIdx)
+		field := val.FieldByIndex(fieldIdx)
 
 		err := setter(field, tt.value, 0, tt.colIdx)
 		if err != nil {
@@ -365,33 +394,33 @@ func TestStructInfoEmptyValues(t *testing.T) {
 	// Test empty int -> 0
 	setter := info.setters[0]
 	fieldIdx := info.fieldMap[0]
-	err := setter(val.Field(fieldIdx), "", 0, 0)
+	err := setter(val.FieldByIndex(fieldIdx), "", 0, 0)
 	if err != nil {
 		t.Errorf("setter for empty int failed: %v", err)
 	}
-	if val.Field(fieldIdx).Int() != 0 {
-		t.Errorf("empty int should be 0, got %d", val.Field(fieldIdx).Int())
+	if val.FieldByIndex(fieldIdx).Int() != 0 {
+		t.Errorf("empty int should be 0, got %d", val.FieldByIndex(fieldIdx).Int())
 	}
 
 	// Test empty float -> 0.0
 	setter = info.setters[1]
 	fieldIdx = info.fieldMap[1]
-	err = setter(val.Field(fieldIdx), "", 0, 1)
+	err = setter(val.FieldByIndex(fieldIdx), "", 0, 1)
 	if err != nil {
 		t.Errorf("setter for empty float failed: %v", err)
 	}
-	if val.Field(fieldIdx).Float() != 0.0 {
-		t.Errorf("empty float should be 0.0, got %f", val.Field(fieldIdx).Float())
+	if val.FieldByIndex(fieldIdx).Float() != 0.0 {
+		t.Errorf("empty float should be 0.0, got %f", val.FieldByIndex(fieldIdx).Float())
 	}
 
 	// Test empty bool -> false
 	setter = info.setters[2]
 	fieldIdx = info.fieldMap[2]
-	err = setter(val.Field(fieldIdx), "", 0, 2)
+	err = setter(val.FieldByIndex(fieldIdx), "", 0, 2)
 	if err != nil {
 		t.Errorf("setter for empty bool failed: %v", err)
 	}
-	if val.Field(fieldIdx).Bool() != false {
+	if val.FieldByIndex(fieldIdx).Bool() != false {
 		t.Error("empty bool should be false")
 	}
 }
@@ -464,7 +493,7 @@ func TestStructInfoOverflowInt(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			setter := info.setters[tt.colIdx]
 			fieldIdx := info.fieldMap[tt.colIdx]
-			field := val.Field(fieldIdx)
+			field := val.FieldByIndex(fieldIdx)
 
 			err := setter(field, tt.value, 0, tt.colIdx)
 			if err == nil {
@@ -507,7 +536,7 @@ func TestStructInfoOverflowUint(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			setter := info.setters[tt.colIdx]
 			fieldIdx := info.fieldMap[tt.colIdx]
-			field := val.Field(fieldIdx)
+			field := val.FieldByIndex(fieldIdx)
 
 			err := setter(field, tt.value, 0, tt.colIdx)
 			if err == nil {
@@ -535,7 +564,7 @@ func TestStructInfoOverflowFloat(t *testing.T) {
 	// Test float32 overflow with extremely large value
 	setter := info.setters[0]
 	fieldIdx := info.fieldMap[0]
-	field := val.Field(fieldIdx)
+	field := val.FieldByIndex(fieldIdx)
 
 	// This value is too large for float32
 	err := setter(field, "3.5e+38", 0, 0)
@@ -578,7 +607,7 @@ func TestStructInfoInvalidValues(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			setter := info.setters[tt.colIdx]
 			fieldIdx := info.fieldMap[tt.colIdx]
-			field := val.Field(fieldIdx)
+			field := val.FieldByIndex(fieldIdx)
 
 			err := setter(field, tt.value, 0, tt.colIdx)
 			if err == nil {
@@ -606,7 +635,7 @@ func TestStructInfoUnsupportedType(t *testing.T) {
 	// Get the setter for the complex field
 	setter := info.setters[0]
 	fieldIdx := info.fieldMap[0]
-	field := val.Field(fieldIdx)
+	field := val.FieldByIndex(fieldIdx)
 
 	err := setter(field, "1+2i", 0, 0)
 	if err == nil {
@@ -631,7 +660,7 @@ func TestStructInfoEmptyUint(t *testing.T) {
 
 	setter := info.setters[0]
 	fieldIdx := info.fieldMap[0]
-	field := val.Field(fieldIdx)
+	field := val.FieldByIndex(fieldIdx)
 
 	err := setter(field, "", 0, 0)
 	if err != nil {
@@ -642,6 +671,86 @@ func TestStructInfoEmptyUint(t *testing.T) {
 	}
 }
 
+// testColor implements encoding.TextUnmarshaler with a pointer receiver.
+type testColor struct {
+	R, G, B uint8
+}
+
+func (c *testColor) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+// testTags implements encoding.TextUnmarshaler with a value receiver.
+type testTags map[string]bool
+
+func (t testTags) UnmarshalText(text []byte) error {
+	for _, tag := range strings.Split(string(text), "|") {
+		t[tag] = true
+	}
+	return nil
+}
+
+// TestStructInfoTextUnmarshaler tests setters for encoding.TextUnmarshaler fields
+func TestStructInfoTextUnmarshaler(t *testing.T) {
+	type Paint struct {
+		Color  testColor  `csv:"color"`
+		Accent *testColor `csv:"accent"`
+	}
+
+	var got []Paint
+	input := "color,accent\n#ff8000,#0000ff\n#010203,\n"
+	if err := Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []Paint{
+		{Color: testColor{0xff, 0x80, 0x00}, Accent: &testColor{0x00, 0x00, 0xff}},
+		{Color: testColor{0x01, 0x02, 0x03}, Accent: nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	// Value receiver: the setter calls UnmarshalText on the field itself
+	structType := reflect.TypeOf(struct {
+		Tags testTags `csv:"tags"`
+	}{})
+	info := getStructInfo(structType, []string{"tags"})
+	val := reflect.New(structType).Elem()
+	val.Field(0).Set(reflect.ValueOf(testTags{}))
+	if err := info.setters[0](val.Field(0), "a|b", 0, 0); err != nil {
+		t.Fatalf("setter error = %v", err)
+	}
+	if tags := val.Field(0).Interface().(testTags); !tags["a"] || !tags["b"] {
+		t.Errorf("tags = %v, want a and b", tags)
+	}
+
+	// Errors from UnmarshalText are wrapped with row and column context
+	err := Unmarshal([]byte("color,accent\nred,\n"), &got)
+	if err == nil {
+		t.Fatal("Unmarshal() should fail when UnmarshalText returns an error")
+	}
+	if !containsStr(err.Error(), "row 1, column 0") {
+		t.Errorf("error = %q, want row/column context", err)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Error("error should wrap the UnmarshalText error")
+	}
+
+	// Empty cells leave the zero value without calling UnmarshalText
+	var events []struct {
+		When time.Time `csv:"when"`
+		Name string    `csv:"name"`
+	}
+	if err := Unmarshal([]byte("when,name\n,a\n"), &events); err != nil {
+		t.Fatalf("Unmarshal() empty time.Time error = %v", err)
+	}
+	if len(events) != 1 || !events[0].When.IsZero() || events[0].Name != "a" {
+		t.Errorf("Unmarshal() = %+v, want zero When", events)
+	}
+}
+
 // Helper function
 func containsStr(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -651,3 +760,33 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+// TestWriteColumns tests the cached columns written for a struct type
+func TestWriteColumns(t *testing.T) {
+	type Row struct {
+		Name    string   `csv:"name,omitempty"`
+		Rate    float64  `csv:"rate,percent"`
+		Tags    []string `csv:"tags,split=;"`
+		Age     int
+		Offset  int64 `csv:"pos,offset"`
+		Ignored string `csv:"-"`
+		hidden  string
+	}
+
+	structType := reflect.TypeOf(Row{})
+	got := WriteColumns(structType)
+	want := []WriteColumn{
+		{Name: "Age", Index: 3, Split: "|"},
+		{Name: "name", Index: 0, OmitEmpty: true, Split: "|"},
+		{Name: "rate", Index: 1, Percent: true, Split: "|"},
+		{Name: "tags", Index: 2, Split: ";"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteColumns() = %+v, want %+v", got, want)
+	}
+
+	// The columns come from the shared type cache
+	if again := WriteColumns(structType); &again[0] != &got[0] {
+		t.Error("WriteColumns() did not return the cached columns")
+	}
+}
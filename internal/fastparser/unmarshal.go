@@ -28,6 +28,9 @@ import (
 // Struct tags:
 //   - Use `csv:"fieldname"` to specify the CSV column name
 //   - If no tag is provided, the field name is used (case-insensitive matching)
+//   - Use a number, such as `csv:"3"`, to bind a field to a 0-based column
+//     index regardless of the header. A struct must use either index tags or
+//     name tags; mixing them, including untagged exported fields, is an error
 //
 // Supported types:
 //   - string
@@ -36,50 +39,152 @@ import (
 //   - float32, float64
 //   - bool (accepts: true/false, 1/0, t/f, T/F, TRUE/FALSE)
 func Unmarshal(data []byte, v interface{}) error {
-	// Validate input
+	elem, err := unmarshalTarget(v, "Unmarshal")
+	if err != nil {
+		return err
+	}
+
+	// Fast path: [][]string - return raw records
+	if isStringSliceType(elem.Type().Elem()) {
+		records, err := Parse(data)
+		if err != nil {
+			return err
+		}
+		return decodeRecords(elem, records, nil, DecodeOptions{})
+	}
+
+	// Parse CSV, keeping track of quoted fields so that a quoted "" decodes
+	// into a non-nil pointer while an empty cell decodes to nil, and of
+	// record positions for offset and line fields
+	records, meta, err := parseWithMeta(data)
+	if err != nil {
+		return err
+	}
+
+	return decodeRecords(elem, records, meta, DecodeOptions{})
+}
+
+// DecodeOptions configures how field values are converted when unmarshaling
+// records into structs. The zero value gives the default Unmarshal behavior.
+type DecodeOptions struct {
+	// DecimalSeparator is the decimal separator for float fields.
+	// Default: 0 ('.')
+	DecimalSeparator rune
+
+	// ThousandsSeparator, if set, is removed from float fields before parsing.
+	// Fields tagged with the "currency" option default to ',' (or '.' when
+	// DecimalSeparator is ',').
+	// Default: 0 (none)
+	ThousandsSeparator rune
+
+	// CurrencySymbol is removed from fields tagged with the "currency" option.
+	// If empty, any Unicode currency symbol ($, €, £, ¥, ...) is removed.
+	CurrencySymbol string
+
+	// StrictNumeric makes numeric fields fail on cells containing any
+	// whitespace, including fields tagged "currency", which otherwise ignore
+	// whitespace.
+	StrictNumeric bool
+
+	// FallbackTag names a struct tag, such as "json", consulted for fields
+	// without a csv tag.
+	FallbackTag string
+
+	// NullValues lists tokens, such as "NA" or "NULL", that mean a missing
+	// value. Matching cells leave scalar fields at their zero value and
+	// pointer fields nil. Comparison is exact unless NullValuesIgnoreCase is set.
+	NullValues []string
+
+	// NullValuesIgnoreCase makes NullValues match case-insensitively.
+	NullValuesIgnoreCase bool
+
+	// CaseSensitiveHeaders matches header columns to field names exactly
+	// instead of ignoring case.
+	CaseSensitiveHeaders bool
+}
+
+// headerKey returns the key under which a column name is matched to a field.
+func (o *DecodeOptions) headerKey(name string) string {
+	if o.CaseSensitiveHeaders {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// isNull reports whether value is one of the NullValues tokens.
+func (o *DecodeOptions) isNull(value string) bool {
+	for _, null := range o.NullValues {
+		if value == null || (o.NullValuesIgnoreCase && strings.EqualFold(value, null)) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalRecords stores already-parsed records in the value pointed to by v,
+// which must be a pointer to [][]string or to a slice of structs. For structs,
+// records[0] is treated as the header row.
+//
+// This lets callers that parse with custom dialect options reuse the cached
+// struct mapping used by Unmarshal.
+func UnmarshalRecords(records [][]string, v interface{}, opts DecodeOptions) error {
+	elem, err := unmarshalTarget(v, "Unmarshal")
+	if err != nil {
+		return err
+	}
+	return decodeRecords(elem, records, nil, opts)
+}
+
+// unmarshalTarget validates that v is a non-nil pointer to [][]string or to a
+// slice of structs and returns the slice value it points to.
+func unmarshalTarget(v interface{}, fn string) (reflect.Value, error) {
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() || v == nil {
-		return errors.New("csv: Unmarshal(nil)")
+		return reflect.Value{}, errors.New("csv: " + fn + "(nil)")
 	}
 
 	if rv.Kind() != reflect.Ptr {
-		return errors.New("csv: Unmarshal(non-pointer " + rv.Type().String() + ")")
+		return reflect.Value{}, errors.New("csv: " + fn + "(non-pointer " + rv.Type().String() + ")")
 	}
 
 	if rv.IsNil() {
-		return errors.New("csv: Unmarshal(nil " + rv.Type().String() + ")")
+		return reflect.Value{}, errors.New("csv: " + fn + "(nil " + rv.Type().String() + ")")
 	}
 
 	// Get the element type
 	elem := rv.Elem()
 	if elem.Kind() != reflect.Slice {
-		return errors.New("csv: Unmarshal expects pointer to slice, got " + elem.Type().String())
+		return reflect.Value{}, errors.New("csv: " + fn + " expects pointer to slice, got " + elem.Type().String())
 	}
 
 	// Get the slice element type
 	sliceElemType := elem.Type().Elem()
+	if isStringSliceType(sliceElemType) || sliceElemType.Kind() == reflect.Struct {
+		return elem, nil
+	}
+
+	return reflect.Value{}, errors.New("csv: " + fn + " expects [][]string or slice of structs, got slice of " + sliceElemType.String())
+}
+
+// isStringSliceType reports whether t is []string.
+func isStringSliceType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
+}
+
+// decodeRecords stores records in elem, a [][]string or slice-of-struct value.
+// meta, if not nil, carries record positions for offset and line fields and
+// reports which fields were quoted in the input; empty fields that were not
+// quoted decode to nil pointers. When meta is nil every empty field decodes to
+// a nil pointer and offset and line fields are left zero.
+func decodeRecords(elem reflect.Value, records [][]string, meta *recordMeta, opts DecodeOptions) error {
+	sliceElemType := elem.Type().Elem()
 
 	// Fast path: [][]string - return raw records
-	if sliceElemType.Kind() == reflect.Slice && sliceElemType.Elem().Kind() == reflect.String {
-		records, err := Parse(data)
-		if err != nil {
-			return err
-		}
+	if isStringSliceType(sliceElemType) {
 		elem.Set(reflect.ValueOf(records))
 		return nil
 	}
 
-	// Struct path: slice of structs
-	if sliceElemType.Kind() != reflect.Struct {
-		return errors.New("csv: Unmarshal expects [][]string or slice of structs, got slice of " + sliceElemType.String())
-	}
-
-	// Parse CSV
-	records, err := Parse(data)
-	if err != nil {
-		return err
-	}
-
 	// Empty data
 	if len(records) == 0 {
 		elem.Set(reflect.MakeSlice(elem.Type(), 0, 0))
@@ -91,7 +196,28 @@ func Unmarshal(data []byte, v interface{}) error {
 	dataRows := records[1:]
 
 	// Get cached struct info (includes field map and pre-computed setters)
-	info := getStructInfo(sliceElemType, headers)
+	info := getStructInfoWithOptions(sliceElemType, headers, opts)
+	if info.err != nil {
+		return info.err
+	}
+
+	// Record positions for offset and line fields
+	var quoted [][]bool
+	var offsets []int64
+	var lines []int
+	var raws *recordMeta
+	if meta != nil {
+		quoted = meta.quoted
+		if info.offsetField != nil {
+			offsets = meta.offsets
+		}
+		if info.lineField != nil {
+			lines = meta.lines()
+		}
+		if info.rawField != nil {
+			raws = meta
+		}
+	}
 
 	// Create result slice
 	result := reflect.MakeSlice(elem.Type(), 0, len(dataRows))
@@ -101,34 +227,24 @@ func Unmarshal(data []byte, v interface{}) error {
 		// Create new struct instance
 		structVal := reflect.New(sliceElemType).Elem()
 
-		// Populate fields using cached setters
-		for colIdx, value := range row {
-			if colIdx >= len(headers) {
-				// Extra columns beyond headers - ignore
-				continue
-			}
-
-			// Look up field index for this column
-			fieldIdx, ok := info.fieldMap[colIdx]
-			if !ok {
-				// Column not mapped to any struct field - skip
-				continue
-			}
-
-			// Get the pre-computed setter for this column
-			setter, ok := info.setters[colIdx]
-			if !ok {
-				// No setter for this column - skip
-				continue
-			}
-
-			// Get the struct field
-			field := structVal.Field(fieldIdx)
+		// Populate position fields
+		if rowIdx+1 < len(offsets) {
+			fieldByIndex(structVal, info.offsetField).SetInt(offsets[rowIdx+1])
+		}
+		if rowIdx+1 < len(lines) {
+			fieldByIndex(structVal, info.lineField).SetInt(int64(lines[rowIdx+1]))
+		}
+		if raws != nil && rowIdx+1 < len(raws.ends) {
+			setRaw(fieldByIndex(structVal, info.rawField), raws.raw(rowIdx+1))
+		}
 
-			// Use pre-computed setter instead of switch-based setFieldValue
-			if err := setter(field, value, rowIdx, colIdx); err != nil {
-				return err
-			}
+		// Populate fields using cached setters
+		var quotedRow []bool
+		if rowIdx+1 < len(quoted) {
+			quotedRow = quoted[rowIdx+1]
+		}
+		if err := decodeRow(structVal, info, headers, row, quotedRow, rowIdx, opts); err != nil {
+			return err
 		}
 
 		// Append to result
@@ -140,6 +256,139 @@ func Unmarshal(data []byte, v interface{}) error {
 	return nil
 }
 
+// fieldByIndex returns the field of v at the index path, allocating any nil
+// embedded struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// setRaw stores a record's source bytes in a string or []byte field. The bytes
+// are copied so the field does not retain the input.
+func setRaw(field reflect.Value, raw []byte) {
+	if field.Kind() == reflect.String {
+		field.SetString(string(raw))
+		return
+	}
+	field.SetBytes(append([]byte(nil), raw...))
+}
+
+// DecodeError reports a field that could not be stored in its struct field.
+// Err carries the conversion error, whose message already names the row and
+// column.
+type DecodeError struct {
+	Row    int    // data row, 1-based and not counting the header row
+	Column int    // column index, 0-based
+	Header string // header of the column, or "" when there is none
+	Value  string // field value that failed to decode
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Header == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (header %q)", e.Err, e.Header)
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError wraps err, which occurred decoding value at rowIdx and
+// colIdx, in a *DecodeError.
+func newDecodeError(headers []string, rowIdx, colIdx int, value string, err error) *DecodeError {
+	var header string
+	if colIdx < len(headers) {
+		header = headers[colIdx]
+	}
+	return &DecodeError{Row: rowIdx + 1, Column: colIdx, Header: header, Value: value, Err: err}
+}
+
+// decodeRow populates structVal from one data row using the cached setters in
+// info. quotedRow reports which fields were quoted in the input; when it is nil,
+// every empty field leaves a pointer field nil. rowIdx is the 0-based data row
+// index used in error messages.
+func decodeRow(structVal reflect.Value, info *structInfo, headers, row []string, quotedRow []bool, rowIdx int, opts DecodeOptions) error {
+	for _, rc := range info.required {
+		if err := rc.check(row, rowIdx, &opts); err != nil {
+			return newDecodeError(headers, rowIdx, rc.col, "", err)
+		}
+	}
+
+	for colIdx, value := range row {
+		if colIdx >= len(headers) && !info.positional {
+			// Extra columns beyond headers - ignore
+			continue
+		}
+
+		// Look up field index for this column
+		fieldIdx, ok := info.fieldMap[colIdx]
+		if !ok {
+			// Column not mapped to any struct field - skip
+			continue
+		}
+
+		// Get the pre-computed setter for this column
+		setter, ok := info.setters[colIdx]
+		if !ok {
+			// No setter for this column - skip
+			continue
+		}
+
+		// Get the struct field
+		field := fieldByIndex(structVal, fieldIdx)
+
+		// Empty cells leave pointer fields nil
+		quotedField := colIdx < len(quotedRow) && quotedRow[colIdx]
+		if value == "" && field.Kind() == reflect.Ptr && !quotedField {
+			continue
+		}
+
+		// Null tokens leave the field at its zero value
+		if len(opts.NullValues) > 0 && opts.isNull(value) {
+			continue
+		}
+
+		// Use pre-computed setter instead of switch-based setFieldValue
+		if err := setter(field, value, rowIdx, colIdx); err != nil {
+			return newDecodeError(headers, rowIdx, colIdx, value, err)
+		}
+	}
+	return nil
+}
+
+// DecodeRecord stores a single record in the struct pointed to by v, mapping
+// fields by headers with the same cached setters as Unmarshal. rowIdx is the
+// 0-based data row index reported in conversion errors. The struct is reset to
+// its zero value first, so it can be reused across records; empty fields leave
+// pointer fields nil. headers may be nil for structs tagged with column indexes.
+func DecodeRecord(headers, record []string, v interface{}, rowIdx int, opts DecodeOptions) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("csv: Decode expects non-nil pointer to struct, got %T", v)
+	}
+	structVal := rv.Elem()
+	info := getStructInfoWithOptions(structVal.Type(), headers, opts)
+	if info.err != nil {
+		return info.err
+	}
+	if headers == nil && !info.positional {
+		return fmt.Errorf("csv: Decode requires headers to map fields of %s by name", structVal.Type())
+	}
+	structVal.Set(reflect.Zero(structVal.Type()))
+	return decodeRow(structVal, info, headers, record, nil, rowIdx, opts)
+}
+
 // UnmarshalBytes parses CSV data using ByteRecord offset tracking and unmarshals
 // it into a slice of structs or [][]string.
 //
@@ -224,6 +473,9 @@ func UnmarshalBytes(data []byte, v interface{}) error {
 
 	// Get cached struct info (includes field map and pre-computed setters)
 	info := getStructInfo(sliceElemType, headers)
+	if info.err != nil {
+		return info.err
+	}
 
 	// Create result slice
 	result := reflect.MakeSlice(elem.Type(), 0, len(dataRecords))
@@ -233,9 +485,18 @@ func UnmarshalBytes(data []byte, v interface{}) error {
 		// Create new struct instance
 		structVal := reflect.New(sliceElemType).Elem()
 
+		if len(info.required) > 0 {
+			fields := record.Fields()
+			for _, rc := range info.required {
+				if err := rc.check(fields, rowIdx, &DecodeOptions{}); err != nil {
+					return newDecodeError(headers, rowIdx, rc.col, "", err)
+				}
+			}
+		}
+
 		// Populate fields using cached setters
 		for colIdx := 0; colIdx < record.NumFields(); colIdx++ {
-			if colIdx >= len(headers) {
+			if colIdx >= len(headers) && !info.positional {
 				// Extra columns beyond headers - ignore
 				continue
 			}
@@ -255,14 +516,19 @@ func UnmarshalBytes(data []byte, v interface{}) error {
 			}
 
 			// Get the struct field
-			field := structVal.Field(fieldIdx)
+			field := fieldByIndex(structVal, fieldIdx)
 
 			// Get field value as string (lazy conversion)
 			value := record.Field(colIdx)
 
+			// Empty cells leave pointer fields nil
+			if value == "" && field.Kind() == reflect.Ptr {
+				continue
+			}
+
 			// Use pre-computed setter instead of switch-based setFieldValue
 			if err := setter(field, value, rowIdx, colIdx); err != nil {
-				return err
+				return newDecodeError(headers, rowIdx, colIdx, value, err)
 			}
 		}
 
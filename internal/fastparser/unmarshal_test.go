@@ -1,7 +1,9 @@
 package fastparser
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -723,3 +725,354 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFastUnmarshal_Percent(t *testing.T) {
+	type Rate struct {
+		Name string  `csv:"name"`
+		Rate float64 `csv:"rate,percent"`
+	}
+
+	input := "name,rate\nA,45%\nB,12.5%\nC,7\nD,\n"
+	var got []Rate
+	if err := Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []Rate{
+		{Name: "A", Rate: 0.45},
+		{Name: "B", Rate: 0.125},
+		{Name: "C", Rate: 0.07},
+		{Name: "D", Rate: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %v, want %v", got, want)
+	}
+
+	var bad []Rate
+	if err := Unmarshal([]byte("name,rate\nA,lots%\n"), &bad); err == nil {
+		t.Error("Unmarshal() should fail on a non-numeric percentage")
+	}
+}
+
+func TestFastUnmarshal_IntBool(t *testing.T) {
+	type Row struct {
+		Name   string `csv:"name"`
+		Flag   bool   `csv:"flag,intbool"`
+		Opt    *bool  `csv:"opt,intbool"`
+		Active bool   `csv:"active"`
+	}
+
+	input := "name,flag,opt,active\nA,0,1,true\nB,1,0,false\nC,2,,T\nD,-1,,0\nE,,,1\n"
+	var got []Row
+	if err := Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	yes, no := true, false
+	want := []Row{
+		{Name: "A", Flag: false, Opt: &yes, Active: true},
+		{Name: "B", Flag: true, Opt: &no, Active: false},
+		{Name: "C", Flag: true, Active: true},
+		{Name: "D", Flag: true, Active: false},
+		{Name: "E", Flag: false, Active: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	var bad []Row
+	if err := Unmarshal([]byte("name,flag,opt,active\nA,true,,\n"), &bad); err == nil {
+		t.Error("Unmarshal() should fail on a non-integer intbool cell")
+	}
+	if err := Unmarshal([]byte("name,flag,opt,active\nA,0,,2\n"), &bad); err == nil {
+		t.Error("Unmarshal() should reject 2 for a plain bool field")
+	}
+}
+
+func TestFastUnmarshal_Currency(t *testing.T) {
+	type Line struct {
+		Item   string  `csv:"item"`
+		Amount float64 `csv:"amount,currency"`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  DecodeOptions
+		want  float64
+	}{
+		{"dollar", "item,amount\nA,\"$1,234.56\"\n", DecodeOptions{}, 1234.56},
+		{"negative", "item,amount\nA,-$5.25\n", DecodeOptions{}, -5.25},
+		{"euro", "item,amount\nA,\"€1.234,56\"\n", DecodeOptions{DecimalSeparator: ',', ThousandsSeparator: '.'}, 1234.56},
+		{"suffix symbol", "item,amount\nA,\"1.234,56 €\"\n", DecodeOptions{DecimalSeparator: ','}, 1234.56},
+		{"custom symbol", "item,amount\nA,CHF 1'000.50\n", DecodeOptions{CurrencySymbol: "CHF", ThousandsSeparator: '\''}, 1000.50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var got []Line
+			if err := UnmarshalRecords(records, &got, tt.opts); err != nil {
+				t.Fatalf("UnmarshalRecords() error = %v", err)
+			}
+			if len(got) != 1 || got[0].Amount != tt.want {
+				t.Errorf("UnmarshalRecords() = %v, want amount %v", got, tt.want)
+			}
+		})
+	}
+
+	var bad []Line
+	if err := Unmarshal([]byte("item,amount\nA,$abc\n"), &bad); err == nil {
+		t.Error("Unmarshal() should fail on a non-numeric currency value")
+	}
+}
+
+func TestFastUnmarshal_Position(t *testing.T) {
+	type Row struct {
+		Offset int64  `csv:",offset"`
+		Line   int    `csv:",line"`
+		Name   string `csv:"name"`
+		Note   string `csv:"note"`
+	}
+
+	input := "name,note\nA,one\nB,\"two\nlines\"\r\n\nC,three\n"
+	var got []Row
+	if err := Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []Row{
+		{Offset: 10, Line: 2, Name: "A", Note: "one"},
+		{Offset: 16, Line: 3, Name: "B", Note: "two\nlines"},
+		{Offset: 32, Line: 6, Name: "C", Note: "three"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unmarshal() = %+v, want %+v", got, want)
+	}
+	for _, r := range got {
+		if !strings.HasPrefix(input[r.Offset:], r.Name+",") {
+			t.Errorf("offset %d does not point at record %s", r.Offset, r.Name)
+		}
+	}
+
+	// A lone CR ends a line too
+	if err := Unmarshal([]byte("name\rA\rB\r"), &got); err != nil {
+		t.Fatalf("Unmarshal() CR-only error = %v", err)
+	}
+	want = []Row{
+		{Offset: 5, Line: 2, Name: "A"},
+		{Offset: 7, Line: 3, Name: "B"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() CR-only = %+v, want %+v", got, want)
+	}
+}
+
+func TestFastUnmarshal_Raw(t *testing.T) {
+	type Row struct {
+		Name string `csv:"name"`
+		Note string `csv:"note"`
+		Raw  string `csv:",raw"`
+	}
+
+	input := "\ufeffname,note\nA,one\r\nB,\"two\nlines, \"\"quoted\"\"\"\n\nC,three"
+	var got []Row
+	if err := Unmarshal([]byte(input), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	wantRaw := []string{"A,one", "B,\"two\nlines, \"\"quoted\"\"\"", "C,three"}
+	wantNotes := []string{"one", "two\nlines, \"quoted\"", "three"}
+	if len(got) != len(wantRaw) {
+		t.Fatalf("Unmarshal() returned %d rows, want %d", len(got), len(wantRaw))
+	}
+	for i, r := range got {
+		if r.Raw != wantRaw[i] {
+			t.Errorf("row %d Raw = %q, want %q", i, r.Raw, wantRaw[i])
+		}
+		if r.Note != wantNotes[i] {
+			t.Errorf("row %d Note = %q, want %q", i, r.Note, wantNotes[i])
+		}
+
+		// The raw text parses back to the same fields
+		records, err := Parse([]byte(r.Raw))
+		if err != nil || len(records) != 1 || records[0][0] != r.Name || records[0][1] != r.Note {
+			t.Errorf("row %d raw text parses to %q, %v", i, records, err)
+		}
+	}
+
+	type ByteRow struct {
+		Raw  []byte `csv:",raw"`
+		Name string `csv:"name"`
+	}
+	var byteRows []ByteRow
+	if err := Unmarshal([]byte(input), &byteRows); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for i, r := range byteRows {
+		if string(r.Raw) != wantRaw[i] {
+			t.Errorf("row %d []byte Raw = %q, want %q", i, r.Raw, wantRaw[i])
+		}
+	}
+}
+
+func TestUnmarshalRecords_NullValues(t *testing.T) {
+	type Row struct {
+		N int  `csv:"n"`
+		P *int `csv:"p"`
+	}
+
+	records := [][]string{{"n", "p"}, {"NA", "NA"}, {"1", "2"}}
+	var got []Row
+	if err := UnmarshalRecords(records, &got, DecodeOptions{NullValues: []string{"NA"}}); err != nil {
+		t.Fatalf("UnmarshalRecords() error = %v", err)
+	}
+	if got[0].N != 0 || got[0].P != nil {
+		t.Errorf("row 0 = %+v, want N = 0 and P = nil", got[0])
+	}
+	if got[1].N != 1 || got[1].P == nil || *got[1].P != 2 {
+		t.Errorf("row 1 = %+v, want N = 1 and P = 2", got[1])
+	}
+
+	// Without NullValues the same records fail to decode
+	var bad []Row
+	if err := UnmarshalRecords(records, &bad, DecodeOptions{}); err == nil {
+		t.Error("UnmarshalRecords() without NullValues should fail on \"NA\"")
+	}
+}
+
+func TestFastUnmarshal_ColumnIndex(t *testing.T) {
+	type Row struct {
+		ID    int    `csv:"0"`
+		Price string `csv:"2"`
+		Extra string `csv:"5"`
+	}
+
+	// The header row is skipped but not used for binding
+	var got []Row
+	if err := Unmarshal([]byte("unreliable,header\n1,x,9.99\n2,y,4.50,z\n"), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := []Row{{ID: 1, Price: "9.99"}, {ID: 2, Price: "4.50"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	// Without a header, records bind by position alone
+	var r Row
+	if err := DecodeRecord(nil, []string{"7", "a", "1.00", "b", "c", "e"}, &r, 0, DecodeOptions{}); err != nil {
+		t.Fatalf("DecodeRecord() error = %v", err)
+	}
+	if want := (Row{ID: 7, Price: "1.00", Extra: "e"}); r != want {
+		t.Errorf("DecodeRecord() = %+v, want %+v", r, want)
+	}
+}
+
+func TestFastUnmarshal_ColumnIndexErrors(t *testing.T) {
+	type Mixed struct {
+		ID   int    `csv:"0"`
+		Name string `csv:"name"`
+	}
+	type Untagged struct {
+		ID   int `csv:"0"`
+		Name string
+	}
+	type Duplicate struct {
+		A string `csv:"1"`
+		B string `csv:"1"`
+	}
+
+	input := []byte("id,name\n1,x\n")
+	var mixed []Mixed
+	if err := Unmarshal(input, &mixed); err == nil || !strings.Contains(err.Error(), "mixes") {
+		t.Errorf("Unmarshal() mixed tags error = %v, want mixing error", err)
+	}
+	var untagged []Untagged
+	if err := Unmarshal(input, &untagged); err == nil {
+		t.Error("Unmarshal() with an untagged field next to index tags expected error")
+	}
+	var dup []Duplicate
+	if err := Unmarshal(input, &dup); err == nil {
+		t.Error("Unmarshal() with a duplicate column index expected error")
+	}
+
+	// Name-bound structs still need headers
+	var m Mixed
+	type Named struct {
+		Name string `csv:"name"`
+	}
+	var n Named
+	if err := DecodeRecord(nil, []string{"x"}, &n, 0, DecodeOptions{}); err == nil {
+		t.Error("DecodeRecord() without headers for a name-bound struct expected error")
+	}
+	if err := DecodeRecord(nil, []string{"1", "x"}, &m, 0, DecodeOptions{}); err == nil {
+		t.Error("DecodeRecord() with mixed tags expected error")
+	}
+}
+
+func TestFastUnmarshal_Required(t *testing.T) {
+	type Row struct {
+		ID   string `csv:"id,required"`
+		Name string `csv:"name"`
+	}
+	type Positional struct {
+		ID   string `csv:"0"`
+		Code string `csv:"2,required"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "present", input: "id,name\n1,a\n2,\n"},
+		{name: "missing column", input: "name\na\n", wantErr: `required column "id" for field ID is missing`},
+		{name: "empty cell", input: "name,id\na,1\nb,\n", wantErr: "required field ID is empty at row 2, column 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for fn, unmarshal := range map[string]func([]byte, interface{}) error{"Unmarshal": Unmarshal, "UnmarshalBytes": UnmarshalBytes} {
+				var got []Row
+				err := unmarshal([]byte(tt.input), &got)
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("%s() error = %v", fn, err)
+					}
+					continue
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("%s() error = %v, want %q", fn, err, tt.wantErr)
+				}
+			}
+		})
+	}
+
+	// A short row leaves a positional required column missing
+	var p []Positional
+	if err := Unmarshal([]byte("h\n1,x,c\n2,y\n"), &p); err == nil || !strings.Contains(err.Error(), "field Code is empty at row 2, column 2") {
+		t.Errorf("Unmarshal() positional error = %v, want empty Code at row 2", err)
+	}
+}
+
+func TestFastUnmarshal_DecodeError(t *testing.T) {
+	type Row struct {
+		Name string `csv:"name"`
+		Qty  int    `csv:"qty"`
+	}
+	input := []byte("name,qty\na,1\nb,2\nc,x\n")
+
+	for fn, unmarshal := range map[string]func([]byte, interface{}) error{"Unmarshal": Unmarshal, "UnmarshalBytes": UnmarshalBytes} {
+		var got []Row
+		err := unmarshal(input, &got)
+		var derr *DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("%s() error = %v, want *DecodeError", fn, err)
+		}
+		if derr.Row != 3 || derr.Column != 1 || derr.Header != "qty" || derr.Value != "x" {
+			t.Errorf("%s() DecodeError = %+v, want row 3, column 1, header qty, value x", fn, derr)
+		}
+	}
+}
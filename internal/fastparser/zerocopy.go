@@ -3,6 +3,8 @@ package fastparser
 import (
 	"errors"
 	"fmt"
+
+	csvparser "github.com/shapestone/shape-csv/internal/parser"
 )
 
 // ParseZeroCopy parses CSV data and returns [][]byte slices pointing into the original buffer.
@@ -262,6 +264,10 @@ type ScannerOptions struct {
 	// This reduces allocations but means you must copy data if you need to retain it.
 	// Matches the pattern used by encoding/csv.Reader.ReuseRecord.
 	ReuseRecord bool
+
+	// MaxRecords, if positive, is the maximum number of records to scan.
+	// Scan fails with an error when the input holds more.
+	MaxRecords int
 }
 
 // Scanner provides a streaming interface for parsing CSV data.
@@ -287,6 +293,8 @@ type Scanner struct {
 
 	// Options
 	reuseRecord bool
+	maxRecords  int
+	count       int // records scanned so far
 
 	// Error state
 	err error
@@ -302,6 +310,7 @@ func NewScanner(data []byte, opts ScannerOptions) *Scanner {
 		pos:         0,
 		length:      len(data),
 		reuseRecord: opts.ReuseRecord,
+		maxRecords:  opts.MaxRecords,
 	}
 }
 
@@ -326,6 +335,11 @@ func (s *Scanner) Scan() bool {
 		return false
 	}
 
+	if s.maxRecords > 0 && s.count >= s.maxRecords {
+		s.err = fmt.Errorf("record at position %d: %w (maximum of %d records)", s.pos, csvparser.ErrTooManyRecords, s.maxRecords)
+		return false
+	}
+
 	// Parse next record
 	record, err := s.parseRecord()
 	if err != nil {
@@ -333,6 +347,7 @@ func (s *Scanner) Scan() bool {
 		return false
 	}
 
+	s.count++
 	s.currentRecord = record
 	return true
 }
@@ -3,11 +3,15 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/shapestone/shape-core/pkg/ast"
 	shapetokenizer "github.com/shapestone/shape-core/pkg/tokenizer"
+	"github.com/shapestone/shape-csv/internal/bom"
 	"github.com/shapestone/shape-csv/internal/tokenizer"
 )
 
@@ -23,34 +27,101 @@ const (
 	BadLineModeSkip
 )
 
+// UnbalancedQuoteMode specifies how to handle a quoted field that is still open
+// at the end of its line.
+type UnbalancedQuoteMode int
+
+const (
+	// UnbalancedQuoteError lets quoted fields span lines and returns an error
+	// if one is still open at the end of the input (default).
+	UnbalancedQuoteError UnbalancedQuoteMode = iota
+	// UnbalancedQuoteSkip drops a record whose quoted field is still open at
+	// the end of its line.
+	UnbalancedQuoteSkip
+	// UnbalancedQuoteBalanceAtLineEnd closes a quoted field that is still
+	// open at the end of its line, ending the record there.
+	UnbalancedQuoteBalanceAtLineEnd
+)
+
+// errUnbalancedQuote reports a quoted field left open at the end of its line
+// under UnbalancedQuoteSkip. The parser skips such records.
+var errUnbalancedQuote = errors.New("unbalanced quote")
+
 // Options configures the parser behavior.
 type Options struct {
 	// Comma is the field delimiter. Default: ','
 	Comma rune
+	// Quote is the quote character. It is escaped inside quoted fields by doubling it.
+	// Default: '"' (also used when 0)
+	Quote rune
 	// Comment is the comment character. Lines starting with this are skipped. Default: 0 (disabled)
 	Comment rune
 	// FieldsPerRecord validates field count. 0=first record sets count, negative=no validation
 	FieldsPerRecord int
+	// NormalizeFieldCount makes Parse pad records with too few fields with empty
+	// fields and truncate records with too many, instead of reporting
+	// ErrFieldCount, whenever FieldsPerRecord sets an expected count
+	NormalizeFieldCount bool
 	// LazyQuotes allows quotes in unquoted fields
 	LazyQuotes bool
 	// TrimLeadingSpace trims leading whitespace from fields
 	TrimLeadingSpace bool
+	// TrimTrailingSpace trims trailing spaces and tabs from unquoted fields
+	TrimTrailingSpace bool
 	// OnBadLine specifies how to handle malformed lines. Default: BadLineModeError
 	OnBadLine BadLineMode
-	// MaxFieldSize is the maximum allowed size for a single field in bytes. 0 means no limit.
+	// UnbalancedQuotes specifies how to handle a quoted field still open at the end
+	// of its line. With any mode other than UnbalancedQuoteError, quoted fields
+	// cannot span lines. Default: UnbalancedQuoteError
+	UnbalancedQuotes UnbalancedQuoteMode
+	// MaxFieldSize is the maximum allowed size for a single field in bytes, measured
+	// after unescaping and enforced while quoted fields are built. 0 means no limit.
 	MaxFieldSize int
 	// MaxRecordSize is the maximum allowed size for a single record in bytes. 0 means no limit.
 	MaxRecordSize int
+	// MaxRecords is the maximum number of records Parse returns. Reaching one more
+	// is an error; with BadLineModeSkip or BadLineModeWarn, parsing stops there and
+	// the records read so far are returned instead. 0 means no limit.
+	MaxRecords int
 	// WarningCallback is invoked for warnings when OnBadLine is BadLineModeWarn
 	WarningCallback func(line int, message string)
+	// Escape, if not 0, is an escape character recognized in quoted and unquoted
+	// fields. The sequences n, r and t after it decode to newline, carriage return
+	// and tab; any other escaped character is kept literally, so an escaped
+	// delimiter, quote or line break is part of the field rather than ending it.
+	// Default: 0 (disabled)
+	Escape rune
+	// Terminator, if not empty, is a sentinel line (such as "." or "[EOF]") that ends
+	// parsing. The line must match exactly and is not returned as a record; any input
+	// after it is ignored. The sentinel cannot contain the delimiter or quotes.
+	// Default: "" (disabled)
+	Terminator string
+	// SkipRows is the number of physical lines discarded before parsing starts,
+	// such as metadata lines above the header. Lines are counted raw: quotes and
+	// comment characters in them are ignored. Default: 0
+	SkipRows int
+	// Deadline, if not zero, is the time after which parsing fails with ErrTimeout.
+	// The clock is read every deadlineCheckInterval tokens, both between
+	// records and inside long records and quoted fields.
+	// Default: zero (no deadline)
+	Deadline time.Time
+	// LoneCR makes a "\r" not followed by "\n" a line break, as in classic Mac
+	// OS files. Otherwise parsing stops at it and the rest of the input is
+	// ignored. Default: false
+	LoneCR bool
 }
 
+// deadlineCheckInterval is the number of tokens read between clock checks
+// against Options.Deadline.
+const deadlineCheckInterval = 4096
+
 // DefaultOptions returns default parser options.
 // Note: FieldsPerRecord defaults to -1 (no validation) for backward compatibility.
 // Set to 0 for encoding/csv-compatible behavior where first record sets expected count.
 func DefaultOptions() Options {
 	return Options{
 		Comma:            ',',
+		Quote:            '"',
 		Comment:          0,
 		FieldsPerRecord:  -1, // No validation by default for backward compatibility
 		LazyQuotes:       false,
@@ -65,9 +136,55 @@ type Parser struct {
 	current        *shapetokenizer.Token
 	hasToken       bool
 	opts           Options
-	expectedFields int // Set from first record when FieldsPerRecord is 0
-	currentLine    int
-	currentColumn  int
+	expectedFields int          // Set from first record when FieldsPerRecord is 0
+	recordNum      int          // Records started so far, including ones that failed
+	recordStart    ast.Position // Position of the record being parsed
+	tokens         int          // Tokens read so far
+	nextCheck      int          // Token count at which the Deadline is next checked
+	lfEnds         int          // Records ended by "\n"
+	crlfEnds       int          // Records ended by "\r\n"
+	crEnds         int          // Records ended by a lone "\r", with LoneCR
+}
+
+// Errors wrapped by Error for conditions callers may want to test for.
+var (
+	// ErrFieldCount indicates a record has the wrong number of fields.
+	ErrFieldCount = errors.New("wrong number of fields")
+	// ErrFieldTooLarge indicates a field exceeded MaxFieldSize.
+	ErrFieldTooLarge = errors.New("field exceeds maximum size")
+	// ErrRecordTooLarge indicates a record exceeded MaxRecordSize.
+	ErrRecordTooLarge = errors.New("record exceeds maximum size")
+	// ErrTimeout indicates parsing did not finish before the Deadline.
+	ErrTimeout = errors.New("parse timeout exceeded")
+	// ErrTooManyRecords indicates the input has more records than MaxRecords.
+	ErrTooManyRecords = errors.New("too many records")
+)
+
+// Error is a parse error annotated with the position where it occurred.
+type Error struct {
+	// StartLine is the line where the record containing the error starts (1-indexed).
+	StartLine int
+	// Line is the line where the error occurred (1-indexed).
+	Line int
+	// Column is the column where the error occurred (1-indexed).
+	Column int
+	// Offset is the byte offset of the error in the input (0-indexed).
+	Offset int
+	// Record is the number of the record containing the error (1-indexed),
+	// counting records that failed to parse.
+	Record int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the underlying error followed by its position.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v at line %d, column %d", e.Err, e.Line, e.Column)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
 }
 
 // NewParser creates a new CSV parser for the given input string.
@@ -78,6 +195,7 @@ func NewParser(input string) *Parser {
 
 // NewParserWithOptions creates a new CSV parser with custom options.
 func NewParserWithOptions(input string, opts Options) *Parser {
+	input = bom.StripString(input)
 	return newParserWithStreamAndOptions(shapetokenizer.NewStream(input), opts)
 }
 
@@ -94,9 +212,16 @@ func NewParserFromStreamWithOptions(stream shapetokenizer.Stream, opts Options)
 
 // newParserWithStreamAndOptions is the internal constructor that accepts a stream and options.
 func newParserWithStreamAndOptions(stream shapetokenizer.Stream, opts Options) *Parser {
-	// Create tokenizer with matching delimiter option
+	if opts.Quote == 0 {
+		opts.Quote = '"'
+	}
+
+	// Create tokenizer with matching delimiter and quote options
 	tokOpts := tokenizer.Options{
-		Comma: opts.Comma,
+		Comma:  opts.Comma,
+		Quote:  opts.Quote,
+		Escape: opts.Escape,
+		LoneCR: opts.LoneCR,
 	}
 	tok := tokenizer.NewTokenizerWithStreamAndOptions(stream, tokOpts)
 
@@ -104,10 +229,13 @@ func newParserWithStreamAndOptions(stream shapetokenizer.Stream, opts Options) *
 		tokenizer:      &tok,
 		opts:           opts,
 		expectedFields: opts.FieldsPerRecord,
-		currentLine:    1,
-		currentColumn:  1,
 	}
 	p.advance() // Load first token
+
+	// Discard leading lines without interpreting quotes
+	for i := 0; i < opts.SkipRows && p.hasToken; i++ {
+		p.skipLine()
+	}
 	return p
 }
 
@@ -142,7 +270,22 @@ func (p *Parser) Parse() (ast.SchemaNode, error) {
 			continue
 		}
 
+		if err := p.checkDeadline(); err != nil {
+			return nil, err
+		}
+
+		atTerminator := p.isTerminatorLine()
 		record, err := p.parseRecord()
+		if atTerminator && err == nil && len(record.Elements()) == 1 {
+			break
+		}
+		if errors.Is(err, errUnbalancedQuote) {
+			p.skipLine()
+			continue
+		}
+		if errors.Is(err, ErrTimeout) {
+			return nil, err
+		}
 		if err != nil {
 			// Handle error based on OnBadLine mode
 			if err := p.handleBadLine(err); err != nil {
@@ -159,9 +302,11 @@ func (p *Parser) Parse() (ast.SchemaNode, error) {
 			if recordNum == 0 && p.opts.FieldsPerRecord == 0 {
 				// First record sets expected count
 				p.expectedFields = fieldCount
+			} else if p.expectedFields > 0 && fieldCount != p.expectedFields && p.opts.NormalizeFieldCount {
+				record = normalizeFieldCount(record, p.expectedFields)
 			} else if p.expectedFields > 0 && fieldCount != p.expectedFields {
-				fieldErr := fmt.Errorf("record on line %d: wrong number of fields (got %d, expected %d)",
-					p.currentLine, fieldCount, p.expectedFields)
+				fieldErr := p.errorAt(record.Position(), fmt.Errorf("%w (got %d, expected %d)",
+					ErrFieldCount, fieldCount, p.expectedFields))
 				if err := p.handleBadLine(fieldErr); err != nil {
 					return nil, err
 				}
@@ -174,8 +319,8 @@ func (p *Parser) Parse() (ast.SchemaNode, error) {
 		if p.opts.MaxRecordSize > 0 {
 			recordSize := p.calculateRecordSize(record)
 			if recordSize > p.opts.MaxRecordSize {
-				sizeErr := fmt.Errorf("record on line %d exceeds maximum size (%d > %d)",
-					p.currentLine, recordSize, p.opts.MaxRecordSize)
+				sizeErr := p.errorAt(record.Position(), fmt.Errorf("%w (%d > %d)",
+					ErrRecordTooLarge, recordSize, p.opts.MaxRecordSize))
 				if err := p.handleBadLine(sizeErr); err != nil {
 					return nil, err
 				}
@@ -183,6 +328,16 @@ func (p *Parser) Parse() (ast.SchemaNode, error) {
 			}
 		}
 
+		// Check record count limit
+		if p.opts.MaxRecords > 0 && recordNum >= p.opts.MaxRecords {
+			limitErr := p.errorAt(record.Position(), fmt.Errorf("%w (maximum of %d records)", ErrTooManyRecords,
+				p.opts.MaxRecords))
+			if err := p.handleBadLine(limitErr); err != nil {
+				return nil, err
+			}
+			break
+		}
+
 		records = append(records, record)
 		recordNum++
 	}
@@ -190,6 +345,72 @@ func (p *Parser) Parse() (ast.SchemaNode, error) {
 	return ast.NewArrayDataNode(records, ast.ZeroPosition()), nil
 }
 
+// normalizeFieldCount returns record padded with empty fields or truncated to
+// exactly n fields.
+func normalizeFieldCount(record *ast.ArrayDataNode, n int) *ast.ArrayDataNode {
+	fields := record.Elements()
+	if len(fields) > n {
+		return ast.NewArrayDataNode(fields[:n], record.Position())
+	}
+	padded := make([]ast.SchemaNode, n)
+	copy(padded, fields)
+	for i := len(fields); i < n; i++ {
+		padded[i] = ast.NewLiteralNode("", record.Position())
+	}
+	return ast.NewArrayDataNode(padded, record.Position())
+}
+
+// NextRecord parses and returns the next record as a slice of field values.
+// Empty lines and comment lines are skipped. It returns io.EOF when the input
+// is exhausted or the Terminator line is reached.
+//
+// Unlike Parse, NextRecord does not validate field counts or record sizes and
+// does not apply OnBadLine; errors are returned to the caller.
+func (p *Parser) NextRecord() ([]string, error) {
+	for p.hasToken {
+		// Skip empty lines (just newlines)
+		if p.peek() != nil && p.peek().Kind() == tokenizer.TokenNewline {
+			p.advance()
+			continue
+		}
+
+		// Skip comment lines if comment character is set
+		if p.opts.Comment != 0 && p.isCommentLine() {
+			p.skipLine()
+			continue
+		}
+
+		if err := p.checkDeadline(); err != nil {
+			return nil, err
+		}
+
+		atTerminator := p.isTerminatorLine()
+		record, err := p.parseRecord()
+		if errors.Is(err, errUnbalancedQuote) {
+			p.skipLine()
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		elements := record.Elements()
+		if atTerminator && len(elements) == 1 {
+			p.hasToken = false
+			p.current = nil
+			return nil, io.EOF
+		}
+		fields := make([]string, len(elements))
+		for i, elem := range elements {
+			if lit, ok := elem.(*ast.LiteralNode); ok {
+				fields[i], _ = lit.Value().(string)
+			}
+		}
+		return fields, nil
+	}
+	return nil, io.EOF
+}
+
 // handleBadLine handles a parsing error based on OnBadLine mode.
 // Returns nil if parsing should continue, or the error if it should stop.
 func (p *Parser) handleBadLine(err error) error {
@@ -200,7 +421,12 @@ func (p *Parser) handleBadLine(err error) error {
 	case BadLineModeWarn:
 		// Log warning and continue
 		if p.opts.WarningCallback != nil {
-			p.opts.WarningCallback(p.currentLine, err.Error())
+			line := p.recordStart.Line
+			var posErr *Error
+			if errors.As(err, &posErr) {
+				line = posErr.StartLine
+			}
+			p.opts.WarningCallback(line, err.Error())
 		}
 		return nil
 	default:
@@ -231,6 +457,8 @@ func (p *Parser) calculateRecordSize(record *ast.ArrayDataNode) int {
 // Returns *ast.ArrayDataNode representing the record (array of field values).
 func (p *Parser) parseRecord() (*ast.ArrayDataNode, error) {
 	startPos := p.position()
+	p.recordNum++
+	p.recordStart = startPos
 	fields := make([]ast.SchemaNode, 0, 8)
 
 	// Parse first field
@@ -243,6 +471,9 @@ func (p *Parser) parseRecord() (*ast.ArrayDataNode, error) {
 	// Parse additional fields: { "," Field }
 	for p.peek() != nil && p.peek().Kind() == tokenizer.TokenComma {
 		p.advance() // consume comma
+		if p.deadlinePassed() {
+			return nil, p.errorAt(p.position(), ErrTimeout)
+		}
 
 		field, err := p.parseField()
 		if err != nil {
@@ -253,6 +484,14 @@ func (p *Parser) parseRecord() (*ast.ArrayDataNode, error) {
 
 	// Consume line terminator (newline or EOF)
 	if p.peek() != nil && p.peek().Kind() == tokenizer.TokenNewline {
+		switch p.peek().ValueString() {
+		case "\r\n":
+			p.crlfEnds++
+		case "\r":
+			p.crEnds++
+		default:
+			p.lfEnds++
+		}
 		p.advance()
 	}
 	// EOF is also a valid line terminator (no need to advance)
@@ -308,8 +547,8 @@ func (p *Parser) parseField() (*ast.LiteralNode, error) {
 	if p.opts.MaxFieldSize > 0 && field != nil {
 		if s, ok := field.Value().(string); ok {
 			if len(s) > p.opts.MaxFieldSize {
-				return nil, fmt.Errorf("field at %s exceeds maximum size (%d > %d)",
-					startPos.String(), len(s), p.opts.MaxFieldSize)
+				return nil, p.errorAt(startPos, fmt.Errorf("%w (%d > %d)",
+					ErrFieldTooLarge, len(s), p.opts.MaxFieldSize))
 			}
 		}
 	}
@@ -321,11 +560,12 @@ func (p *Parser) parseField() (*ast.LiteralNode, error) {
 //
 // Grammar:
 //
-//	QuotedField = '"' { QuotedChar | EscapedQuote } '"' ;
+//	QuotedField = '"' { QuotedChar | EscapedQuote | EscapeSequence } '"' ;
 //	EscapedQuote = '""' ;
 //
 // Returns *ast.LiteralNode with unescaped string value.
-// Handles embedded quotes (escaped as ""), commas, and newlines.
+// Handles embedded quotes (escaped as "", or with the Escape character),
+// commas, and newlines.
 func (p *Parser) parseQuotedField() (*ast.LiteralNode, error) {
 	startPos := p.position()
 
@@ -340,7 +580,10 @@ func (p *Parser) parseQuotedField() (*ast.LiteralNode, error) {
 	for {
 		token := p.peek()
 		if token == nil || !p.hasToken {
-			return nil, fmt.Errorf("unclosed quoted field at %s", startPos.String())
+			if p.opts.UnbalancedQuotes != UnbalancedQuoteError {
+				return p.unbalancedQuote(value.String(), startPos)
+			}
+			return nil, p.errorAt(startPos, errors.New("unclosed quoted field"))
 		}
 
 		kind := token.Kind()
@@ -353,8 +596,11 @@ func (p *Parser) parseQuotedField() (*ast.LiteralNode, error) {
 			nextToken := p.peek()
 			if nextToken != nil && nextToken.Kind() == tokenizer.TokenDQuote {
 				// Escaped quote - add single quote to value
-				value.WriteByte('"')
+				value.WriteRune(p.opts.Quote)
 				p.advance() // consume second quote
+				if err := p.checkFieldGrowth(value.Len(), startPos); err != nil {
+					return nil, err
+				}
 			} else {
 				// Closing quote - we're done
 				return ast.NewLiteralNode(value.String(), startPos), nil
@@ -363,26 +609,45 @@ func (p *Parser) parseQuotedField() (*ast.LiteralNode, error) {
 			// Field content
 			value.WriteString(token.ValueString())
 			p.advance()
+		} else if kind == tokenizer.TokenEscape {
+			// Escape sequence, such as an escaped quote
+			value.WriteString(p.unescape(token.ValueString()))
+			p.advance()
 		} else if kind == tokenizer.TokenComma {
 			// Delimiter inside quoted field - treat as literal
 			value.WriteRune(p.opts.Comma)
 			p.advance()
 		} else if kind == tokenizer.TokenNewline {
-			// Newline inside quoted field - treat as literal
-			// Detect CRLF vs LF by checking the token value
-			tokenValue := token.ValueString()
-			if tokenValue == "\r\n" {
-				value.WriteString("\r\n")
-			} else {
-				value.WriteByte('\n')
+			if p.opts.UnbalancedQuotes != UnbalancedQuoteError {
+				// The quote is still open at the end of its line
+				return p.unbalancedQuote(value.String(), startPos)
 			}
+			// Newline inside quoted field - treat as literal,
+			// keeping LF, CRLF or a lone CR as written
+			value.WriteString(token.ValueString())
 			p.advance()
 		} else {
-			return nil, fmt.Errorf("unexpected token %s in quoted field at %s", kind, p.positionStr())
+			return nil, p.errorAt(p.position(), fmt.Errorf("unexpected token %s in quoted field", kind))
+		}
+
+		if err := p.checkFieldGrowth(value.Len(), startPos); err != nil {
+			return nil, err
+		}
+		if p.deadlinePassed() {
+			return nil, p.errorAt(p.position(), ErrTimeout)
 		}
 	}
 }
 
+// unbalancedQuote applies UnbalancedQuotes to a quoted field still open at the
+// end of its line. The line terminator is left for parseRecord to consume.
+func (p *Parser) unbalancedQuote(value string, startPos ast.Position) (*ast.LiteralNode, error) {
+	if p.opts.UnbalancedQuotes == UnbalancedQuoteSkip {
+		return nil, p.errorAt(startPos, errUnbalancedQuote)
+	}
+	return ast.NewLiteralNode(value, startPos), nil
+}
+
 // parseUnquotedField parses an unquoted CSV field.
 //
 // Grammar:
@@ -407,47 +672,93 @@ func (p *Parser) parseUnquotedField(startPos ast.Position) (*ast.LiteralNode, er
 			tok := p.peek()
 			if tok.Kind() == tokenizer.TokenComma || tok.Kind() == tokenizer.TokenNewline {
 				break
-			}
-			if tok.Kind() == tokenizer.TokenField {
+			} else if tok.Kind() == tokenizer.TokenField || tok.Kind() == tokenizer.TokenEscape {
 				value.WriteString(tok.ValueString())
 			} else if tok.Kind() == tokenizer.TokenDQuote {
-				value.WriteByte('"')
+				value.WriteRune(p.opts.Quote)
 			}
 			p.advance()
+			if err := p.checkFieldGrowth(value.Len(), startPos); err != nil {
+				return nil, err
+			}
 		}
 
 		result := value.String()
 		if p.opts.TrimLeadingSpace {
 			result = p.trimLeadingSpace(result)
 		}
-		return ast.NewLiteralNode(result, startPos), nil
+		result = p.trimTrailingSpace(result)
+		return ast.NewLiteralNode(p.unescape(result), startPos), nil
 	}
 
 	// Strict mode: quotes are not allowed in unquoted fields
-	if token.Kind() == tokenizer.TokenField {
-		value := token.ValueString()
-		p.advance()
-
-		// Apply TrimLeadingSpace if enabled
-		value = p.trimLeadingSpace(value)
+	if token.Kind() == tokenizer.TokenField || token.Kind() == tokenizer.TokenEscape {
+		var sb strings.Builder
 
-		// Check for invalid quote in middle of unquoted field
-		if strings.ContainsRune(value, '"') {
-			return nil, fmt.Errorf("quote character in unquoted field at %s", startPos.String())
+		// Escape sequences, such as an escaped delimiter, quote or line
+		// break, continue the field
+		for tok := p.peek(); tok != nil && (tok.Kind() == tokenizer.TokenField || tok.Kind() == tokenizer.TokenEscape); tok = p.peek() {
+			sb.WriteString(tok.ValueString())
+			p.advance()
+			if err := p.checkFieldGrowth(sb.Len(), startPos); err != nil {
+				return nil, err
+			}
 		}
 
-		return ast.NewLiteralNode(value, startPos), nil
+		// Apply TrimLeadingSpace and TrimTrailingSpace if enabled
+		value := p.trimLeadingSpace(sb.String())
+		value = p.trimTrailingSpace(value)
+
+		return ast.NewLiteralNode(p.unescape(value), startPos), nil
 	}
 
 	// Quote at start of what should be unquoted field
 	if token.Kind() == tokenizer.TokenDQuote {
-		return nil, fmt.Errorf("quote character in unquoted field at %s", startPos.String())
+		return nil, p.errorAt(startPos, errors.New("quote character in unquoted field"))
 	}
 
 	// Empty field at end of line
 	return ast.NewLiteralNode("", startPos), nil
 }
 
+// unescape decodes the Escape sequences in a field value.
+func (p *Parser) unescape(value string) string {
+	esc := p.opts.Escape
+	if esc == 0 || !strings.ContainsRune(value, esc) {
+		return value
+	}
+
+	var result strings.Builder
+	result.Grow(len(value))
+	escaped := false
+	for _, ch := range value {
+		if !escaped {
+			if ch == esc {
+				escaped = true
+			} else {
+				result.WriteRune(ch)
+			}
+			continue
+		}
+		switch ch {
+		case 'n':
+			result.WriteRune('\n')
+		case 'r':
+			result.WriteRune('\r')
+		case 't':
+			result.WriteRune('\t')
+		default:
+			result.WriteRune(ch)
+		}
+		escaped = false
+	}
+	if escaped {
+		// Trailing escape character with nothing to escape
+		result.WriteRune(esc)
+	}
+	return result.String()
+}
+
 // Helper methods
 
 // peek returns current token without advancing.
@@ -458,6 +769,7 @@ func (p *Parser) peek() *shapetokenizer.Token {
 // advance moves to next token.
 func (p *Parser) advance() {
 	token, ok := p.tokenizer.NextToken()
+	p.tokens++
 	if ok {
 		p.current = token
 		p.hasToken = true
@@ -470,8 +782,7 @@ func (p *Parser) advance() {
 // expect consumes token of expected kind or returns error.
 func (p *Parser) expect(kind string) error {
 	if p.peek() == nil || p.peek().Kind() != kind {
-		return fmt.Errorf("expected %s at %s, got %s",
-			kind, p.positionStr(), p.peek().Kind())
+		return p.errorAt(p.position(), fmt.Errorf("expected %s, got %s", kind, p.peek().Kind()))
 	}
 	p.advance()
 	return nil
@@ -489,9 +800,51 @@ func (p *Parser) position() ast.Position {
 	return ast.ZeroPosition()
 }
 
-// positionStr returns current position as a string for error messages.
-func (p *Parser) positionStr() string {
-	return p.position().String()
+// checkDeadline returns ErrTimeout, positioned at the next record, once
+// Options.Deadline has passed.
+func (p *Parser) checkDeadline() error {
+	if !p.deadlinePassed() {
+		return nil
+	}
+	pos := p.position()
+	return &Error{
+		StartLine: pos.Line,
+		Line:      pos.Line,
+		Column:    pos.Column,
+		Offset:    pos.Offset,
+		Record:    p.recordNum + 1,
+		Err:       ErrTimeout,
+	}
+}
+
+// Terminators returns the number of records parsed so far that were ended by
+// "\n", by "\r\n" and, with LoneCR, by a lone "\r". Line breaks inside quoted
+// fields, blank lines, comment lines and skipped rows are not counted, nor is
+// a record ended by EOF.
+func (p *Parser) Terminators() (lf, crlf, cr int) {
+	return p.lfEnds, p.crlfEnds, p.crEnds
+}
+
+// deadlinePassed reports whether Options.Deadline has passed. The clock is
+// read only every deadlineCheckInterval tokens to keep the check cheap.
+func (p *Parser) deadlinePassed() bool {
+	if p.opts.Deadline.IsZero() || p.tokens < p.nextCheck {
+		return false
+	}
+	p.nextCheck = p.tokens + deadlineCheckInterval
+	return time.Now().After(p.opts.Deadline)
+}
+
+// errorAt annotates err with pos and the record being parsed.
+func (p *Parser) errorAt(pos ast.Position, err error) *Error {
+	return &Error{
+		StartLine: p.recordStart.Line,
+		Line:      pos.Line,
+		Column:    pos.Column,
+		Offset:    pos.Offset,
+		Record:    p.recordNum,
+		Err:       err,
+	}
 }
 
 // isCommentLine checks if the current line starts with the comment character.
@@ -507,6 +860,27 @@ func (p *Parser) isCommentLine() bool {
 	return rune(value[0]) == p.opts.Comment
 }
 
+// checkFieldGrowth returns an error once a field being built exceeds MaxFieldSize.
+// Checking while the unescaped value grows, rather than only after the field is
+// complete, bounds allocation for crafted inputs such as long runs of "" escapes.
+func (p *Parser) checkFieldGrowth(size int, startPos ast.Position) error {
+	if p.opts.MaxFieldSize > 0 && size > p.opts.MaxFieldSize {
+		return p.errorAt(startPos, fmt.Errorf("%w (%d > %d)",
+			ErrFieldTooLarge, size, p.opts.MaxFieldSize))
+	}
+	return nil
+}
+
+// isTerminatorLine checks if the current line starts with the Terminator sentinel.
+// The caller confirms the match by checking that the parsed record has one field.
+func (p *Parser) isTerminatorLine() bool {
+	if p.opts.Terminator == "" {
+		return false
+	}
+	token := p.peek()
+	return token != nil && token.Kind() == tokenizer.TokenField && token.ValueString() == p.opts.Terminator
+}
+
 // skipLine advances past all tokens until the next newline or EOF.
 func (p *Parser) skipLine() {
 	for p.hasToken {
@@ -525,3 +899,11 @@ func (p *Parser) trimLeadingSpace(s string) string {
 	}
 	return strings.TrimLeft(s, " \t")
 }
+
+// trimTrailingSpace removes trailing whitespace from a string if TrimTrailingSpace is enabled.
+func (p *Parser) trimTrailingSpace(s string) string {
+	if !p.opts.TrimTrailingSpace {
+		return s
+	}
+	return strings.TrimRight(s, " \t")
+}
@@ -1,7 +1,12 @@
 package parser
 
 import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shapestone/shape-core/pkg/ast"
 	shapetokenizer "github.com/shapestone/shape-core/pkg/tokenizer"
@@ -1041,6 +1046,105 @@ func TestTrimLeadingSpace(t *testing.T) {
 	}
 }
 
+func TestTrimTrailingSpace(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		trim       bool
+		wantFields []string
+	}{
+		{
+			name:       "trim trailing spaces",
+			input:      "a ,b ,c ",
+			trim:       true,
+			wantFields: []string{"a", "b", "c"},
+		},
+		{
+			name:       "no trim - preserve spaces",
+			input:      "a ,b ,c ",
+			trim:       false,
+			wantFields: []string{"a ", "b ", "c "},
+		},
+		{
+			name:       "trim tabs",
+			input:      "a\t,b\t,c\t",
+			trim:       true,
+			wantFields: []string{"a", "b", "c"},
+		},
+		{
+			name:       "trim mixed whitespace",
+			input:      "Alice \t ,30",
+			trim:       true,
+			wantFields: []string{"Alice", "30"},
+		},
+		{
+			name:       "quoted fields not affected by trim",
+			input:      "\"a \",\"b \"",
+			trim:       true,
+			wantFields: []string{"a ", "b "},
+		},
+		{
+			name:       "all-space fields become empty",
+			input:      "   ,\t,c",
+			trim:       true,
+			wantFields: []string{"", "", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{
+				Comma:             ',',
+				TrimTrailingSpace: tt.trim,
+				FieldsPerRecord:   -1,
+			}
+
+			p := NewParserWithOptions(tt.input, opts)
+			node, err := p.Parse()
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			arr, ok := node.(*ast.ArrayDataNode)
+			if !ok {
+				t.Fatalf("expected *ast.ArrayDataNode, got %T", node)
+			}
+
+			if arr.Len() != 1 {
+				t.Fatalf("expected 1 record, got %d", arr.Len())
+			}
+
+			record := arr.Get(0)
+			recordArr, ok := record.(*ast.ArrayDataNode)
+			if !ok {
+				t.Fatalf("expected *ast.ArrayDataNode for record, got %T", record)
+			}
+
+			if recordArr.Len() != len(tt.wantFields) {
+				t.Fatalf("expected %d fields, got %d", len(tt.wantFields), recordArr.Len())
+			}
+
+			for i, wantField := range tt.wantFields {
+				field := recordArr.Get(i)
+				lit, ok := field.(*ast.LiteralNode)
+				if !ok {
+					t.Fatalf("field %d: expected *ast.LiteralNode, got %T", i, field)
+				}
+
+				str, ok := lit.Value().(string)
+				if !ok {
+					t.Fatalf("field %d: expected string value, got %T", i, lit.Value())
+				}
+
+				if str != wantField {
+					t.Errorf("field %d: expected %q, got %q", i, wantField, str)
+				}
+			}
+		})
+	}
+}
+
 // TestLazyQuotes tests the LazyQuotes option for unquoted fields
 func TestLazyQuotes(t *testing.T) {
 	tests := []struct {
@@ -1236,6 +1340,74 @@ func TestFieldsPerRecord(t *testing.T) {
 	}
 }
 
+// TestNormalizeFieldCount tests padding and truncation of ragged records
+func TestNormalizeFieldCount(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		fieldsPerRecord int
+		want            [][]string
+	}{
+		{
+			name:            "short row padded",
+			input:           "a,b,c\nd",
+			fieldsPerRecord: 3,
+			want:            [][]string{{"a", "b", "c"}, {"d", "", ""}},
+		},
+		{
+			name:            "long row truncated",
+			input:           "a,b,c\nd,e,f,g,h",
+			fieldsPerRecord: 3,
+			want:            [][]string{{"a", "b", "c"}, {"d", "e", "f"}},
+		},
+		{
+			name:            "first record sets count",
+			input:           "a,b\nc\nd,e,f",
+			fieldsPerRecord: 0,
+			want:            [][]string{{"a", "b"}, {"c", ""}, {"d", "e"}},
+		},
+		{
+			name:            "no validation leaves records alone",
+			input:           "a,b\nc\nd,e,f",
+			fieldsPerRecord: -1,
+			want:            [][]string{{"a", "b"}, {"c"}, {"d", "e", "f"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{
+				Comma:               ',',
+				FieldsPerRecord:     tt.fieldsPerRecord,
+				NormalizeFieldCount: true,
+				OnBadLine:           BadLineModeError,
+			}
+
+			node, err := NewParserWithOptions(tt.input, opts).Parse()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			arr := node.(*ast.ArrayDataNode)
+			if arr.Len() != len(tt.want) {
+				t.Fatalf("expected %d records, got %d", len(tt.want), arr.Len())
+			}
+			for i, wantRec := range tt.want {
+				rec := arr.Elements()[i].(*ast.ArrayDataNode)
+				if rec.Len() != len(wantRec) {
+					t.Fatalf("record %d: expected %d fields, got %d", i, len(wantRec), rec.Len())
+				}
+				for j, wantField := range wantRec {
+					lit := rec.Elements()[j].(*ast.LiteralNode)
+					if got := lit.Value().(string); got != wantField {
+						t.Errorf("record %d field %d: expected %q, got %q", i, j, wantField, got)
+					}
+				}
+			}
+		})
+	}
+}
+
 // TestQuotedFieldLineEndings tests CRLF vs LF handling in quoted fields
 func TestQuotedFieldLineEndings(t *testing.T) {
 	tests := []struct {
@@ -1367,3 +1539,597 @@ func TestEmptyLines(t *testing.T) {
 		})
 	}
 }
+
+// TestTerminator tests that parsing stops at the sentinel line
+func TestTerminator(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		terminator  string
+		wantRecords int
+		wantLast    string
+	}{
+		{
+			name:        "stops at sentinel",
+			input:       "a,b\nc,d\n.\ne,f\n",
+			terminator:  ".",
+			wantRecords: 2,
+			wantLast:    "c",
+		},
+		{
+			name:        "ignores malformed bytes after sentinel",
+			input:       "a,b\n[EOF]\n\"unclosed",
+			terminator:  "[EOF]",
+			wantRecords: 1,
+			wantLast:    "a",
+		},
+		{
+			name:        "sentinel at end of input",
+			input:       "a,b\n.",
+			terminator:  ".",
+			wantRecords: 1,
+			wantLast:    "a",
+		},
+		{
+			name:        "sentinel as one of several fields",
+			input:       ".,b\nc,d\n",
+			terminator:  ".",
+			wantRecords: 2,
+			wantLast:    "c",
+		},
+		{
+			name:        "quoted sentinel is data",
+			input:       "a\n\".\"\nc\n",
+			terminator:  ".",
+			wantRecords: 3,
+			wantLast:    "c",
+		},
+		{
+			name:        "sentinel inside multi-line quoted field",
+			input:       "\"x\n.\ny\"\nc\n",
+			terminator:  ".",
+			wantRecords: 2,
+			wantLast:    "c",
+		},
+		{
+			name:        "disabled",
+			input:       "a\n.\nc\n",
+			terminator:  "",
+			wantRecords: 3,
+			wantLast:    "c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Terminator = tt.terminator
+
+			node, err := NewParserWithOptions(tt.input, opts).Parse()
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			arr := node.(*ast.ArrayDataNode)
+			if arr.Len() != tt.wantRecords {
+				t.Fatalf("Parse() got %d records, want %d", arr.Len(), tt.wantRecords)
+			}
+			last := arr.Elements()[arr.Len()-1].(*ast.ArrayDataNode)
+			if got := last.Elements()[0].(*ast.LiteralNode).Value(); got != tt.wantLast {
+				t.Errorf("last record first field = %q, want %q", got, tt.wantLast)
+			}
+
+			// NextRecord must stop at the same place
+			p := NewParserWithOptions(tt.input, opts)
+			count := 0
+			for {
+				_, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextRecord() unexpected error: %v", err)
+				}
+				count++
+			}
+			if count != tt.wantRecords {
+				t.Errorf("NextRecord() got %d records, want %d", count, tt.wantRecords)
+			}
+		})
+	}
+}
+
+// TestMaxFieldSizeUnescaped tests that the limit applies to unescaped content
+// and stops the quoted-field builder early
+func TestMaxFieldSizeUnescaped(t *testing.T) {
+	escapes := strings.Repeat(`""`, 100000)
+	tests := []struct {
+		name      string
+		input     string
+		lazy      bool
+		wantErr   bool
+		wantValue string
+	}{
+		{
+			name:    "many escaped quotes",
+			input:   `"` + escapes + `"`,
+			wantErr: true,
+		},
+		{
+			name:    "unclosed field of escaped quotes",
+			input:   `"` + escapes,
+			wantErr: true,
+		},
+		{
+			name:    "lazy quotes",
+			input:   `a"` + strings.Repeat(`x"`, 100),
+			lazy:    true,
+			wantErr: true,
+		},
+		{
+			name:      "escapes within limit",
+			input:     `"` + strings.Repeat(`""`, 8) + `"`,
+			wantValue: strings.Repeat(`"`, 8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.MaxFieldSize = 16
+			opts.LazyQuotes = tt.lazy
+
+			node, err := NewParserWithOptions(tt.input, opts).Parse()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for field exceeding max size, got nil")
+				}
+				if !strings.Contains(err.Error(), "exceeds maximum size (17 > 16)") {
+					t.Errorf("error = %v, want it to stop at 17 bytes", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rec := node.(*ast.ArrayDataNode).Elements()[0].(*ast.ArrayDataNode)
+			if got := rec.Elements()[0].(*ast.LiteralNode).Value(); got != tt.wantValue {
+				t.Errorf("value = %q, want %q", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestQuoteChar(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		quote   rune
+		lazy    bool
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:  "single-quoted field with delimiter",
+			input: "'a,b',c",
+			quote: '\'',
+			want:  [][]string{{"a,b", "c"}},
+		},
+		{
+			name:  "doubled single quote is escaped",
+			input: "'it''s',x\n",
+			quote: '\'',
+			want:  [][]string{{"it's", "x"}},
+		},
+		{
+			name:  "double quote is ordinary content",
+			input: "say \"hi\",'a\nb'",
+			quote: '\'',
+			want:  [][]string{{"say \"hi\"", "a\nb"}},
+		},
+		{
+			name:    "configured quote in unquoted field",
+			input:   "it's,x",
+			quote:   '\'',
+			wantErr: true,
+		},
+		{
+			name:  "configured quote in unquoted field with LazyQuotes",
+			input: "it's,x",
+			quote: '\'',
+			lazy:  true,
+			want:  [][]string{{"it's", "x"}},
+		},
+		{
+			name:  "zero quote defaults to double quote",
+			input: "\"a,b\",c",
+			want:  [][]string{{"a,b", "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Quote = tt.quote
+			opts.LazyQuotes = tt.lazy
+
+			p := NewParserWithOptions(tt.input, opts)
+			var got [][]string
+			for {
+				record, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Fatalf("NextRecord() unexpected error: %v", err)
+					}
+					return
+				}
+				got = append(got, record)
+			}
+			if tt.wantErr {
+				t.Fatal("NextRecord() expected error")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextRecord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxRecords(t *testing.T) {
+	input := "a\nb\nc\n"
+	tests := []struct {
+		name        string
+		maxRecords  int
+		mode        BadLineMode
+		wantRecords int
+		wantErr     bool
+		wantWarns   int
+	}{
+		{name: "unlimited", maxRecords: 0, wantRecords: 3},
+		{name: "exactly at limit", maxRecords: 3, wantRecords: 3},
+		{name: "one over limit", maxRecords: 2, wantErr: true},
+		{name: "skip stops at limit", maxRecords: 2, mode: BadLineModeSkip, wantRecords: 2},
+		{name: "warn stops at limit", maxRecords: 1, mode: BadLineModeWarn, wantRecords: 1, wantWarns: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns := 0
+			opts := DefaultOptions()
+			opts.MaxRecords = tt.maxRecords
+			opts.OnBadLine = tt.mode
+			opts.WarningCallback = func(line int, message string) { warns++ }
+
+			node, err := NewParserWithOptions(input, opts).Parse()
+			if tt.wantErr {
+				if !errors.Is(err, ErrTooManyRecords) || !strings.Contains(err.Error(), "maximum of 2 records") {
+					t.Fatalf("Parse() error = %v, want record limit error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if got := node.(*ast.ArrayDataNode).Len(); got != tt.wantRecords {
+				t.Errorf("Parse() got %d records, want %d", got, tt.wantRecords)
+			}
+			if warns != tt.wantWarns {
+				t.Errorf("got %d warnings, want %d", warns, tt.wantWarns)
+			}
+		})
+	}
+}
+
+func TestSkipRows(t *testing.T) {
+	input := "Report generated 2024-01-01\n# \"vendor\" export\nname,note\nAlice,\"two\nlines\"\nBob,x\n"
+	tests := []struct {
+		name     string
+		skipRows int
+		want     [][]string
+	}{
+		{
+			name:     "skip none",
+			skipRows: 0,
+			want: [][]string{
+				{"Report generated 2024-01-01"}, {"# \"vendor\" export"},
+				{"name", "note"}, {"Alice", "two\nlines"}, {"Bob", "x"},
+			},
+		},
+		{
+			name:     "skip metadata lines",
+			skipRows: 2,
+			want:     [][]string{{"name", "note"}, {"Alice", "two\nlines"}, {"Bob", "x"}},
+		},
+		{
+			name:     "skip more lines than the input has",
+			skipRows: 10,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.SkipRows = tt.skipRows
+			opts.LazyQuotes = true
+
+			p := NewParserWithOptions(input, opts)
+			var got [][]string
+			for {
+				record, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextRecord() unexpected error: %v", err)
+				}
+				got = append(got, record)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextRecord() = %q, want %q", got, tt.want)
+			}
+
+			node, err := NewParserWithOptions(input, opts).Parse()
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if n := node.(*ast.ArrayDataNode).Len(); n != len(tt.want) {
+				t.Errorf("Parse() got %d records, want %d", n, len(tt.want))
+			}
+		})
+	}
+}
+
+// TestUnbalancedQuotes tests recovery from quoted fields left open at a line end
+func TestUnbalancedQuotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  UnbalancedQuoteMode
+		want  [][]string
+	}{
+		{
+			name:  "skip",
+			input: "a,\"b\"\",c\nd,e\n",
+			mode:  UnbalancedQuoteSkip,
+			want:  [][]string{{"d", "e"}},
+		},
+		{
+			name:  "skip at EOF",
+			input: "a,b\nc,\"d",
+			mode:  UnbalancedQuoteSkip,
+			want:  [][]string{{"a", "b"}},
+		},
+		{
+			name:  "balance at line end",
+			input: "a,\"b\"\",c\r\nd,e\n",
+			mode:  UnbalancedQuoteBalanceAtLineEnd,
+			want:  [][]string{{"a", "b\",c"}, {"d", "e"}},
+		},
+		{
+			name:  "balance at EOF",
+			input: "a,b\nc,\"d",
+			mode:  UnbalancedQuoteBalanceAtLineEnd,
+			want:  [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "balanced quotes unaffected",
+			input: "\"a,b\",\"c\"\"d\"\n",
+			mode:  UnbalancedQuoteSkip,
+			want:  [][]string{{"a,b", "c\"d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.UnbalancedQuotes = tt.mode
+
+			p := NewParserWithOptions(tt.input, opts)
+			var got [][]string
+			for {
+				record, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextRecord() unexpected error: %v", err)
+				}
+				got = append(got, record)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextRecord() = %q, want %q", got, tt.want)
+			}
+
+			node, err := NewParserWithOptions(tt.input, opts).Parse()
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if n := node.(*ast.ArrayDataNode).Len(); n != len(tt.want) {
+				t.Errorf("Parse() got %d records, want %d", n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestUnquotedEscapeDelimiters(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		lazy  bool
+		want  [][]string
+	}{
+		{
+			name:  "escaped comma",
+			input: "a\\,b,c\nd,e\n",
+			want:  [][]string{{"a,b", "c"}, {"d", "e"}},
+		},
+		{
+			name:  "escaped newline joins lines",
+			input: "one\\\ntwo,x\r\nthree\\\r\nfour,y\n",
+			want:  [][]string{{"one\ntwo", "x"}, {"three\r\nfour", "y"}},
+		},
+		{
+			name:  "escaped quote at field start",
+			input: "\\\"q\\\",z\n",
+			want:  [][]string{{"\"q\"", "z"}},
+		},
+		{
+			name:  "escaped comma with lazy quotes",
+			input: "a\"b\\,c,d\n",
+			lazy:  true,
+			want:  [][]string{{"a\"b,c", "d"}},
+		},
+		{
+			name:  "trailing escape at EOF",
+			input: "a,b\\",
+			want:  [][]string{{"a", "b\\"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Escape = '\\'
+			opts.LazyQuotes = tt.lazy
+
+			p := NewParserWithOptions(tt.input, opts)
+			var got [][]string
+			for {
+				record, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextRecord() unexpected error: %v", err)
+				}
+				got = append(got, record)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextRecord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// An unescaped quote is still rejected in strict mode
+	opts := DefaultOptions()
+	opts.Escape = '\\'
+	if _, err := NewParserWithOptions("a\\\"b\"c,d\n", opts).Parse(); err == nil {
+		t.Error("Parse() expected error for a bare quote after an escaped one")
+	}
+}
+
+func TestLoneCR(t *testing.T) {
+	opts := DefaultOptions()
+	opts.LoneCR = true
+	p := NewParserWithOptions("a,\"x\ry\"\rb,c\r\nd,e\r", opts)
+	var got [][]string
+	for {
+		record, err := p.NextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextRecord() unexpected error: %v", err)
+		}
+		got = append(got, record)
+	}
+	want := [][]string{{"a", "x\ry"}, {"b", "c"}, {"d", "e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NextRecord() = %q, want %q", got, want)
+	}
+	if lf, crlf, cr := p.Terminators(); lf != 0 || crlf != 1 || cr != 2 {
+		t.Errorf("Terminators() = %d, %d, %d, want 0, 1, 2", lf, crlf, cr)
+	}
+}
+
+func TestEscapeInQuotedFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]string
+	}{
+		{
+			name:  "escaped quote",
+			input: "\"x\\\"y\",z\n",
+			want:  [][]string{{"x\"y", "z"}},
+		},
+		{
+			name:  "escaped backslash",
+			input: "\"a\\\\b\",c\n",
+			want:  [][]string{{"a\\b", "c"}},
+		},
+		{
+			name:  "escaped backslash before closing quote",
+			input: "\"a\\\\\",b\n",
+			want:  [][]string{{"a\\", "b"}},
+		},
+		{
+			name:  "escape sequences",
+			input: "\"a\\nb\\tc\"\n",
+			want:  [][]string{{"a\nb\tc"}},
+		},
+		{
+			name:  "doubled quote still escapes",
+			input: "\"a\"\"b\"\n",
+			want:  [][]string{{"a\"b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Escape = '\\'
+
+			p := NewParserWithOptions(tt.input, opts)
+			var got [][]string
+			for {
+				record, err := p.NextRecord()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextRecord() unexpected error: %v", err)
+				}
+				got = append(got, record)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextRecord() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDeadlineInsideRecord tests that the deadline is checked while a single
+// long record or quoted field is parsed, not only between records
+func TestDeadlineInsideRecord(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"wide record", strings.Repeat("a,", 10000) + "a\n"},
+		{"long quoted field", "\"" + strings.Repeat("x,\n", 10000) + "\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Deadline = time.Now().Add(-time.Second)
+			p := NewParserWithOptions(tt.input, opts)
+			// Skip the check before the first record so only the checks
+			// inside it can fire
+			p.nextCheck = p.tokens + 1
+
+			_, err := p.Parse()
+			var posErr *Error
+			if !errors.As(err, &posErr) || !errors.Is(err, ErrTimeout) {
+				t.Fatalf("Parse() error = %v, want ErrTimeout", err)
+			}
+			if posErr.Record != 1 {
+				t.Errorf("Error.Record = %d, want 1", posErr.Record)
+			}
+		})
+	}
+}
@@ -8,12 +8,23 @@ import (
 type Options struct {
 	// Comma is the field delimiter. Default: ','
 	Comma rune
+	// Quote is the quote character, emitted as TokenDQuote. Default: '"' (also used when 0)
+	Quote rune
+	// Escape, if not 0, is an escape character. It and the character after it,
+	// or a CRLF after it, are emitted together as TokenEscape, so an escaped
+	// delimiter, quote or line break is never emitted as a structural token.
+	// Default: 0 (disabled)
+	Escape rune
+	// LoneCR makes a "\r" not followed by "\n" a TokenNewline. Otherwise no
+	// matcher accepts it and tokenizing stops there. Default: false
+	LoneCR bool
 }
 
 // DefaultOptions returns default tokenizer options.
 func DefaultOptions() Options {
 	return Options{
 		Comma: ',',
+		Quote: '"',
 	}
 }
 
@@ -33,19 +44,32 @@ func NewTokenizer() tokenizer.Tokenizer {
 
 // NewTokenizerWithOptions creates a tokenizer with custom options.
 func NewTokenizerWithOptions(opts Options) tokenizer.Tokenizer {
-	return tokenizer.NewTokenizerWithoutWhitespace(
+	if opts.Quote == 0 {
+		opts.Quote = '"'
+	}
+	var matchers []tokenizer.Matcher
+	if opts.Escape != 0 {
+		// Escape sequences before the structural tokens they may escape
+		matchers = append(matchers, EscapeMatcher(opts.Escape))
+	}
+	matchers = append(matchers,
 		// Newlines (CRLF before LF for greedy matching)
 		tokenizer.StringMatcherFunc(TokenNewline, "\r\n"),
 		tokenizer.StringMatcherFunc(TokenNewline, "\n"),
-
+	)
+	if opts.LoneCR {
+		matchers = append(matchers, tokenizer.StringMatcherFunc(TokenNewline, "\r"))
+	}
+	matchers = append(matchers,
 		// Structural tokens - use custom delimiter
 		tokenizer.StringMatcherFunc(TokenComma, string(opts.Comma)),
-		tokenizer.StringMatcherFunc(TokenDQuote, `"`),
+		tokenizer.StringMatcherFunc(TokenDQuote, string(opts.Quote)),
 
 		// Field content (everything else)
 		// The parser handles the distinction between quoted and unquoted fields
-		FieldContentMatcherWithDelim(opts.Comma),
+		fieldContentMatcher(opts.Comma, opts.Quote, opts.Escape),
 	)
+	return tokenizer.NewTokenizerWithoutWhitespace(matchers...)
 }
 
 // NewTokenizerWithStream creates a tokenizer for CSV format using a pre-configured stream.
@@ -80,21 +104,64 @@ func FieldContentMatcher() tokenizer.Matcher {
 //
 // Performance: Uses ByteStream for fast ASCII scanning when available.
 func FieldContentMatcherWithDelim(delim rune) tokenizer.Matcher {
+	return FieldContentMatcherWithDelimAndQuote(delim, '"')
+}
+
+// FieldContentMatcherWithDelimAndQuote creates a matcher for field content with a
+// custom delimiter and quote character. Matches runs of characters that are not
+// the delimiter, the quote character, CR, or LF.
+func FieldContentMatcherWithDelimAndQuote(delim, quote rune) tokenizer.Matcher {
+	return fieldContentMatcher(delim, quote, 0)
+}
+
+// fieldContentMatcher is like FieldContentMatcherWithDelimAndQuote, but also
+// stops at the escape character unless it is 0.
+func fieldContentMatcher(delim, quote, escape rune) tokenizer.Matcher {
+	if escape == 0 {
+		// Stopping at the quote twice is the same as not stopping at an escape
+		escape = quote
+	}
 	return func(stream tokenizer.Stream) *tokenizer.Token {
-		// Try ByteStream fast path (only if delimiter is ASCII)
-		if delim < 128 {
+		// Try ByteStream fast path (only if delimiter, quote and escape are ASCII)
+		if delim < 128 && quote < 128 && escape < 128 {
 			if byteStream, ok := stream.(tokenizer.ByteStream); ok {
-				return fieldContentMatcherByteWithDelim(byteStream, byte(delim))
+				return fieldContentMatcherByteWithDelim(byteStream, byte(delim), byte(quote), byte(escape))
 			}
 		}
 
 		// Fallback to rune-based matcher
-		return fieldContentMatcherRuneWithDelim(stream, delim)
+		return fieldContentMatcherRuneWithDelim(stream, delim, quote, escape)
+	}
+}
+
+// EscapeMatcher creates a matcher for an escape sequence: the escape character
+// followed by any character, or by CRLF. An escape character at the end of the
+// input is matched alone.
+func EscapeMatcher(escape rune) tokenizer.Matcher {
+	return func(stream tokenizer.Stream) *tokenizer.Token {
+		r, ok := stream.PeekChar()
+		if !ok || r != escape {
+			return nil
+		}
+		stream.NextChar()
+		value := []rune{escape}
+
+		if r, ok = stream.PeekChar(); ok {
+			stream.NextChar()
+			value = append(value, r)
+			if r == '\r' {
+				if lf, ok := stream.PeekChar(); ok && lf == '\n' {
+					stream.NextChar()
+					value = append(value, lf)
+				}
+			}
+		}
+		return tokenizer.NewToken(TokenEscape, value)
 	}
 }
 
 // fieldContentMatcherByteWithDelim uses ByteStream for optimal performance.
-func fieldContentMatcherByteWithDelim(stream tokenizer.ByteStream, delim byte) *tokenizer.Token {
+func fieldContentMatcherByteWithDelim(stream tokenizer.ByteStream, delim, quote, escape byte) *tokenizer.Token {
 	startPos := stream.BytePosition()
 
 	for {
@@ -104,7 +171,7 @@ func fieldContentMatcherByteWithDelim(stream tokenizer.ByteStream, delim byte) *
 		}
 
 		// Stop at delimiters
-		if b == delim || b == '"' || b == '\n' || b == '\r' {
+		if b == delim || b == quote || b == escape || b == '\n' || b == '\r' {
 			break
 		}
 
@@ -121,7 +188,7 @@ func fieldContentMatcherByteWithDelim(stream tokenizer.ByteStream, delim byte) *
 }
 
 // fieldContentMatcherRuneWithDelim is the fallback rune-based implementation.
-func fieldContentMatcherRuneWithDelim(stream tokenizer.Stream, delim rune) *tokenizer.Token {
+func fieldContentMatcherRuneWithDelim(stream tokenizer.Stream, delim, quote, escape rune) *tokenizer.Token {
 	var value []rune
 
 	for {
@@ -131,7 +198,7 @@ func fieldContentMatcherRuneWithDelim(stream tokenizer.Stream, delim rune) *toke
 		}
 
 		// Stop at delimiters
-		if r == delim || r == '"' || r == '\n' || r == '\r' {
+		if r == delim || r == quote || r == escape || r == '\n' || r == '\r' {
 			break
 		}
 
@@ -298,6 +298,67 @@ func TestNewTokenizer_BasicTokens(t *testing.T) {
 	}
 }
 
+// TestNewTokenizer_Escape tests that escape sequences are emitted as single
+// tokens when an escape character is set.
+func TestNewTokenizer_Escape(t *testing.T) {
+	type token struct {
+		kind  string
+		value string
+	}
+	tests := []struct {
+		name     string
+		input    string
+		expected []token
+	}{
+		{
+			name:     "escaped quote in quoted field",
+			input:    `"x\"y"`,
+			expected: []token{{TokenDQuote, `"`}, {TokenField, "x"}, {TokenEscape, `\"`}, {TokenField, "y"}, {TokenDQuote, `"`}},
+		},
+		{
+			name:     "escaped escape and delimiter",
+			input:    `a\\\,b`,
+			expected: []token{{TokenField, "a"}, {TokenEscape, `\\`}, {TokenEscape, `\,`}, {TokenField, "b"}},
+		},
+		{
+			name:     "escaped CRLF",
+			input:    "a\\\r\nb",
+			expected: []token{{TokenField, "a"}, {TokenEscape, "\\\r\n"}, {TokenField, "b"}},
+		},
+		{
+			name:     "trailing escape",
+			input:    `a\`,
+			expected: []token{{TokenField, "a"}, {TokenEscape, `\`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Escape = '\\'
+			tok := NewTokenizerWithOptions(opts)
+			tok.Initialize(tt.input)
+
+			var got []token
+			for {
+				next, ok := tok.NextToken()
+				if !ok {
+					break
+				}
+				got = append(got, token{next.Kind(), next.ValueString()})
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("got tokens %q, want %q", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("token %d = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
 // TestTokenizer_LargeCSV tests tokenizing a large CSV that crosses buffer boundaries.
 func TestTokenizer_LargeCSV(t *testing.T) {
 	// Create a CSV with many rows to test buffering
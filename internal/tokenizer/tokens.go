@@ -15,6 +15,9 @@ const (
 	// Field content token
 	TokenField = "Field" // Field content (any non-delimiter character)
 
+	// Escape token, emitted only when Options.Escape is set
+	TokenEscape = "Escape" // escape character and the character it escapes
+
 	// Special token
 	TokenEOF = "EOF" // End of file
 )
@@ -2,9 +2,13 @@
 package csv
 
 import (
+	"errors"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/shapestone/shape-csv/internal/parser"
 )
 
 // EscapeMode specifies how escape characters are handled.
@@ -29,6 +33,8 @@ type AdvancedOptions struct {
 
 	// PreProcess is called for each record before field processing.
 	// Can modify fields before they are assigned to struct fields.
+	// ParseWithAdvancedOptions calls it on each record as it is parsed; a nil
+	// result drops the record.
 	PreProcess func([]string) []string
 
 	// PostProcess is called for each unmarshaled struct after field assignment.
@@ -44,6 +50,48 @@ func DefaultAdvancedOptions() AdvancedOptions {
 	}
 }
 
+// ParseWithAdvancedOptions parses data in the default dialect and returns its
+// records, calling opts.PreProcess on each record as it is parsed. PreProcess
+// may return a rewritten record, or nil to drop the record. EscapeMode and
+// EscapeChar select backslash escapes in fields, as the ReaderOptions
+// fields of the same name do.
+//
+// Example:
+//
+//	opts := csv.DefaultAdvancedOptions()
+//	opts.PreProcess = func(record []string) []string {
+//	    if record[0] == "" {
+//	        return nil // skip rows without an ID
+//	    }
+//	    record[1] = strings.ToUpper(record[1])
+//	    return record
+//	}
+//	records, err := csv.ParseWithAdvancedOptions(data, opts)
+func ParseWithAdvancedOptions(data string, opts AdvancedOptions) ([][]string, error) {
+	ropts := DefaultReaderOptions()
+	ropts.EscapeMode = opts.EscapeMode
+	ropts.EscapeChar = opts.EscapeChar
+	input, ropts := prepareInput(data, ropts)
+	p := parser.NewParserWithOptions(input, ropts.parserOptions())
+
+	records := [][]string{}
+	for {
+		record, err := p.NextRecord()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, toParseError(err)
+		}
+		if opts.PreProcess != nil {
+			if record = opts.PreProcess(record); record == nil {
+				continue
+			}
+		}
+		records = append(records, record)
+	}
+}
+
 // MultiValueSeparator is the default separator for multi-value fields.
 const MultiValueSeparator = "|"
 
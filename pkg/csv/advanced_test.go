@@ -1,6 +1,8 @@
 package csv_test
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -132,6 +134,119 @@ func TestFlattenStruct(t *testing.T) {
 	})
 }
 
+func TestUnmarshalRecurse(t *testing.T) {
+	type Address struct {
+		Street string `csv:"street"`
+		City   string `csv:"city"`
+	}
+
+	type Person struct {
+		Name    string  `csv:"name"`
+		Age     int     `csv:"age"`
+		Address Address `csv:"address,recurse"`
+	}
+
+	t.Run("dotted headers", func(t *testing.T) {
+		input := "name,address.street,address.city\nAlice,123 Main St,NYC\nBob,,LA\n"
+		var got []Person
+		if err := csv.Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		want := []Person{
+			{Name: "Alice", Address: Address{Street: "123 Main St", City: "NYC"}},
+			{Name: "Bob", Address: Address{City: "LA"}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("round trip with FlattenStruct", func(t *testing.T) {
+		p := Person{Name: "Carol", Age: 41, Address: Address{Street: "9 Elm", City: "SF"}}
+		flat := csv.FlattenStruct(p, "")
+
+		headers := []string{"name", "age", "address.street", "address.city"}
+		row := make([]string, len(headers))
+		for i, h := range headers {
+			row[i] = flat[h]
+		}
+		var sb strings.Builder
+		if err := csv.WriteAll(&sb, [][]string{headers, row}, csv.DefaultWriterOptions()); err != nil {
+			t.Fatalf("WriteAll() error = %v", err)
+		}
+
+		var got []Person
+		if err := csv.Unmarshal([]byte(sb.String()), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || got[0] != p {
+			t.Errorf("Unmarshal() = %+v, want %+v", got, p)
+		}
+	})
+
+	t.Run("pointer and unmatched columns", func(t *testing.T) {
+		type Contact struct {
+			Name string   `csv:"name"`
+			Home *Address `csv:",recurse"`
+		}
+
+		input := "name,Home.City,Home.Zip,address.city\nDan,Boston,02101,ignored\n"
+		var got []Contact
+		if err := csv.Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Home == nil || *got[0].Home != (Address{City: "Boston"}) {
+			t.Errorf("Unmarshal() = %+v, want Home.City Boston", got)
+		}
+	})
+}
+
+func TestUnmarshalSplit(t *testing.T) {
+	type Item struct {
+		Name   string   `csv:"name"`
+		Tags   []string `csv:"tags"`
+		Scores []int    `csv:"scores,split=;"`
+	}
+
+	t.Run("string and int slices", func(t *testing.T) {
+		input := "name,tags,scores\nwidget,red|blue|green,1;2;3\ngadget,solo,42\n"
+		var got []Item
+		if err := csv.Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		want := []Item{
+			{Name: "widget", Tags: []string{"red", "blue", "green"}, Scores: []int{1, 2, 3}},
+			{Name: "gadget", Tags: []string{"solo"}, Scores: []int{42}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("empty cell gives empty slice", func(t *testing.T) {
+		input := "name,tags,scores\nbare,,\n"
+		var got []Item
+		if err := csv.Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Tags == nil || len(got[0].Tags) != 0 || got[0].Scores == nil || len(got[0].Scores) != 0 {
+			t.Errorf("Unmarshal() = %#v, want empty non-nil slices", got)
+		}
+	})
+
+	t.Run("bad element", func(t *testing.T) {
+		input := "name,tags,scores\nwidget,a,1;x;3\n"
+		var got []Item
+		err := csv.Unmarshal([]byte(input), &got)
+		if err == nil {
+			t.Fatal("Unmarshal() error = nil, want error for non-integer element")
+		}
+		if !strings.Contains(err.Error(), `"x"`) {
+			t.Errorf("Unmarshal() error = %v, want it to name the bad element", err)
+		}
+	})
+}
+
 func TestTransformOptions(t *testing.T) {
 	t.Run("field transform", func(t *testing.T) {
 		opts := csv.TransformOptions{
@@ -228,6 +343,61 @@ func TestAdvancedOptions(t *testing.T) {
 	})
 }
 
+func TestParseWithAdvancedOptions(t *testing.T) {
+	input := "id,name\n1,alice\n,nobody\n2,bob\n"
+
+	tests := []struct {
+		name       string
+		preProcess func([]string) []string
+		want       [][]string
+	}{
+		{
+			name: "no hook",
+			want: [][]string{{"id", "name"}, {"1", "alice"}, {"", "nobody"}, {"2", "bob"}},
+		},
+		{
+			name: "rewrite field",
+			preProcess: func(record []string) []string {
+				record[1] = strings.ToUpper(record[1])
+				return record
+			},
+			want: [][]string{{"id", "NAME"}, {"1", "ALICE"}, {"", "NOBODY"}, {"2", "BOB"}},
+		},
+		{
+			name: "nil drops record",
+			preProcess: func(record []string) []string {
+				if record[0] == "" {
+					return nil
+				}
+				return record
+			},
+			want: [][]string{{"id", "name"}, {"1", "alice"}, {"2", "bob"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultAdvancedOptions()
+			opts.PreProcess = tt.preProcess
+			got, err := csv.ParseWithAdvancedOptions(input, opts)
+			if err != nil {
+				t.Fatalf("ParseWithAdvancedOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithAdvancedOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("parse error", func(t *testing.T) {
+		_, err := csv.ParseWithAdvancedOptions("a,\"b\n", csv.DefaultAdvancedOptions())
+		var perr *csv.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseWithAdvancedOptions() error = %v, want *ParseError", err)
+		}
+	})
+}
+
 func TestMultiValueSeparator(t *testing.T) {
 	if csv.MultiValueSeparator != "|" {
 		t.Errorf("MultiValueSeparator = %q, want %q", csv.MultiValueSeparator, "|")
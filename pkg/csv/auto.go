@@ -0,0 +1,166 @@
+package csv
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shapestone/shape-csv/internal/bom"
+)
+
+// Encodings reported in DetectedOptions.Encoding.
+const (
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF16LE = "UTF-16LE"
+	EncodingUTF16BE = "UTF-16BE"
+	EncodingLatin1  = "ISO-8859-1"
+)
+
+// DetectedOptions describes the dialect ReadFileAuto found in a file.
+type DetectedOptions struct {
+	// Encoding is the detected text encoding, one of EncodingUTF8,
+	// EncodingUTF16LE, EncodingUTF16BE or EncodingLatin1.
+	Encoding string
+
+	// BOM reports whether the file started with a byte order mark.
+	BOM bool
+
+	// HasHeader reports whether the first row was taken as the header.
+	HasHeader bool
+
+	// Options are the reader options used to parse the decoded text,
+	// including the detected delimiter in Comma.
+	Options ReaderOptions
+}
+
+// ReadFileAuto reads the CSV file at path, detecting its encoding, byte order
+// mark, delimiter and header row, and parses it into a Document. When a header
+// is detected, the first row becomes the document headers. The choices made
+// are available from Document.Detected.
+//
+// UTF-8 and UTF-16 are recognized by their byte order mark, or for UTF-16 by
+// the pattern of zero bytes in ASCII-heavy text. Input that is not valid UTF-8
+// is decoded as ISO-8859-1. The delimiter is chosen by DetectDelimiter,
+// falling back to comma, and the header by Sniffer.HasHeader.
+//
+// Example:
+//
+//	doc, err := csv.ReadFileAuto("export.csv")
+//	if err != nil {
+//	    return err
+//	}
+//	detected := doc.Detected()
+//	fmt.Println(detected.Encoding, string(detected.Options.Comma), detected.HasHeader)
+func ReadFileAuto(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseAuto(data)
+}
+
+// parseAuto detects the dialect of data and parses it into a Document.
+func parseAuto(data []byte) (*Document, error) {
+	text, encoding, hasBOM := decodeText(data)
+
+	opts := DefaultReaderOptions()
+	if delim, err := DetectDelimiter([]byte(text)); err == nil {
+		opts.Comma = delim
+	}
+
+	sample := text
+	if len(sample) > detectSampleBytes {
+		sample = sample[:detectSampleBytes]
+	}
+	opts.HasHeader = NewSniffer(sample).HasHeader()
+
+	doc, err := ParseDocumentWithOptions(text, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.detected = &DetectedOptions{
+		Encoding:  encoding,
+		BOM:       hasBOM,
+		HasHeader: opts.HasHeader,
+		Options:   opts,
+	}
+	return doc, nil
+}
+
+// decodeText converts data to a UTF-8 string, detecting its encoding and
+// removing any byte order mark.
+func decodeText(data []byte) (text, encoding string, hasBOM bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte(bom.UTF8)):
+		return string(data[len(bom.UTF8):]), EncodingUTF8, true
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], false), EncodingUTF16LE, true
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], true), EncodingUTF16BE, true
+	}
+
+	if enc := sniffUTF16(data); enc != "" {
+		return decodeUTF16(data, enc == EncodingUTF16BE), enc, false
+	}
+	if utf8.Valid(data) {
+		return string(data), EncodingUTF8, false
+	}
+
+	// Every byte is a valid ISO-8859-1 code point
+	var sb strings.Builder
+	sb.Grow(len(data) * 2)
+	for _, b := range data {
+		sb.WriteRune(rune(b))
+	}
+	return sb.String(), EncodingLatin1, false
+}
+
+// sniffUTF16 guesses the byte order of UTF-16 text without a byte order mark
+// from the zero high bytes of ASCII characters in a sample. It returns "" if
+// data does not look like UTF-16.
+func sniffUTF16(data []byte) string {
+	if len(data) > detectSampleBytes {
+		data = data[:detectSampleBytes]
+	}
+	if len(data) < 2 || len(data)%2 != 0 {
+		return ""
+	}
+
+	evenZeros, oddZeros := 0, 0
+	for i := 0; i+1 < len(data); i += 2 {
+		if data[i] == 0 {
+			evenZeros++
+		}
+		if data[i+1] == 0 {
+			oddZeros++
+		}
+	}
+
+	// Require most characters to be ASCII in one byte order only
+	pairs := len(data) / 2
+	switch {
+	case oddZeros*2 > pairs && evenZeros*10 < pairs:
+		return EncodingUTF16LE
+	case evenZeros*2 > pairs && oddZeros*10 < pairs:
+		return EncodingUTF16BE
+	}
+	return ""
+}
+
+// decodeUTF16 decodes UTF-16 data in the given byte order exactly as
+// ReaderOptions.Encoding does, replacing unpaired surrogates and a trailing
+// odd byte with U+FFFD.
+func decodeUTF16(data []byte, bigEndian bool) string {
+	// Reading from a bytes.Reader cannot fail
+	text, _ := io.ReadAll(&utf16Reader{r: bytes.NewReader(data), bigEndian: bigEndian})
+	return string(text)
+}
+
+// Detected returns the dialect detected by ReadFileAuto, or nil if the
+// document was not read with it.
+func (d *Document) Detected() *DetectedOptions {
+	return d.detected
+}
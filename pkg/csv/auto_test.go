@@ -0,0 +1,139 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, optionally with a
+// byte order mark
+func encodeUTF16(s string, bigEndian, withBOM bool) []byte {
+	units := utf16.Encode([]rune(s))
+	if withBOM {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	out := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	return out
+}
+
+func TestReadFileAuto(t *testing.T) {
+	const text = "name\tcity\tage\nAlice\tZürich\t30\nBob\tParis\t25\n"
+
+	tests := []struct {
+		name        string
+		data        []byte
+		wantEnc     string
+		wantBOM     bool
+		wantComma   rune
+		wantHeader  bool
+		wantHeaders []string
+		wantFirst   []string
+	}{
+		{
+			name:        "UTF-16LE with BOM, tab-delimited",
+			data:        encodeUTF16(text, false, true),
+			wantEnc:     EncodingUTF16LE,
+			wantBOM:     true,
+			wantComma:   '\t',
+			wantHeader:  true,
+			wantHeaders: []string{"name", "city", "age"},
+			wantFirst:   []string{"Alice", "Zürich", "30"},
+		},
+		{
+			name:        "UTF-16BE with BOM",
+			data:        encodeUTF16(text, true, true),
+			wantEnc:     EncodingUTF16BE,
+			wantBOM:     true,
+			wantComma:   '\t',
+			wantHeader:  true,
+			wantHeaders: []string{"name", "city", "age"},
+			wantFirst:   []string{"Alice", "Zürich", "30"},
+		},
+		{
+			name:        "UTF-16LE without BOM",
+			data:        encodeUTF16(text, false, false),
+			wantEnc:     EncodingUTF16LE,
+			wantComma:   '\t',
+			wantHeader:  true,
+			wantHeaders: []string{"name", "city", "age"},
+			wantFirst:   []string{"Alice", "Zürich", "30"},
+		},
+		{
+			name:        "UTF-8 with BOM, semicolons",
+			data:        []byte("\ufeffid;label\n1;x\n2;y\n"),
+			wantEnc:     EncodingUTF8,
+			wantBOM:     true,
+			wantComma:   ';',
+			wantHeader:  true,
+			wantHeaders: []string{"id", "label"},
+			wantFirst:   []string{"1", "x"},
+		},
+		{
+			name:      "Latin-1 without header",
+			data:      []byte("1,caf\xe9\n2,na\xefve\n"),
+			wantEnc:   EncodingLatin1,
+			wantComma: ',',
+			wantFirst: []string{"1", "café"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.csv")
+			if err := os.WriteFile(path, tt.data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := ReadFileAuto(path)
+			if err != nil {
+				t.Fatalf("ReadFileAuto() error = %v", err)
+			}
+
+			got := doc.Detected()
+			if got == nil {
+				t.Fatal("Detected() = nil")
+			}
+			if got.Encoding != tt.wantEnc || got.BOM != tt.wantBOM || got.Options.Comma != tt.wantComma || got.HasHeader != tt.wantHeader {
+				t.Errorf("Detected() = %+v, want encoding %s, BOM %v, comma %q, header %v",
+					*got, tt.wantEnc, tt.wantBOM, tt.wantComma, tt.wantHeader)
+			}
+			if tt.wantHeader && !reflect.DeepEqual(doc.Headers(), tt.wantHeaders) {
+				t.Errorf("Headers() = %q, want %q", doc.Headers(), tt.wantHeaders)
+			}
+			first, ok := doc.GetRecord(0)
+			if !ok || !reflect.DeepEqual(first.Fields(), tt.wantFirst) {
+				t.Errorf("first record = %q, want %q", first.Fields(), tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestReadFileAuto_Errors(t *testing.T) {
+	if _, err := ReadFileAuto(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("ReadFileAuto() on a missing file expected error")
+	}
+
+	// UTF-16 byte order mark followed by an odd number of bytes decodes the
+	// truncated code unit as ReaderOptions.Encoding does
+	doc, err := parseAuto([]byte{0xFF, 0xFE, 'a', 0, 'b'})
+	if err != nil {
+		t.Fatalf("parseAuto() on truncated UTF-16 error = %v", err)
+	}
+	if got, want := doc.records, [][]string{{"a\ufffd"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAuto() on truncated UTF-16 = %q, want %q", got, want)
+	}
+
+	if doc := NewDocument(); doc.Detected() != nil {
+		t.Error("Detected() on a new document should be nil")
+	}
+}
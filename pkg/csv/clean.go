@@ -0,0 +1,207 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/shapestone/shape-csv/internal/bom"
+)
+
+// CleanOptions selects the repairs performed by Clean.
+// Every toggle is off by default; enable the fixes you want.
+type CleanOptions struct {
+	// Comma is the field delimiter, used to find field boundaries.
+	// Default: 0 (',')
+	Comma rune
+
+	// StripBOM removes a leading UTF-8 byte order mark.
+	StripBOM bool
+
+	// NormalizeLineEndings rewrites CRLF and lone CR record terminators as LF.
+	// Line breaks inside quoted fields are data and are left unchanged.
+	NormalizeLineEndings bool
+
+	// TrimTrailingWhitespace removes spaces and tabs at the end of each record.
+	TrimTrailingWhitespace bool
+
+	// RemoveBlankLines drops lines that are empty or contain only spaces and tabs.
+	RemoveBlankLines bool
+
+	// RepairQuotes re-encodes fields with malformed quoting: a quote inside an
+	// unquoted field, a stray quote inside a quoted field, or a quoted field
+	// left open at the end of the input.
+	RepairQuotes bool
+}
+
+// CleanReport describes the changes made by Clean.
+type CleanReport struct {
+	BOMStripped               bool // a leading byte order mark was removed
+	LineEndingsNormalized     int  // record terminators rewritten as LF
+	TrailingWhitespaceTrimmed int  // records that had trailing whitespace removed
+	BlankLinesRemoved         int  // blank lines dropped
+	QuotesRepaired            int  // fields re-encoded to fix their quoting
+}
+
+// Changed reports whether Clean modified the input.
+func (r *CleanReport) Changed() bool {
+	return r.BOMStripped || r.LineEndingsNormalized > 0 || r.TrailingWhitespaceTrimmed > 0 ||
+		r.BlankLinesRemoved > 0 || r.QuotesRepaired > 0
+}
+
+// Clean repairs and normalizes CSV data in a single pass and reports what changed.
+// Records that need no fixing are copied through byte for byte.
+//
+// Example:
+//
+//	cleaned, report, err := csv.Clean(data, csv.CleanOptions{
+//	    StripBOM:             true,
+//	    NormalizeLineEndings: true,
+//	    RemoveBlankLines:     true,
+//	    RepairQuotes:         true,
+//	})
+func Clean(data []byte, opts CleanOptions) ([]byte, *CleanReport, error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	if !validDelim(opts.Comma) {
+		return nil, nil, errors.New("csv: Clean: invalid delimiter")
+	}
+
+	report := &CleanReport{}
+	if opts.StripBOM {
+		if stripped := bom.Strip(data); len(stripped) < len(data) {
+			data = stripped
+			report.BOMStripped = true
+		}
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+
+	for pos := 0; pos < len(data); {
+		rec := scanRawRecord(data, pos, opts.Comma)
+		pos = rec.next
+		line := data[rec.start:rec.end]
+
+		if opts.RemoveBlankLines && len(bytes.Trim(line, " \t")) == 0 {
+			report.BlankLinesRemoved++
+			continue
+		}
+
+		if opts.RepairQuotes && rec.malformed > 0 {
+			var fixed bytes.Buffer
+			for i, field := range rec.fields {
+				if i > 0 {
+					fixed.WriteRune(opts.Comma)
+				}
+				writeCSVFieldWithDelim(&fixed, field, opts.Comma)
+			}
+			line = fixed.Bytes()
+			report.QuotesRepaired += rec.malformed
+		}
+
+		if opts.TrimTrailingWhitespace && !rec.unclosed {
+			if trimmed := bytes.TrimRight(line, " \t"); len(trimmed) < len(line) {
+				line = trimmed
+				report.TrailingWhitespaceTrimmed++
+			}
+		}
+
+		out.Write(line)
+
+		terminator := data[rec.end:rec.next]
+		if opts.NormalizeLineEndings && len(terminator) > 0 && !bytes.Equal(terminator, []byte{'\n'}) {
+			terminator = []byte{'\n'}
+			report.LineEndingsNormalized++
+		}
+		out.Write(terminator)
+	}
+
+	return out.Bytes(), report, nil
+}
+
+// rawRecord describes one record found by scanRawRecord.
+type rawRecord struct {
+	start, end int      // record content, excluding the terminator
+	next       int      // offset just past the terminator
+	fields     []string // leniently decoded field values
+	malformed  int      // number of fields with malformed quoting
+	unclosed   bool     // the last field is a quoted field left open at EOF
+}
+
+// scanRawRecord leniently scans the record starting at pos. Quoting errors are
+// counted rather than reported so that the record can be repaired.
+func scanRawRecord(data []byte, pos int, comma rune) rawRecord {
+	rec := rawRecord{start: pos}
+	delim := string(comma)
+	var field []byte
+
+	for {
+		field = field[:0]
+		bad := false
+
+		if pos < len(data) && data[pos] == '"' {
+			// Quoted field
+			pos++
+			closed := false
+			for pos < len(data) {
+				c := data[pos]
+				if c != '"' {
+					field = append(field, c)
+					pos++
+					continue
+				}
+				if pos+1 < len(data) && data[pos+1] == '"' {
+					field = append(field, '"')
+					pos += 2
+					continue
+				}
+				if pos+1 == len(data) || data[pos+1] == '\n' || data[pos+1] == '\r' ||
+					bytes.HasPrefix(data[pos+1:], []byte(delim)) {
+					pos++
+					closed = true
+					break
+				}
+				// Stray quote inside a quoted field
+				field = append(field, '"')
+				bad = true
+				pos++
+			}
+			if !closed {
+				bad = true
+				rec.unclosed = true
+			}
+		} else {
+			// Unquoted field
+			for pos < len(data) && data[pos] != '\n' && data[pos] != '\r' &&
+				!bytes.HasPrefix(data[pos:], []byte(delim)) {
+				if data[pos] == '"' {
+					bad = true
+				}
+				field = append(field, data[pos])
+				pos++
+			}
+		}
+
+		rec.fields = append(rec.fields, string(field))
+		if bad {
+			rec.malformed++
+		}
+
+		if pos < len(data) && bytes.HasPrefix(data[pos:], []byte(delim)) {
+			pos += len(delim)
+			continue
+		}
+		break
+	}
+
+	rec.end = pos
+	if pos < len(data) && data[pos] == '\r' {
+		pos++
+	}
+	if pos < len(data) && data[pos] == '\n' {
+		pos++
+	}
+	rec.next = pos
+	return rec
+}
@@ -0,0 +1,117 @@
+package csv_test
+
+import (
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		opts   csv.CleanOptions
+		want   string
+		report csv.CleanReport
+	}{
+		{
+			name:   "strip BOM",
+			input:  "\xEF\xBB\xBFa,b\n1,2\n",
+			opts:   csv.CleanOptions{StripBOM: true},
+			want:   "a,b\n1,2\n",
+			report: csv.CleanReport{BOMStripped: true},
+		},
+		{
+			name:   "normalize line endings",
+			input:  "a,b\r\n1,\"x\r\ny\"\r2,3",
+			opts:   csv.CleanOptions{NormalizeLineEndings: true},
+			want:   "a,b\n1,\"x\r\ny\"\n2,3",
+			report: csv.CleanReport{LineEndingsNormalized: 2},
+		},
+		{
+			name:   "trim trailing whitespace",
+			input:  "a,b  \n1,2\t\n\"3 \",4\n",
+			opts:   csv.CleanOptions{TrimTrailingWhitespace: true},
+			want:   "a,b\n1,2\n\"3 \",4\n",
+			report: csv.CleanReport{TrailingWhitespaceTrimmed: 2},
+		},
+		{
+			name:   "remove blank lines",
+			input:  "a,b\n\n  \n1,2\n\n",
+			opts:   csv.CleanOptions{RemoveBlankLines: true},
+			want:   "a,b\n1,2\n",
+			report: csv.CleanReport{BlankLinesRemoved: 3},
+		},
+		{
+			name:   "repair quotes",
+			input:  "a,b\n5\" pipe,ok\n\"open,end",
+			opts:   csv.CleanOptions{RepairQuotes: true},
+			want:   "a,b\n\"5\"\" pipe\",ok\n\"open,end\"",
+			report: csv.CleanReport{QuotesRepaired: 2},
+		},
+		{
+			name:  "no toggles leaves input unchanged",
+			input: "\xEF\xBB\xBFa,b \r\n\r\nx\"y,2",
+			want:  "\xEF\xBB\xBFa,b \r\n\r\nx\"y,2",
+		},
+		{
+			name:   "semicolon delimiter",
+			input:  "a;b\"c\n",
+			opts:   csv.CleanOptions{Comma: ';', RepairQuotes: true},
+			want:   "a;\"b\"\"c\"\n",
+			report: csv.CleanReport{QuotesRepaired: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, report, err := csv.Clean([]byte(tt.input), tt.opts)
+			if err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Clean() = %q, want %q", got, tt.want)
+			}
+			if *report != tt.report {
+				t.Errorf("Clean() report = %+v, want %+v", *report, tt.report)
+			}
+		})
+	}
+}
+
+func TestClean_Combined(t *testing.T) {
+	input := "\xEF\xBB\xBFname,note \r\n\r\nAlice,say \"hi\"\r\nBob,ok\t\r\n"
+	got, report, err := csv.Clean([]byte(input), csv.CleanOptions{
+		StripBOM:               true,
+		NormalizeLineEndings:   true,
+		TrimTrailingWhitespace: true,
+		RemoveBlankLines:       true,
+		RepairQuotes:           true,
+	})
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+
+	want := "name,note\nAlice,\"say \"\"hi\"\"\"\nBob,ok\n"
+	if string(got) != want {
+		t.Errorf("Clean() = %q, want %q", got, want)
+	}
+
+	wantReport := csv.CleanReport{
+		BOMStripped:               true,
+		LineEndingsNormalized:     3,
+		TrailingWhitespaceTrimmed: 2,
+		BlankLinesRemoved:         1,
+		QuotesRepaired:            1,
+	}
+	if *report != wantReport {
+		t.Errorf("Clean() report = %+v, want %+v", *report, wantReport)
+	}
+	if !report.Changed() {
+		t.Error("Changed() = false, want true")
+	}
+
+	if _, err := csv.Parse(string(got)); err != nil {
+		t.Errorf("cleaned output does not parse: %v", err)
+	}
+}
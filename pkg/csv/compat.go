@@ -0,0 +1,98 @@
+package csv
+
+import (
+	stdcsv "encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// CompareWithStdlib parses data with both shape-csv and encoding/csv, using
+// the equivalent encoding/csv.Reader settings for Comma, Comment,
+// FieldsPerRecord, LazyQuotes and TrimLeadingSpace, and reports whether the
+// two produce the same records. It is intended for compatibility audits and
+// tests rather than production parsing.
+//
+// If both parsers reject the input, match is true and err is nil. If only one
+// rejects it, match is false and err is that parser's error. err is also
+// returned, with match false, when opts is invalid.
+//
+// The parsers intentionally differ in these cases:
+//   - A leading UTF-8 byte order mark is skipped by shape-csv but kept as part
+//     of the first field by encoding/csv.
+//   - A CRLF inside a quoted field is preserved by shape-csv; encoding/csv
+//     rewrites it as LF.
+//   - With LazyQuotes, encoding/csv keeps a quoted field open across a stray
+//     quote, while shape-csv ends the field at the first quote that is not
+//     doubled.
+//   - Options that encoding/csv does not have, such as EscapeMode, Quote,
+//     TerminatorLine, TrimTrailingSpace and AutoDetectDelimiter, only affect
+//     shape-csv.
+//
+// Known bugs in the shape-csv parser also show up as mismatches:
+//   - A lone CR outside a quoted field stops parsing, and the rest of the
+//     input is dropped without an error.
+//   - Text after a closing quote, as in "b"x, starts a new record instead of
+//     being an error.
+//   - A quote after leading space, as in a, "b", ends the record and opens a
+//     new one.
+//
+// Example:
+//
+//	match, got, want, err := csv.CompareWithStdlib(data, csv.DefaultReaderOptions())
+//	if !match {
+//	    fmt.Printf("shape-csv: %q\nencoding/csv: %q\n", got, want)
+//	}
+func CompareWithStdlib(data []byte, opts ReaderOptions) (match bool, shapeCSV, stdlib [][]string, err error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	if err := opts.Validate(); err != nil {
+		return false, nil, nil, err
+	}
+
+	node, shapeErr := ParseWithOptions(string(data), opts)
+	if shapeErr == nil {
+		shapeCSV = NodeToRecords(node)
+	}
+
+	r := stdcsv.NewReader(strings.NewReader(string(data)))
+	r.Comma = opts.Comma
+	r.Comment = opts.Comment
+	r.FieldsPerRecord = opts.FieldsPerRecord
+	r.LazyQuotes = opts.LazyQuotes
+	r.TrimLeadingSpace = opts.TrimLeadingSpace
+	stdlib, stdErr := r.ReadAll()
+	if stdErr != nil {
+		stdlib = nil
+	}
+
+	switch {
+	case shapeErr != nil && stdErr != nil:
+		return true, nil, nil, nil
+	case shapeErr != nil:
+		return false, nil, stdlib, fmt.Errorf("csv: shape-csv: %w", shapeErr)
+	case stdErr != nil:
+		return false, shapeCSV, nil, fmt.Errorf("csv: encoding/csv: %w", stdErr)
+	}
+
+	return recordsEqual(shapeCSV, stdlib), shapeCSV, stdlib, nil
+}
+
+// recordsEqual reports whether a and b hold the same records. A nil and an
+// empty slice are equal.
+func recordsEqual(a, b [][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package csv_test
+
+import (
+	stdcsv "encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+// TestCompareWithStdlib_Agree is a corpus of inputs on which shape-csv and
+// encoding/csv must produce the same records or both reject the input.
+func TestCompareWithStdlib_Agree(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		modify func(*csv.ReaderOptions)
+	}{
+		{name: "simple", input: "a,b\nc,d\n"},
+		{name: "no trailing newline", input: "a,b\nc,d"},
+		{name: "empty input", input: ""},
+		{name: "CRLF records", input: "a,b\r\nc,d\r\n"},
+		{name: "blank lines", input: "a,b\n\n\nc,d\n"},
+		{name: "quoted delimiter", input: "\"a,b\",c\n"},
+		{name: "escaped quote", input: "\"say \"\"hi\"\"\",x\n"},
+		{name: "empty quoted field", input: "a,\"\"\n\"\"\n"},
+		{name: "multi-line quoted field", input: "\"line1\nline2\",x\n"},
+		{name: "empty fields", input: "a,,\n,\n"},
+		{name: "leading space kept", input: "a, b\n"},
+		{name: "unclosed quote", input: "\"a\nb\n"},
+		{name: "bare quote", input: "a,b\"c\n"},
+		{name: "ragged records", input: "a,b\nc\n"},
+		{
+			name:   "lazy quote in unquoted field",
+			input:  "a,b\"c\nd,e\n",
+			modify: func(o *csv.ReaderOptions) { o.LazyQuotes = true },
+		},
+		{
+			name:   "trim leading space",
+			input:  "a,  b,\t c\n",
+			modify: func(o *csv.ReaderOptions) { o.TrimLeadingSpace = true },
+		},
+		{
+			name:   "comment lines",
+			input:  "# header comment\na,b\n# another\nc,d\n",
+			modify: func(o *csv.ReaderOptions) { o.Comment = '#' },
+		},
+		{
+			name:   "semicolon delimiter",
+			input:  "a;\"b;c\"\nd;e\n",
+			modify: func(o *csv.ReaderOptions) { o.Comma = ';' },
+		},
+		{
+			name:   "tab delimiter",
+			input:  "a\tb\nc\td\n",
+			modify: func(o *csv.ReaderOptions) { o.Comma = '\t' },
+		},
+		{
+			name:   "field count mismatch",
+			input:  "a,b\nc\n",
+			modify: func(o *csv.ReaderOptions) { o.FieldsPerRecord = 0 },
+		},
+		{
+			name:   "fixed field count",
+			input:  "a,b\nc,d\n",
+			modify: func(o *csv.ReaderOptions) { o.FieldsPerRecord = 2 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			if tt.modify != nil {
+				tt.modify(&opts)
+			}
+			match, got, want, err := csv.CompareWithStdlib([]byte(tt.input), opts)
+			if err != nil {
+				t.Fatalf("CompareWithStdlib() error = %v", err)
+			}
+			if !match {
+				t.Errorf("CompareWithStdlib() mismatch:\nshape-csv:    %q\nencoding/csv: %q", got, want)
+			}
+		})
+	}
+}
+
+// TestCompareWithStdlib_Differences covers the documented differences.
+func TestCompareWithStdlib_Differences(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "byte order mark", input: "\ufeffa,b\n"},
+		{name: "CRLF in quoted field", input: "\"a\r\nb\",c\r\n"},
+		{name: "lone CR", input: "a\rb\n"},
+		{name: "text after closing quote", input: "a,\"b\"x\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, _, _, err := csv.CompareWithStdlib([]byte(tt.input), csv.DefaultReaderOptions())
+			if match {
+				t.Error("CompareWithStdlib() match = true, want a documented difference")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompareWithStdlib() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompareWithStdlib_InvalidOptions(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.Comma = '\n'
+	if _, _, _, err := csv.CompareWithStdlib([]byte("a,b\n"), opts); err == nil {
+		t.Error("CompareWithStdlib() expected error for invalid delimiter")
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		modify func(*csv.ReaderOptions)
+	}{
+		{name: "multi-line quoted field", input: "id,note\n1,\"line1\nline2, with comma\"\n2,\"say \"\"hi\"\"\"\n"},
+		{name: "empty input", input: ""},
+		{
+			name:   "comments and semicolons",
+			input:  "# exported\na;b\n# mid\n\"c;d\";e\n",
+			modify: func(o *csv.ReaderOptions) { o.Comment = '#'; o.Comma = ';' },
+		},
+		{
+			name:   "lazy quotes",
+			input:  "a,b\"c\n",
+			modify: func(o *csv.ReaderOptions) { o.LazyQuotes = true },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			if tt.modify != nil {
+				tt.modify(&opts)
+			}
+			got, err := csv.ReadAll(strings.NewReader(tt.input), opts)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+
+			r := stdcsv.NewReader(strings.NewReader(tt.input))
+			r.Comma = opts.Comma
+			r.Comment = opts.Comment
+			r.LazyQuotes = opts.LazyQuotes
+			r.FieldsPerRecord = opts.FieldsPerRecord
+			want, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("encoding/csv ReadAll() error = %v", err)
+			}
+			if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+				t.Errorf("ReadAll() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	t.Run("field count error", func(t *testing.T) {
+		input := "a,b\nc,d\ne\n"
+		opts := csv.DefaultReaderOptions()
+		opts.FieldsPerRecord = 0
+
+		_, err := csv.ReadAll(strings.NewReader(input), opts)
+		if !errors.Is(err, csv.ErrFieldCount) {
+			t.Errorf("ReadAll() error = %v, want ErrFieldCount", err)
+		}
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+			t.Errorf("ReadAll() error = %#v, want *ParseError on line 3", err)
+		}
+
+		r := stdcsv.NewReader(strings.NewReader(input))
+		r.FieldsPerRecord = 0
+		if _, err := r.ReadAll(); !errors.Is(err, stdcsv.ErrFieldCount) {
+			t.Errorf("encoding/csv ReadAll() error = %v, want ErrFieldCount", err)
+		}
+	})
+
+	t.Run("zero options", func(t *testing.T) {
+		got, err := csv.ReadAll(strings.NewReader("a,b\n"), csv.ReaderOptions{})
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if want := [][]string{{"a", "b"}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadAll() = %q, want %q", got, want)
+		}
+	})
+}
@@ -240,6 +240,10 @@ type TypeInferenceOptions struct {
 	Converters map[string]string
 	// Registry is the converter registry to use.
 	Registry *ConverterRegistry
+	// ForceStringColumns names columns that InferSchema always types as
+	// ColumnTypeString, such as zip codes or phone numbers that look numeric
+	// but must keep leading zeros and formatting.
+	ForceStringColumns []string
 }
 
 // DefaultTypeInferenceOptions returns default type inference options.
@@ -251,3 +255,71 @@ func DefaultTypeInferenceOptions() TypeInferenceOptions {
 		Registry:   NewConverterRegistry(),
 	}
 }
+
+// InferSchema builds a Schema from records, treating records[0] as the header
+// row. Each column is typed from its non-null values (see NullValues): int if
+// every value is an integer, float if every value is numeric, bool or date if
+// every value is one, and string otherwise or when the column has no values.
+// Columns listed in ForceStringColumns are always typed as string.
+//
+// Example:
+//
+//	opts := csv.DefaultTypeInferenceOptions()
+//	opts.ForceStringColumns = []string{"zip"}
+//	schema, err := csv.InferSchema(records, opts)
+func InferSchema(records [][]string, opts TypeInferenceOptions) (*Schema, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("csv: InferSchema requires a header row")
+	}
+	headers := records[0]
+
+	forced := make(map[string]bool, len(opts.ForceStringColumns))
+	for _, name := range opts.ForceStringColumns {
+		found := false
+		for _, h := range headers {
+			if h == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("csv: ForceStringColumns: unknown column %q", name)
+		}
+		forced[name] = true
+	}
+
+	schema := NewSchema()
+	for col, name := range headers {
+		colType := ColumnTypeString
+		if !forced[name] {
+			colType = inferColumnType(records[1:], col, opts.NullValues)
+		}
+		schema.AddSimpleColumn(name, colType)
+	}
+	return schema, nil
+}
+
+// inferColumnType merges the InferType results for column col of rows.
+func inferColumnType(rows [][]string, col int, nullValues []string) ColumnType {
+	var result ColumnType
+	for _, row := range rows {
+		if col >= len(row) || IsNullValue(row[col], nullValues) || row[col] == "" {
+			continue
+		}
+		typeName, _ := InferType(row[col])
+		colType := ColumnType(typeName)
+		switch {
+		case result == "" || result == colType:
+			result = colType
+		case (result == ColumnTypeInt && colType == ColumnTypeFloat) ||
+			(result == ColumnTypeFloat && colType == ColumnTypeInt):
+			result = ColumnTypeFloat
+		default:
+			return ColumnTypeString
+		}
+	}
+	if result == "" {
+		return ColumnTypeString
+	}
+	return result
+}
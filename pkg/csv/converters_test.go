@@ -292,3 +292,61 @@ func TestDefaultTypeInferenceOptions(t *testing.T) {
 		t.Error("Registry should not be nil")
 	}
 }
+
+func TestInferSchema(t *testing.T) {
+	records := [][]string{
+		{"zip", "price", "qty", "active", "note"},
+		{"02134", "1.5", "3", "true", "a"},
+		{"10001", "2", "NA", "false", ""},
+		{"94103", "", "7", "TRUE", "12"},
+	}
+
+	tests := []struct {
+		name   string
+		forced []string
+		want   []csv.ColumnType
+	}{
+		{
+			name: "inferred",
+			want: []csv.ColumnType{csv.ColumnTypeInt, csv.ColumnTypeFloat, csv.ColumnTypeInt, csv.ColumnTypeBool, csv.ColumnTypeString},
+		},
+		{
+			name:   "zip forced to string",
+			forced: []string{"zip"},
+			want:   []csv.ColumnType{csv.ColumnTypeString, csv.ColumnTypeFloat, csv.ColumnTypeInt, csv.ColumnTypeBool, csv.ColumnTypeString},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultTypeInferenceOptions()
+			opts.ForceStringColumns = tt.forced
+
+			schema, err := csv.InferSchema(records, opts)
+			if err != nil {
+				t.Fatalf("InferSchema() error = %v", err)
+			}
+			if len(schema.Columns) != len(tt.want) {
+				t.Fatalf("InferSchema() got %d columns, want %d", len(schema.Columns), len(tt.want))
+			}
+			for i, want := range tt.want {
+				col := schema.Columns[i]
+				if col.Name != records[0][i] || col.Type != want {
+					t.Errorf("column %d = %s %s, want %s %s", i, col.Name, col.Type, records[0][i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestInferSchemaErrors(t *testing.T) {
+	opts := csv.DefaultTypeInferenceOptions()
+	if _, err := csv.InferSchema(nil, opts); err == nil {
+		t.Error("InferSchema() expected error for empty records")
+	}
+
+	opts.ForceStringColumns = []string{"phone"}
+	if _, err := csv.InferSchema([][]string{{"zip"}, {"02134"}}, opts); err == nil {
+		t.Error("InferSchema() expected error for unknown forced column")
+	}
+}
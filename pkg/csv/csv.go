@@ -57,6 +57,7 @@ import (
 
 	"github.com/shapestone/shape-core/pkg/ast"
 	"github.com/shapestone/shape-core/pkg/tokenizer"
+	"github.com/shapestone/shape-csv/internal/bom"
 	"github.com/shapestone/shape-csv/internal/fastparser"
 	"github.com/shapestone/shape-csv/internal/parser"
 )
@@ -81,7 +82,11 @@ import (
 //	// records[1] is the first data row
 func Parse(input string) (ast.SchemaNode, error) {
 	p := parser.NewParser(input)
-	return p.Parse()
+	node, err := p.Parse()
+	if err != nil {
+		return nil, toParseError(err)
+	}
+	return node, nil
 }
 
 // ParseReader parses CSV format into an AST from an io.Reader.
@@ -120,9 +125,13 @@ func Parse(input string) (ast.SchemaNode, error) {
 //	reader := strings.NewReader("name,age\nAlice,30")
 //	node, err := csv.ParseReader(reader)
 func ParseReader(reader io.Reader) (ast.SchemaNode, error) {
-	stream := tokenizer.NewStreamFromReader(reader)
+	stream := tokenizer.NewStreamFromReader(bom.NewReader(reader))
 	p := parser.NewParserFromStream(stream)
-	return p.Parse()
+	node, err := p.Parse()
+	if err != nil {
+		return nil, toParseError(err)
+	}
+	return node, nil
 }
 
 // Format returns the format identifier for this parser.
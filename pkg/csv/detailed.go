@@ -0,0 +1,108 @@
+package csv
+
+import (
+	"fmt"
+
+	"github.com/shapestone/shape-core/pkg/ast"
+	"github.com/shapestone/shape-csv/internal/parser"
+)
+
+// LineEnding identifies the record terminators used by parsed input.
+type LineEnding int
+
+const (
+	// LineEndingNone means the input had no record terminators, such as a
+	// single record without a trailing newline.
+	LineEndingNone LineEnding = iota
+	// LineEndingLF means every record ended with "\n".
+	LineEndingLF
+	// LineEndingCRLF means every record ended with "\r\n".
+	LineEndingCRLF
+	// LineEndingCR means every record ended with a lone "\r", as in classic
+	// Mac OS files.
+	LineEndingCR
+	// LineEndingMixed means more than one style of terminator was used.
+	LineEndingMixed
+)
+
+// String returns the string representation of LineEnding.
+func (e LineEnding) String() string {
+	switch e {
+	case LineEndingNone:
+		return "none"
+	case LineEndingLF:
+		return "LF"
+	case LineEndingCRLF:
+		return "CRLF"
+	case LineEndingCR:
+		return "CR"
+	case LineEndingMixed:
+		return "mixed"
+	default:
+		return fmt.Sprintf("LineEnding(%d)", e)
+	}
+}
+
+// ParseResult is the result of ParseDetailed: the parsed AST along with
+// metadata about the source text.
+type ParseResult struct {
+	// Node is the parsed AST, as returned by ParseWithOptions.
+	Node ast.SchemaNode
+
+	// LineEnding is the style of record terminator used by the input, taken
+	// from the records the parser returned. Line breaks inside quoted fields
+	// are field data and are not counted, nor are those ending blank lines,
+	// comment lines or skipped rows.
+	LineEnding LineEnding
+}
+
+// ParseDetailed parses input like ParseWithOptions and also reports metadata
+// about the source, such as its line-ending style, so that an editor can
+// rewrite the file in the style it was read. It always uses the default
+// engine. Unlike ParseWithOptions, which stops at a lone "\r", ParseDetailed
+// reads a lone "\r" as a line break, so CR-only input is parsed in full.
+//
+// Example:
+//
+//	result, err := csv.ParseDetailed(input, csv.DefaultReaderOptions())
+//	if err != nil {
+//	    return err
+//	}
+//	wopts := csv.DefaultWriterOptions()
+//	wopts.UseCRLF = result.LineEnding == csv.LineEndingCRLF
+func ParseDetailed(input string, opts ReaderOptions) (*ParseResult, error) {
+	input, opts = prepareInput(input, opts)
+	popts := opts.parserOptions()
+	popts.LoneCR = true
+	p := parser.NewParserWithOptions(input, popts)
+	node, err := p.Parse()
+	if err != nil {
+		return nil, toParseError(err)
+	}
+	return &ParseResult{
+		Node:       node,
+		LineEnding: lineEnding(p.Terminators()),
+	}, nil
+}
+
+// lineEnding classifies the record terminators counted by the parser.
+func lineEnding(lf, crlf, cr int) LineEnding {
+	styles := 0
+	for _, n := range []int{lf, crlf, cr} {
+		if n > 0 {
+			styles++
+		}
+	}
+	switch {
+	case styles > 1:
+		return LineEndingMixed
+	case lf > 0:
+		return LineEndingLF
+	case crlf > 0:
+		return LineEndingCRLF
+	case cr > 0:
+		return LineEndingCR
+	default:
+		return LineEndingNone
+	}
+}
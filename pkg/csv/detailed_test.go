@@ -0,0 +1,56 @@
+package csv_test
+
+import (
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestParseDetailedLineEnding(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		comment rune
+		skip    int
+		want    csv.LineEnding
+		records int
+	}{
+		{name: "LF only", input: "a,b\n1,2\n", want: csv.LineEndingLF},
+		{name: "CRLF only", input: "a,b\r\n1,2\r\n", want: csv.LineEndingCRLF},
+		{name: "mixed", input: "a,b\r\n1,2\n3,4\r\n", want: csv.LineEndingMixed},
+		{name: "no terminator", input: "a,b", want: csv.LineEndingNone},
+		{name: "quoted line break ignored", input: "a,\"x\ny\"\r\n1,2\r\n", want: csv.LineEndingCRLF},
+		{name: "blank lines ignored", input: "a,b\r\n\n1,2\r\n", want: csv.LineEndingCRLF},
+		{name: "CR only", input: "a,b\r1,2\r", want: csv.LineEndingCR, records: 2},
+		{name: "CR and LF", input: "a,b\r1,2\n", want: csv.LineEndingMixed, records: 2},
+		{name: "quoted CR ignored", input: "a,\"x\ry\"\r\n1,2\r\n", want: csv.LineEndingCRLF, records: 2},
+		{name: "quotes in comment line", input: "# it's \"odd\na,b\r\nc,d\n", comment: '#', want: csv.LineEndingMixed},
+		{name: "skipped rows ignored", input: "meta \"x\r\na,b\n1,2\n", skip: 1, want: csv.LineEndingLF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.Comment = tt.comment
+			opts.SkipRows = tt.skip
+			result, err := csv.ParseDetailed(tt.input, opts)
+			if err != nil {
+				t.Fatalf("ParseDetailed() error = %v", err)
+			}
+			if result.LineEnding != tt.want {
+				t.Errorf("LineEnding = %v, want %v", result.LineEnding, tt.want)
+			}
+			records := csv.NodeToRecords(result.Node)
+			if len(records) == 0 {
+				t.Error("ParseDetailed() returned no records")
+			}
+			if tt.records > 0 && len(records) != tt.records {
+				t.Errorf("ParseDetailed() returned %d records, want %d", len(records), tt.records)
+			}
+		})
+	}
+
+	if _, err := csv.ParseDetailed("a,\"b\n", csv.DefaultReaderOptions()); err == nil {
+		t.Error("ParseDetailed() expected error for unclosed quote")
+	}
+}
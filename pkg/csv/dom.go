@@ -37,7 +37,13 @@
 package csv
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/shapestone/shape-core/pkg/ast"
@@ -53,6 +59,8 @@ import (
 type Document struct {
 	headers []string
 	records [][]string
+
+	detected *DetectedOptions // set by ReadFileAuto
 }
 
 // Record represents a single row in a CSV file.
@@ -103,6 +111,55 @@ func ParseDocument(input string) (*Document, error) {
 	return doc, nil
 }
 
+// ParseDocumentWithOptions parses CSV string into a Document using custom options.
+// With HasHeader, the first row becomes the document headers; otherwise all
+// rows are treated as data records, and with AutoNameColumns the document
+// headers are set to col1, col2, ... up to the widest record.
+//
+// Example:
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.AutoNameColumns = true
+//	doc, err := csv.ParseDocumentWithOptions("Alice,30\nBob,25", opts)
+//	record, _ := doc.GetRecord(0)
+//	age, _ := record.GetByName("col2") // "30"
+func ParseDocumentWithOptions(input string, opts ReaderOptions) (*Document, error) {
+	node, err := ParseWithOptions(input, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := NewDocument()
+	records := NodeToRecords(node)
+	if opts.HasHeader && len(records) > 0 {
+		doc.SetHeaders(records[0])
+		records = records[1:]
+	}
+
+	width := 0
+	for _, record := range records {
+		doc.AddRecord(record)
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+
+	if opts.AutoNameColumns && !opts.HasHeader {
+		doc.SetHeaders(autoColumnNames(width))
+	}
+
+	return doc, nil
+}
+
+// autoColumnNames returns the synthetic column names col1..colN.
+func autoColumnNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("col%d", i+1)
+	}
+	return names
+}
+
 // SetHeaders sets the column headers for this CSV document.
 // Headers are used by Record.GetByName() to access fields by name.
 // Returns the Document for method chaining.
@@ -137,6 +194,40 @@ func (d *Document) Records() []Record {
 	return records
 }
 
+// Each calls fn for every data record in order, with its 0-based index,
+// stopping early if fn returns false. Unlike Records, it does not allocate a
+// slice of all records.
+//
+// Example:
+//
+//	doc.Each(func(i int, r csv.Record) bool {
+//	    name, _ := r.GetByName("name")
+//	    fmt.Println(i, name)
+//	    return i < 9 // stop after ten records
+//	})
+func (d *Document) Each(fn func(i int, r Record) bool) {
+	for i, fields := range d.records {
+		if !fn(i, Record{fields: fields, headers: d.headers}) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the data records and their 0-based indices,
+// for use with range. Like Each, it does not allocate a slice of all records.
+//
+// Example:
+//
+//	for i, r := range doc.All() {
+//	    if i == 10 {
+//	        break
+//	    }
+//	    fmt.Println(r.Fields())
+//	}
+func (d *Document) All() iter.Seq2[int, Record] {
+	return d.Each
+}
+
 // RecordCount returns the number of data records in the document.
 // This does not include the header row.
 func (d *Document) RecordCount() int {
@@ -157,6 +248,494 @@ func (d *Document) GetRecord(index int) (Record, bool) {
 	}, true
 }
 
+// SetCell sets the field in the named column of the data record at row
+// (0-based, not counting the header). A record too short to have the column
+// is padded with empty fields. Returns an error if row is out of range, no
+// headers are set or the column is not found.
+//
+// Example:
+//
+//	for i, r := range doc.Records() {
+//	    email, _ := r.GetByName("email")
+//	    doc.SetCell(i, "email", strings.ToLower(email))
+//	}
+func (d *Document) SetCell(row int, column string, value string) error {
+	if len(d.headers) == 0 {
+		return fmt.Errorf("csv: SetCell requires headers")
+	}
+	idx, ok := d.columnIndex(column)
+	if !ok {
+		return fmt.Errorf("csv: column %q not found", column)
+	}
+	return d.SetCellByIndex(row, idx, value)
+}
+
+// SetCellByIndex sets field col (0-based) of the data record at row (0-based,
+// not counting the header). A record too short to have the field is padded
+// with empty fields, up to the width of the headers. Returns an error if row
+// or col is out of range.
+func (d *Document) SetCellByIndex(row, col int, value string) error {
+	if row < 0 || row >= len(d.records) {
+		return fmt.Errorf("csv: row %d out of range [0, %d)", row, len(d.records))
+	}
+	record := d.records[row]
+	width := max(len(record), len(d.headers))
+	if col < 0 || col >= width {
+		return fmt.Errorf("csv: column %d out of range [0, %d)", col, width)
+	}
+
+	// Copy rather than write through, since records may be shared with
+	// Documents returned by Filter
+	fields := make([]string, max(len(record), col+1))
+	copy(fields, record)
+	fields[col] = value
+	d.records[row] = fields
+	return nil
+}
+
+// Filter returns a new Document with the same headers and only the records
+// for which pred returns true. The Record passed to pred supports GetByName.
+//
+// Example:
+//
+//	adults := doc.Filter(func(r csv.Record) bool {
+//	    age, _ := r.GetByName("age")
+//	    n, err := strconv.Atoi(age)
+//	    return err == nil && n >= 18
+//	})
+func (d *Document) Filter(pred func(Record) bool) *Document {
+	result := NewDocument().SetHeaders(d.headers)
+	for _, fields := range d.records {
+		if pred(Record{fields: fields, headers: d.headers}) {
+			result.AddRecord(fields)
+		}
+	}
+	return result
+}
+
+// Dedup returns a new Document with the same headers and the records of d,
+// in order, without any record whose fields all equal those of an earlier
+// record. Records of different lengths are never duplicates.
+//
+// Example:
+//
+//	unique := doc.Dedup()
+func (d *Document) Dedup() *Document {
+	return d.dedup(nil)
+}
+
+// DedupByColumns is like Dedup but compares only the named columns, keeping
+// the first record for each combination of their values; the other columns
+// are ignored. Names are resolved against Headers(), using the first
+// occurrence of a repeated name. A record too short to have a column counts
+// as an empty value there. With no names, whole records are compared as by
+// Dedup. Returns an error if names are given but no headers are set or a
+// name is not found.
+//
+// Example:
+//
+//	// email,name              email,name
+//	// a@x,Alice               a@x,Alice
+//	// b@x,Bob          =>     b@x,Bob
+//	// a@x,Alice Smith
+//	latest, err := doc.DedupByColumns("email")
+func (d *Document) DedupByColumns(names ...string) (*Document, error) {
+	if len(names) > 0 && len(d.headers) == 0 {
+		return nil, fmt.Errorf("csv: DedupByColumns requires headers")
+	}
+
+	indices := make([]int, len(names))
+	for i, name := range names {
+		idx, ok := d.columnIndex(name)
+		if !ok {
+			return nil, fmt.Errorf("csv: column %q not found", name)
+		}
+		indices[i] = idx
+	}
+	return d.dedup(indices), nil
+}
+
+// dedup returns the records of d without repeats of the fields at indices,
+// or of whole records if indices is empty.
+func (d *Document) dedup(indices []int) *Document {
+	seen := make(map[string]bool)
+	var key strings.Builder
+	return d.Filter(func(r Record) bool {
+		key.Reset()
+		if len(indices) == 0 {
+			for _, field := range r.fields {
+				writeDedupKey(&key, field)
+			}
+		} else {
+			for _, idx := range indices {
+				writeDedupKey(&key, cell(r.fields, idx))
+			}
+		}
+
+		k := key.String()
+		if seen[k] {
+			return false
+		}
+		seen[k] = true
+		return true
+	})
+}
+
+// writeDedupKey appends field to a deduplication key, prefixed with its length
+// so that different field tuples never produce the same key.
+func writeDedupKey(key *strings.Builder, field string) {
+	key.WriteString(strconv.Itoa(len(field)))
+	key.WriteByte(':')
+	key.WriteString(field)
+}
+
+// SelectColumns returns a new Document containing only the named columns, in
+// the order requested. Names are resolved against Headers(); if a header name
+// appears more than once, the first occurrence is used. Records too short to
+// have a selected column get an empty field. Returns an error if no headers
+// are set or a name is not found.
+//
+// Example:
+//
+//	projected, err := doc.SelectColumns("email", "name")
+func (d *Document) SelectColumns(names ...string) (*Document, error) {
+	if len(d.headers) == 0 {
+		return nil, fmt.Errorf("csv: SelectColumns requires headers")
+	}
+
+	indices := make([]int, len(names))
+	for i, name := range names {
+		idx, ok := d.columnIndex(name)
+		if !ok {
+			return nil, fmt.Errorf("csv: column %q not found", name)
+		}
+		indices[i] = idx
+	}
+
+	headers := make([]string, len(names))
+	copy(headers, names)
+	result := NewDocument().SetHeaders(headers)
+	for _, record := range d.records {
+		fields := make([]string, len(indices))
+		for i, idx := range indices {
+			if idx < len(record) {
+				fields[i] = record[idx]
+			}
+		}
+		result.AddRecord(fields)
+	}
+
+	return result, nil
+}
+
+// ExplodeColumn returns a new Document in which each record is repeated once
+// per value in the named column, splitting that column's cell on sep. The
+// other columns are duplicated into every output record. A cell without sep,
+// including an empty one, yields a single record, as does a record too short
+// to have the column. Returns an error if sep is empty, no headers are set or
+// the column is not found.
+//
+// Example:
+//
+//	// name,tags          name,tags
+//	// Alice,a|b|c   =>   Alice,a
+//	//                    Alice,b
+//	//                    Alice,c
+//	exploded, err := doc.ExplodeColumn("tags", "|")
+func (d *Document) ExplodeColumn(name, sep string) (*Document, error) {
+	if sep == "" {
+		return nil, fmt.Errorf("csv: ExplodeColumn requires a non-empty separator")
+	}
+	if len(d.headers) == 0 {
+		return nil, fmt.Errorf("csv: ExplodeColumn requires headers")
+	}
+	idx, ok := d.columnIndex(name)
+	if !ok {
+		return nil, fmt.Errorf("csv: column %q not found", name)
+	}
+
+	result := NewDocument().SetHeaders(d.headers)
+	for _, record := range d.records {
+		if idx >= len(record) {
+			result.AddRecord(append([]string(nil), record...))
+			continue
+		}
+		for _, value := range strings.Split(record[idx], sep) {
+			fields := append([]string(nil), record...)
+			fields[idx] = value
+			result.AddRecord(fields)
+		}
+	}
+
+	return result, nil
+}
+
+// GroupBy buckets the records of d by the value of keyColumn and returns a new
+// Document with one record per group, in the order each key is first seen.
+// Each output record is the key followed by the fields agg returns for the
+// group's records, which support GetByName. The headers are keyColumn
+// followed by "result" when agg returns one field, or "result1", "result2",
+// and so on for the widest result; use RenameColumn to give them meaningful
+// names. A record too short to have keyColumn is grouped under "". Returns an
+// error if agg is nil, no headers are set or the column is not found.
+//
+// Example:
+//
+//	// category,amount          category,result
+//	// fruit,3                  fruit,5
+//	// veg,4            =>      veg,4
+//	// fruit,2
+//	totals, err := doc.GroupBy("category", func(group []csv.Record) []string {
+//	    sum := 0.0
+//	    for _, r := range group {
+//	        amount, _ := r.GetByName("amount")
+//	        n, _ := strconv.ParseFloat(amount, 64)
+//	        sum += n
+//	    }
+//	    return []string{strconv.FormatFloat(sum, 'f', -1, 64)}
+//	})
+func (d *Document) GroupBy(keyColumn string, agg func(group []Record) []string) (*Document, error) {
+	if agg == nil {
+		return nil, fmt.Errorf("csv: GroupBy requires an aggregate function")
+	}
+	if len(d.headers) == 0 {
+		return nil, fmt.Errorf("csv: GroupBy requires headers")
+	}
+	idx, ok := d.columnIndex(keyColumn)
+	if !ok {
+		return nil, fmt.Errorf("csv: column %q not found", keyColumn)
+	}
+
+	var keys []string
+	groups := make(map[string][]Record)
+	for _, fields := range d.records {
+		key := cell(fields, idx)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], Record{fields: fields, headers: d.headers})
+	}
+
+	result := NewDocument()
+	width := 0
+	for _, key := range keys {
+		values := agg(groups[key])
+		width = max(width, len(values))
+		result.AddRecord(append([]string{key}, values...))
+	}
+
+	headers := []string{keyColumn}
+	if width == 1 {
+		headers = append(headers, "result")
+	} else {
+		for i := 1; i <= width; i++ {
+			headers = append(headers, "result"+strconv.Itoa(i))
+		}
+	}
+	return result.SetHeaders(headers), nil
+}
+
+// Transpose returns a new Document whose records are the columns of d, so an
+// R×C document becomes C×R. When headers are set, they become the first
+// column of the result, which has no headers of its own. Ragged records are
+// padded with empty cells to the widest record or header row.
+//
+// Example:
+//
+//	// metric,jan,feb          metric,revenue,costs
+//	// revenue,10,12   =>      jan,10,7
+//	// costs,7,8               feb,12,8
+//	pivoted := doc.Transpose()
+func (d *Document) Transpose() *Document {
+	rows := d.records
+	if len(d.headers) > 0 {
+		rows = append([][]string{d.headers}, d.records...)
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	result := NewDocument()
+	for col := 0; col < width; col++ {
+		fields := make([]string, len(rows))
+		for i, row := range rows {
+			fields[i] = cell(row, col)
+		}
+		result.AddRecord(fields)
+	}
+	return result
+}
+
+// AddColumn appends a column named name to the headers, setting its cell in
+// each record to the value at the same index in values. Records shorter than
+// the headers are padded with empty fields first, so the new column lines up.
+// Returns an error if the document has records but no headers, name is
+// already a header, or len(values) differs from RecordCount.
+//
+// Example:
+//
+//	totals := make([]string, doc.RecordCount())
+//	for i, r := range doc.Records() {
+//	    price, _ := r.GetByName("price")
+//	    qty, _ := r.GetByName("qty")
+//	    totals[i] = multiply(price, qty)
+//	}
+//	err := doc.AddColumn("total", totals)
+func (d *Document) AddColumn(name string, values []string) error {
+	if len(d.headers) == 0 && len(d.records) > 0 {
+		return fmt.Errorf("csv: AddColumn requires headers")
+	}
+	if _, exists := d.columnIndex(name); exists {
+		return fmt.Errorf("csv: column %q already exists", name)
+	}
+	if len(values) != len(d.records) {
+		return fmt.Errorf("csv: AddColumn got %d values for %d records", len(values), len(d.records))
+	}
+
+	idx := len(d.headers)
+	for i, record := range d.records {
+		fields := make([]string, 0, max(len(record), idx)+1)
+		fields = append(fields, record[:min(len(record), idx)]...)
+		for len(fields) < idx {
+			fields = append(fields, "")
+		}
+		fields = append(fields, values[i])
+		if len(record) > idx {
+			fields = append(fields, record[idx:]...)
+		}
+		d.records[i] = fields
+	}
+	d.headers = append(append([]string(nil), d.headers...), name)
+	return nil
+}
+
+// RemoveColumn removes the first column named name from the headers and from
+// every record long enough to have it. Returns an error if the column is not
+// found.
+func (d *Document) RemoveColumn(name string) error {
+	idx, ok := d.columnIndex(name)
+	if !ok {
+		return fmt.Errorf("csv: column %q not found", name)
+	}
+
+	for i, record := range d.records {
+		if idx < len(record) {
+			d.records[i] = removeField(record, idx)
+		}
+	}
+	d.headers = removeField(d.headers, idx)
+	return nil
+}
+
+// RenameColumn renames the first column named oldName to newName. Records are
+// unchanged. Returns an error if oldName is not found or newName is already
+// another column's header.
+func (d *Document) RenameColumn(oldName, newName string) error {
+	idx, ok := d.columnIndex(oldName)
+	if !ok {
+		return fmt.Errorf("csv: column %q not found", oldName)
+	}
+	if other, exists := d.columnIndex(newName); exists && other != idx {
+		return fmt.Errorf("csv: column %q already exists", newName)
+	}
+
+	headers := append([]string(nil), d.headers...)
+	headers[idx] = newName
+	d.headers = headers
+	return nil
+}
+
+// removeField returns a copy of fields without the field at idx. The result
+// does not share memory with fields, which may be shared with other Documents.
+func removeField(fields []string, idx int) []string {
+	result := make([]string, 0, len(fields)-1)
+	result = append(result, fields[:idx]...)
+	return append(result, fields[idx+1:]...)
+}
+
+// SortByColumn stably reorders the records by the values in the named column,
+// using less to compare them. Records too short to have the column sort as an
+// empty value. Headers are unchanged. Returns an error if no headers are set or
+// the column is not found.
+//
+// Example:
+//
+//	err := doc.SortByColumn("name", func(a, b string) bool { return a < b })
+func (d *Document) SortByColumn(name string, less func(a, b string) bool) error {
+	idx, ok := d.columnIndex(name)
+	if !ok {
+		return fmt.Errorf("csv: column %q not found", name)
+	}
+	sort.SliceStable(d.records, func(i, j int) bool {
+		return less(cell(d.records[i], idx), cell(d.records[j], idx))
+	})
+	return nil
+}
+
+// SortByColumnNumeric stably reorders the records by the named column, parsing
+// each cell as a float64. The records are left unchanged if any cell is not a
+// number.
+func (d *Document) SortByColumnNumeric(name string, ascending bool) error {
+	idx, ok := d.columnIndex(name)
+	if !ok {
+		return fmt.Errorf("csv: column %q not found", name)
+	}
+
+	keys := make([]float64, len(d.records))
+	for i, record := range d.records {
+		value := cell(record, idx)
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("csv: cannot sort by %q: record %d value %q is not numeric", name, i, value)
+		}
+		keys[i] = f
+	}
+
+	sort.Stable(numericRecords{records: d.records, keys: keys, ascending: ascending})
+	return nil
+}
+
+// numericRecords sorts records by precomputed numeric keys.
+type numericRecords struct {
+	records   [][]string
+	keys      []float64
+	ascending bool
+}
+
+func (n numericRecords) Len() int { return len(n.records) }
+
+func (n numericRecords) Less(i, j int) bool {
+	if n.ascending {
+		return n.keys[i] < n.keys[j]
+	}
+	return n.keys[i] > n.keys[j]
+}
+
+func (n numericRecords) Swap(i, j int) {
+	n.records[i], n.records[j] = n.records[j], n.records[i]
+	n.keys[i], n.keys[j] = n.keys[j], n.keys[i]
+}
+
+// columnIndex returns the index of the first header equal to name.
+func (d *Document) columnIndex(name string) (int, bool) {
+	for i, header := range d.headers {
+		if header == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// cell returns the field at idx, or "" if the record is too short.
+func cell(record []string, idx int) string {
+	if idx < len(record) {
+		return record[idx]
+	}
+	return ""
+}
+
 // CSV renders the Document back to a CSV string.
 // This includes headers (if set) followed by all data records.
 //
@@ -187,6 +766,115 @@ func (d *Document) CSV() (string, error) {
 	return sb.String(), nil
 }
 
+// ToMarkdown renders the Document as a GitHub-flavored Markdown table. The
+// header row is Headers(), or the first record if no headers are set, and all
+// columns are left-aligned. Short records are padded with empty cells. Pipe
+// characters in cells are escaped as \| and line breaks become <br>. An empty
+// document renders as an empty string.
+//
+// Example:
+//
+//	md, _ := doc.ToMarkdown()
+//	// | name | age |
+//	// | :--- | :--- |
+//	// | Alice | 30 |
+func (d *Document) ToMarkdown() (string, error) {
+	header, rows := d.headers, d.records
+	if len(header) == 0 {
+		if len(rows) == 0 {
+			return "", nil
+		}
+		header, rows = rows[0], rows[1:]
+	}
+
+	width := len(header)
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	var sb strings.Builder
+	writeMarkdownRow(&sb, header, width)
+	sb.WriteByte('|')
+	for i := 0; i < width; i++ {
+		sb.WriteString(" :--- |")
+	}
+	sb.WriteByte('\n')
+	for _, row := range rows {
+		writeMarkdownRow(&sb, row, width)
+	}
+
+	return sb.String(), nil
+}
+
+// markdownEscaper escapes cell text that would break a Markdown table row.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// writeMarkdownRow writes fields as a Markdown table row of width cells.
+func writeMarkdownRow(sb *strings.Builder, fields []string, width int) {
+	sb.WriteByte('|')
+	for i := 0; i < width; i++ {
+		sb.WriteByte(' ')
+		sb.WriteString(markdownEscaper.Replace(cell(fields, i)))
+		sb.WriteString(" |")
+	}
+	sb.WriteByte('\n')
+}
+
+// Reader returns an io.Reader that lazily renders the document as CSV using the
+// given writer options. Records are encoded one at a time as the reader is
+// consumed, so the full output is never held in memory. This makes a Document
+// usable as the body of http.Post, the Stdin of an exec.Cmd, or any other
+// io.Reader sink.
+//
+// The document should not be modified while the reader is in use.
+//
+// Example:
+//
+//	resp, err := http.Post(url, "text/csv", doc.Reader(csv.DefaultWriterOptions()))
+func (d *Document) Reader(opts WriterOptions) io.Reader {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	r := &documentReader{doc: d, opts: opts}
+	if len(d.headers) > 0 {
+		r.next = -1
+	}
+	r.force, r.err = forceQuoteMask(d.headers, opts)
+	return r
+}
+
+// documentReader renders a Document record by record on demand.
+type documentReader struct {
+	doc   *Document
+	opts  WriterOptions
+	next  int    // index of the next record to render; -1 is the header row
+	force []bool // columns quoted by ForceQuoteColumns
+	err   error  // ForceQuoteColumns error, returned by Read
+	buf   bytes.Buffer
+}
+
+// Read implements io.Reader.
+func (r *documentReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	for r.buf.Len() == 0 {
+		if r.next >= len(r.doc.records) {
+			return 0, io.EOF
+		}
+		r.buf.Reset()
+		if r.next < 0 {
+			writeRecordWithOptions(&r.buf, r.doc.headers, r.opts, r.force)
+		} else {
+			writeRecordWithOptions(&r.buf, r.doc.records[r.next], r.opts, r.force)
+		}
+		r.next++
+	}
+	return r.buf.Read(p)
+}
+
 // writeRecord writes a single record to the string builder in CSV format.
 // Handles quoting of fields that contain commas, quotes, or newlines.
 func writeRecord(sb *strings.Builder, fields []string) error {
@@ -266,6 +954,59 @@ func (r Record) Fields() []string {
 	return fields
 }
 
+// Map returns the record as a map keyed by the document headers.
+// Returns (nil, false) if no headers are set.
+//
+// Duplicate header names are disambiguated: the first occurrence keeps its
+// name, matching GetByName, and later ones get a numeric suffix ("name_2",
+// "name_3", ...) that does not collide with another header. Headers without a
+// field map to "", and fields beyond the headers are omitted.
+//
+// Example:
+//
+//	record, _ := doc.GetRecord(0)
+//	m, ok := record.Map() // map[age:30 name:Alice]
+func (r Record) Map() (map[string]string, bool) {
+	if len(r.headers) == 0 {
+		return nil, false
+	}
+
+	keys := uniqueHeaders(r.headers)
+	m := make(map[string]string, len(keys))
+	for i, key := range keys {
+		m[key] = cell(r.fields, i)
+	}
+	return m, true
+}
+
+// uniqueHeaders returns headers with duplicate names disambiguated by a
+// numeric suffix, as described on Record.Map.
+func uniqueHeaders(headers []string) []string {
+	taken := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		taken[h] = true
+	}
+
+	keys := make([]string, len(headers))
+	seen := make(map[string]bool, len(headers))
+	for i, h := range headers {
+		if !seen[h] {
+			seen[h] = true
+			keys[i] = h
+			continue
+		}
+		for n := 2; ; n++ {
+			candidate := fmt.Sprintf("%s_%d", h, n)
+			if !taken[candidate] {
+				taken[candidate] = true
+				keys[i] = candidate
+				break
+			}
+		}
+	}
+	return keys
+}
+
 // Len returns the number of fields in the record.
 func (r Record) Len() int {
 	return len(r.fields)
@@ -338,3 +1079,175 @@ func FromAST(node ast.SchemaNode) (*Document, error) {
 
 	return doc, nil
 }
+
+// ============================================================================
+// JSON Conversion
+// ============================================================================
+
+// ToJSON renders the Document as JSON. With headers set, each record becomes
+// an object keyed by header name, in header order; duplicate headers are
+// disambiguated as described on Record.Map. Without headers, each record
+// becomes an array of strings.
+//
+// Example:
+//
+//	data, _ := doc.ToJSON()
+//	// [{"name":"Alice","age":"30"},{"name":"Bob","age":"25"}]
+func (d *Document) ToJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+
+	keys := uniqueHeaders(d.headers)
+	for i, record := range d.records {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if len(keys) == 0 {
+			if err := writeJSONArray(&buf, record); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		buf.WriteByte('{')
+		for j, key := range keys {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONString(&buf, key); err != nil {
+				return nil, err
+			}
+			buf.WriteByte(':')
+			if err := writeJSONString(&buf, cell(record, j)); err != nil {
+				return nil, err
+			}
+		}
+		buf.WriteByte('}')
+	}
+
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// writeJSONArray writes fields as a JSON array of strings.
+func writeJSONArray(buf *bytes.Buffer, fields []string) error {
+	buf.WriteByte('[')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONString(buf, field); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// writeJSONString writes s as a JSON string.
+func writeJSONString(buf *bytes.Buffer, s string) error {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
+
+// FromJSON creates a Document from a JSON array of objects, the form produced
+// by ToJSON for a document with headers. The headers are the union of the
+// object keys in the order they are first seen, and a key missing from an
+// object gives an empty cell. String values are used as is, null becomes an
+// empty cell, and any other value is kept as its JSON text, such as 30 or true.
+//
+// Example:
+//
+//	doc, err := csv.FromJSON([]byte(`[{"name":"Alice","age":30},{"name":"Bob"}]`))
+//	// headers [name age], records [Alice 30] and [Bob ""]
+func FromJSON(data []byte) (*Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	var headers []string
+	columns := make(map[string]int)
+	var rows []map[string]string
+	for dec.More() {
+		if err := expectJSONDelim(dec, '{'); err != nil {
+			return nil, err
+		}
+		row := make(map[string]string)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("csv: FromJSON: %w", err)
+			}
+			key := tok.(string) // object keys are always strings
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("csv: FromJSON: %w", err)
+			}
+			value, err := jsonCellValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("csv: FromJSON: %w", err)
+			}
+
+			if _, ok := columns[key]; !ok {
+				columns[key] = len(headers)
+				headers = append(headers, key)
+			}
+			row[key] = value
+		}
+		if err := expectJSONDelim(dec, '}'); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if err := expectJSONDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("csv: FromJSON: unexpected data after array")
+	}
+
+	doc := NewDocument()
+	if len(headers) > 0 {
+		doc.SetHeaders(headers)
+	}
+	for _, row := range rows {
+		fields := make([]string, len(headers))
+		for key, value := range row {
+			fields[columns[key]] = value
+		}
+		doc.AddRecord(fields)
+	}
+	return doc, nil
+}
+
+// expectJSONDelim reads the next token from dec and checks that it is delim.
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("csv: FromJSON: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("csv: FromJSON: expected %q, got %v", delim, tok)
+	}
+	return nil
+}
+
+// jsonCellValue converts a JSON value to a cell: strings are unquoted, null is
+// empty and anything else keeps its JSON text.
+func jsonCellValue(raw json.RawMessage) (string, error) {
+	switch {
+	case len(raw) > 0 && raw[0] == '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case string(raw) == "null":
+		return "", nil
+	default:
+		return string(raw), nil
+	}
+}
@@ -1,6 +1,10 @@
 package csv_test
 
 import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -496,3 +500,1032 @@ func TestWriteRecordWithQuoting(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentReader(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "note"}).
+		AddRecord([]string{"Alice", "says \"hi\""}).
+		AddRecord([]string{"Bob", "a,b"}).
+		AddRecord([]string{"Carol", "line1\nline2"})
+
+	want, err := doc.CSV()
+	if err != nil {
+		t.Fatalf("CSV() error = %v", err)
+	}
+
+	t.Run("read all", func(t *testing.T) {
+		got, err := io.ReadAll(doc.Reader(csv.DefaultWriterOptions()))
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("Reader() output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("partial reads", func(t *testing.T) {
+		r := doc.Reader(csv.DefaultWriterOptions())
+		var sb strings.Builder
+		p := make([]byte, 3)
+		for {
+			n, err := r.Read(p)
+			if n > 3 {
+				t.Fatalf("Read() returned %d bytes into a 3-byte buffer", n)
+			}
+			sb.Write(p[:n])
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+		}
+		if sb.String() != want {
+			t.Errorf("partial reads = %q, want %q", sb.String(), want)
+		}
+	})
+
+	t.Run("writer options", func(t *testing.T) {
+		opts := csv.DefaultWriterOptions()
+		opts.Comma = ';'
+		opts.UseCRLF = true
+		small := csv.NewDocument().AddRecord([]string{"a", "b;c"})
+		got, err := io.ReadAll(small.Reader(opts))
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(got) != "a;\"b;c\"\r\n" {
+			t.Errorf("Reader() output = %q", got)
+		}
+	})
+
+	t.Run("empty document", func(t *testing.T) {
+		got, err := io.ReadAll(csv.NewDocument().Reader(csv.DefaultWriterOptions()))
+		if err != nil || len(got) != 0 {
+			t.Errorf("Reader() on empty document = %q, %v", got, err)
+		}
+	})
+}
+
+// TestParseDocumentAutoNameColumns tests synthetic headers for headerless data
+func TestParseDocumentAutoNameColumns(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.AutoNameColumns = true
+	opts.FieldsPerRecord = -1
+
+	doc, err := csv.ParseDocumentWithOptions("Alice,30\nBob,25,bob@example.com\n", opts)
+	if err != nil {
+		t.Fatalf("ParseDocumentWithOptions() error = %v", err)
+	}
+
+	wantHeaders := []string{"col1", "col2", "col3"}
+	if got := doc.Headers(); strings.Join(got, ",") != strings.Join(wantHeaders, ",") {
+		t.Errorf("Headers() = %v, want %v", got, wantHeaders)
+	}
+	if doc.RecordCount() != 2 {
+		t.Fatalf("RecordCount() = %d, want 2", doc.RecordCount())
+	}
+
+	first, _ := doc.GetRecord(0)
+	if age, ok := first.GetByName("col2"); !ok || age != "30" {
+		t.Errorf("GetByName(col2) = %q, %v, want \"30\", true", age, ok)
+	}
+	if _, ok := first.GetByName("col3"); ok {
+		t.Error("GetByName(col3) on a short record should return false")
+	}
+
+	second, _ := doc.GetRecord(1)
+	if email, ok := second.GetByName("col3"); !ok || email != "bob@example.com" {
+		t.Errorf("GetByName(col3) = %q, %v, want \"bob@example.com\", true", email, ok)
+	}
+}
+
+// TestParseDocumentWithOptionsNoAutoNames tests that headers stay unset by default
+func TestParseDocumentWithOptionsNoAutoNames(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.Comma = ';'
+
+	doc, err := csv.ParseDocumentWithOptions("a;b\nc;d\n", opts)
+	if err != nil {
+		t.Fatalf("ParseDocumentWithOptions() error = %v", err)
+	}
+	if len(doc.Headers()) != 0 {
+		t.Errorf("Headers() = %v, want []", doc.Headers())
+	}
+	if doc.RecordCount() != 2 {
+		t.Errorf("RecordCount() = %d, want 2", doc.RecordCount())
+	}
+	rec, _ := doc.GetRecord(1)
+	if v, _ := rec.Get(1); v != "d" {
+		t.Errorf("Get(1) = %q, want \"d\"", v)
+	}
+}
+
+// TestParseDocumentWithOptionsHasHeader tests that HasHeader takes the first
+// row as the headers, overriding AutoNameColumns
+func TestParseDocumentWithOptionsHasHeader(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.HasHeader = true
+	opts.AutoNameColumns = true
+
+	doc, err := csv.ParseDocumentWithOptions("name,age\nAlice,30\n", opts)
+	if err != nil {
+		t.Fatalf("ParseDocumentWithOptions() error = %v", err)
+	}
+	if got := doc.Headers(); !reflect.DeepEqual(got, []string{"name", "age"}) {
+		t.Errorf("Headers() = %v, want [name age]", got)
+	}
+	if doc.RecordCount() != 1 {
+		t.Errorf("RecordCount() = %d, want 1", doc.RecordCount())
+	}
+	rec, _ := doc.GetRecord(0)
+	if v, _ := rec.GetByName("age"); v != "30" {
+		t.Errorf("GetByName(age) = %q, want \"30\"", v)
+	}
+}
+
+// TestDocumentSelectColumns tests column projection
+func TestDocumentSelectColumns(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "age", "email"}).
+		AddRecord([]string{"Alice", "30", "alice@example.com"}).
+		AddRecord([]string{"Bob", "25"})
+
+	tests := []struct {
+		name        string
+		columns     []string
+		wantHeaders []string
+		wantRecords [][]string
+		wantErr     bool
+	}{
+		{
+			name:        "subset",
+			columns:     []string{"name", "email"},
+			wantHeaders: []string{"name", "email"},
+			wantRecords: [][]string{{"Alice", "alice@example.com"}, {"Bob", ""}},
+		},
+		{
+			name:        "reordered",
+			columns:     []string{"age", "name"},
+			wantHeaders: []string{"age", "name"},
+			wantRecords: [][]string{{"30", "Alice"}, {"25", "Bob"}},
+		},
+		{
+			name:    "missing column",
+			columns: []string{"name", "phone"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := doc.SelectColumns(tt.columns...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(got.Headers(), ",") != strings.Join(tt.wantHeaders, ",") {
+				t.Errorf("Headers() = %v, want %v", got.Headers(), tt.wantHeaders)
+			}
+			if got.RecordCount() != len(tt.wantRecords) {
+				t.Fatalf("RecordCount() = %d, want %d", got.RecordCount(), len(tt.wantRecords))
+			}
+			for i, want := range tt.wantRecords {
+				rec, _ := got.GetRecord(i)
+				if strings.Join(rec.Fields(), "|") != strings.Join(want, "|") {
+					t.Errorf("record %d = %q, want %q", i, rec.Fields(), want)
+				}
+			}
+		})
+	}
+
+	// The source document is unchanged
+	if len(doc.Headers()) != 3 {
+		t.Errorf("source Headers() = %v, want 3 columns", doc.Headers())
+	}
+}
+
+// TestDocumentExplodeColumn tests splitting multi-value cells into records
+func TestDocumentExplodeColumn(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "tags", "age"}).
+		AddRecord([]string{"Alice", "a|b|c", "30"}).
+		AddRecord([]string{"Bob", "", "25"}).
+		AddRecord([]string{"Carol", "x", "41"}).
+		AddRecord([]string{"Dave"})
+
+	got, err := doc.ExplodeColumn("tags", "|")
+	if err != nil {
+		t.Fatalf("ExplodeColumn() error = %v", err)
+	}
+
+	want := [][]string{
+		{"Alice", "a", "30"},
+		{"Alice", "b", "30"},
+		{"Alice", "c", "30"},
+		{"Bob", "", "25"},
+		{"Carol", "x", "41"},
+		{"Dave"},
+	}
+	if strings.Join(got.Headers(), ",") != "name,tags,age" {
+		t.Errorf("Headers() = %v, want [name tags age]", got.Headers())
+	}
+	if got.RecordCount() != len(want) {
+		t.Fatalf("RecordCount() = %d, want %d", got.RecordCount(), len(want))
+	}
+	for i, w := range want {
+		rec, _ := got.GetRecord(i)
+		if strings.Join(rec.Fields(), ",") != strings.Join(w, ",") {
+			t.Errorf("record %d = %q, want %q", i, rec.Fields(), w)
+		}
+	}
+
+	// The source document is unchanged
+	if rec, _ := doc.GetRecord(0); rec.Fields()[1] != "a|b|c" {
+		t.Errorf("source record 0 = %q, want tags \"a|b|c\"", rec.Fields())
+	}
+
+	errTests := []struct {
+		name   string
+		doc    *csv.Document
+		column string
+		sep    string
+	}{
+		{name: "unknown column", doc: doc, column: "missing", sep: "|"},
+		{name: "empty separator", doc: doc, column: "tags", sep: ""},
+		{name: "no headers", doc: csv.NewDocument().AddRecord([]string{"a|b"}), column: "tags", sep: "|"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.doc.ExplodeColumn(tt.column, tt.sep); err == nil {
+				t.Error("ExplodeColumn() expected error")
+			}
+		})
+	}
+}
+
+// TestDocumentGroupBy tests rolling up records by a key column
+func TestDocumentGroupBy(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"category", "item", "amount"}).
+		AddRecord([]string{"fruit", "apple", "3"}).
+		AddRecord([]string{"veg", "leek", "4.5"}).
+		AddRecord([]string{"fruit", "pear", "2"}).
+		AddRecord([]string{"nuts", "pecan", "1"}).
+		AddRecord([]string{"veg", "kale", "0.5"})
+
+	sum := func(group []csv.Record) []string {
+		total := 0.0
+		for _, r := range group {
+			amount, _ := r.GetByName("amount")
+			n, err := strconv.ParseFloat(amount, 64)
+			if err != nil {
+				t.Fatalf("ParseFloat(%q) error = %v", amount, err)
+			}
+			total += n
+		}
+		return []string{strconv.FormatFloat(total, 'f', -1, 64)}
+	}
+
+	got, err := doc.GroupBy("category", sum)
+	if err != nil {
+		t.Fatalf("GroupBy() error = %v", err)
+	}
+	if want := []string{"category", "result"}; !reflect.DeepEqual(got.Headers(), want) {
+		t.Errorf("Headers() = %q, want %q", got.Headers(), want)
+	}
+	want := [][]string{{"fruit", "5"}, {"veg", "5"}, {"nuts", "1"}}
+	for i, w := range want {
+		rec, ok := got.GetRecord(i)
+		if !ok || !reflect.DeepEqual(rec.Fields(), w) {
+			t.Errorf("record %d = %q, want %q", i, rec.Fields(), w)
+		}
+	}
+	if got.RecordCount() != len(want) {
+		t.Errorf("RecordCount() = %d, want %d", got.RecordCount(), len(want))
+	}
+
+	// Several aggregate fields get numbered headers
+	countAndSum := func(group []csv.Record) []string {
+		return append([]string{strconv.Itoa(len(group))}, sum(group)...)
+	}
+	got, err = doc.GroupBy("category", countAndSum)
+	if err != nil {
+		t.Fatalf("GroupBy() error = %v", err)
+	}
+	if want := []string{"category", "result1", "result2"}; !reflect.DeepEqual(got.Headers(), want) {
+		t.Errorf("Headers() = %q, want %q", got.Headers(), want)
+	}
+	if rec, _ := got.GetRecord(0); !reflect.DeepEqual(rec.Fields(), []string{"fruit", "2", "5"}) {
+		t.Errorf("record 0 = %q, want [fruit 2 5]", rec.Fields())
+	}
+
+	errTests := []struct {
+		name   string
+		doc    *csv.Document
+		column string
+		agg    func([]csv.Record) []string
+	}{
+		{name: "unknown column", doc: doc, column: "missing", agg: sum},
+		{name: "nil aggregate", doc: doc, column: "category"},
+		{name: "no headers", doc: csv.NewDocument().AddRecord([]string{"a"}), column: "category", agg: sum},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.doc.GroupBy(tt.column, tt.agg); err == nil {
+				t.Error("GroupBy() expected error")
+			}
+		})
+	}
+}
+
+// TestDocumentDedup tests dropping repeated records
+func TestDocumentDedup(t *testing.T) {
+	records := func(doc *csv.Document) [][]string {
+		var out [][]string
+		for _, r := range doc.Records() {
+			out = append(out, r.Fields())
+		}
+		return out
+	}
+
+	doc := csv.NewDocument().
+		SetHeaders([]string{"email", "name", "city"}).
+		AddRecord([]string{"a@x", "Alice", "Paris"}).
+		AddRecord([]string{"b@x", "Bob", "Rome"}).
+		AddRecord([]string{"a@x", "Alice", "Paris"}).
+		AddRecord([]string{"a@x", "Alice Smith", "Paris"}).
+		AddRecord([]string{"b@x", "Bob", "Rome"}).
+		AddRecord([]string{"a@x", "Alice"}).
+		AddRecord([]string{"1:", "2"}).
+		AddRecord([]string{"1", ":2"})
+
+	got := doc.Dedup()
+	want := [][]string{
+		{"a@x", "Alice", "Paris"},
+		{"b@x", "Bob", "Rome"},
+		{"a@x", "Alice Smith", "Paris"},
+		{"a@x", "Alice"},
+		{"1:", "2"},
+		{"1", ":2"},
+	}
+	if !reflect.DeepEqual(records(got), want) {
+		t.Errorf("Dedup() = %q, want %q", records(got), want)
+	}
+	if !reflect.DeepEqual(got.Headers(), doc.Headers()) {
+		t.Errorf("Dedup() headers = %q, want %q", got.Headers(), doc.Headers())
+	}
+	if doc.RecordCount() != 8 {
+		t.Errorf("Dedup() changed the source document to %d records", doc.RecordCount())
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		want    [][]string
+	}{
+		{
+			name:    "single key column",
+			columns: []string{"email"},
+			want: [][]string{
+				{"a@x", "Alice", "Paris"},
+				{"b@x", "Bob", "Rome"},
+				{"1:", "2"},
+				{"1", ":2"},
+			},
+		},
+		{
+			name:    "two key columns",
+			columns: []string{"email", "name"},
+			want: [][]string{
+				{"a@x", "Alice", "Paris"},
+				{"b@x", "Bob", "Rome"},
+				{"a@x", "Alice Smith", "Paris"},
+				{"1:", "2"},
+				{"1", ":2"},
+			},
+		},
+		{
+			name:    "short record has empty key field",
+			columns: []string{"city"},
+			want: [][]string{
+				{"a@x", "Alice", "Paris"},
+				{"b@x", "Bob", "Rome"},
+				{"a@x", "Alice"},
+			},
+		},
+		{
+			name:    "no columns compares whole records",
+			columns: nil,
+			want:    want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := doc.DedupByColumns(tt.columns...)
+			if err != nil {
+				t.Fatalf("DedupByColumns(%q) error = %v", tt.columns, err)
+			}
+			if !reflect.DeepEqual(records(got), tt.want) {
+				t.Errorf("DedupByColumns(%q) = %q, want %q", tt.columns, records(got), tt.want)
+			}
+		})
+	}
+
+	if _, err := doc.DedupByColumns("emial"); err == nil {
+		t.Error("DedupByColumns() expected error for unknown column")
+	}
+	headerless := csv.NewDocument().AddRecord([]string{"a"})
+	if _, err := headerless.DedupByColumns("email"); err == nil {
+		t.Error("DedupByColumns() expected error without headers")
+	}
+}
+
+// TestDocumentTranspose tests turning columns into rows
+func TestDocumentTranspose(t *testing.T) {
+	records := func(doc *csv.Document) [][]string {
+		var out [][]string
+		for _, r := range doc.Records() {
+			out = append(out, r.Fields())
+		}
+		return out
+	}
+
+	doc := csv.NewDocument().
+		AddRecord([]string{"a", "b", "c"}).
+		AddRecord([]string{"d", "e", "f"})
+
+	got := doc.Transpose()
+	want := [][]string{{"a", "d"}, {"b", "e"}, {"c", "f"}}
+	if !reflect.DeepEqual(records(got), want) {
+		t.Errorf("Transpose() = %q, want %q", records(got), want)
+	}
+	if len(got.Headers()) != 0 {
+		t.Errorf("Transpose() headers = %q, want none", got.Headers())
+	}
+	if back := got.Transpose(); !reflect.DeepEqual(records(back), records(doc)) {
+		t.Errorf("Transpose().Transpose() = %q, want %q", records(back), records(doc))
+	}
+
+	// Headers become the first column and ragged records are padded
+	ragged := csv.NewDocument().
+		SetHeaders([]string{"metric", "jan", "feb"}).
+		AddRecord([]string{"revenue", "10", "12"}).
+		AddRecord([]string{"costs", "7"})
+	got = ragged.Transpose()
+	want = [][]string{{"metric", "revenue", "costs"}, {"jan", "10", "7"}, {"feb", "12", ""}}
+	if !reflect.DeepEqual(records(got), want) {
+		t.Errorf("Transpose() = %q, want %q", records(got), want)
+	}
+	back := [][]string{{"metric", "jan", "feb"}, {"revenue", "10", "12"}, {"costs", "7", ""}}
+	if got := records(got.Transpose()); !reflect.DeepEqual(got, back) {
+		t.Errorf("Transpose().Transpose() = %q, want %q", got, back)
+	}
+
+	if n := csv.NewDocument().Transpose().RecordCount(); n != 0 {
+		t.Errorf("empty Transpose() RecordCount() = %d, want 0", n)
+	}
+}
+
+// TestDocumentSelectColumnsNoHeaders tests projection without headers
+func TestDocumentSelectColumnsNoHeaders(t *testing.T) {
+	doc := csv.NewDocument().AddRecord([]string{"a", "b"})
+	if _, err := doc.SelectColumns("a"); err == nil {
+		t.Error("SelectColumns() without headers expected error")
+	}
+}
+
+// TestDocumentFilter tests filtering records with a predicate
+func TestDocumentFilter(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "age"}).
+		AddRecord([]string{"Alice", "30"}).
+		AddRecord([]string{"Bob", "25"}).
+		AddRecord([]string{"Carol", "42"}).
+		AddRecord([]string{"Dave", "n/a"})
+
+	filtered := doc.Filter(func(r csv.Record) bool {
+		age, _ := r.GetByName("age")
+		n, err := strconv.Atoi(age)
+		return err == nil && n >= 30
+	})
+
+	if filtered.RecordCount() != 2 {
+		t.Fatalf("Filter() RecordCount() = %d, want 2", filtered.RecordCount())
+	}
+	if strings.Join(filtered.Headers(), ",") != "name,age" {
+		t.Errorf("Filter() Headers() = %v, want [name age]", filtered.Headers())
+	}
+	for i, want := range []string{"Alice", "Carol"} {
+		rec, _ := filtered.GetRecord(i)
+		if name, _ := rec.GetByName("name"); name != want {
+			t.Errorf("Filter() record %d name = %q, want %q", i, name, want)
+		}
+	}
+
+	if doc.RecordCount() != 4 {
+		t.Errorf("source RecordCount() = %d, want 4", doc.RecordCount())
+	}
+}
+
+// TestDocumentSortByColumn tests lexical sorting by a named column
+func TestDocumentSortByColumn(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "age"}).
+		AddRecord([]string{"Carol", "42"}).
+		AddRecord([]string{"alice", "30"}).
+		AddRecord([]string{"Bob"})
+
+	if err := doc.SortByColumn("name", func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	}); err != nil {
+		t.Fatalf("SortByColumn() error = %v", err)
+	}
+	assertColumn(t, doc, 0, []string{"alice", "Bob", "Carol"})
+
+	// Short records sort as empty values
+	if err := doc.SortByColumn("age", func(a, b string) bool { return a < b }); err != nil {
+		t.Fatalf("SortByColumn() error = %v", err)
+	}
+	assertColumn(t, doc, 0, []string{"Bob", "alice", "Carol"})
+
+	if strings.Join(doc.Headers(), ",") != "name,age" {
+		t.Errorf("Headers() = %v, want [name age]", doc.Headers())
+	}
+	if err := doc.SortByColumn("email", func(a, b string) bool { return a < b }); err == nil {
+		t.Error("SortByColumn() expected error for missing column")
+	}
+}
+
+// TestDocumentSortByColumnNumeric tests numeric sorting by a named column
+func TestDocumentSortByColumnNumeric(t *testing.T) {
+	newDoc := func() *csv.Document {
+		return csv.NewDocument().
+			SetHeaders([]string{"name", "score"}).
+			AddRecord([]string{"a", "10"}).
+			AddRecord([]string{"b", "9.5"}).
+			AddRecord([]string{"c", "100"}).
+			AddRecord([]string{"d", "10"})
+	}
+
+	doc := newDoc()
+	if err := doc.SortByColumnNumeric("score", true); err != nil {
+		t.Fatalf("SortByColumnNumeric() error = %v", err)
+	}
+	assertColumn(t, doc, 0, []string{"b", "a", "d", "c"})
+
+	doc = newDoc()
+	if err := doc.SortByColumnNumeric("score", false); err != nil {
+		t.Fatalf("SortByColumnNumeric() error = %v", err)
+	}
+	assertColumn(t, doc, 0, []string{"c", "a", "d", "b"})
+
+	doc = newDoc()
+	if err := doc.SortByColumnNumeric("name", true); err == nil {
+		t.Error("SortByColumnNumeric() expected error for non-numeric column")
+	}
+	assertColumn(t, doc, 0, []string{"a", "b", "c", "d"})
+}
+
+// assertColumn checks the values of column col across all records of doc.
+func assertColumn(t *testing.T, doc *csv.Document, col int, want []string) {
+	t.Helper()
+	var got []string
+	for _, rec := range doc.Records() {
+		v, _ := rec.Get(col)
+		got = append(got, v)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("column %d = %v, want %v", col, got, want)
+	}
+}
+
+// TestRecordMap tests the header-keyed map view of a record
+func TestRecordMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		fields  []string
+		want    map[string]string
+		wantOK  bool
+	}{
+		{
+			name:    "with headers",
+			headers: []string{"name", "age"},
+			fields:  []string{"Alice", "30"},
+			want:    map[string]string{"name": "Alice", "age": "30"},
+			wantOK:  true,
+		},
+		{
+			name:    "without headers",
+			headers: []string{},
+			fields:  []string{"Alice", "30"},
+			wantOK:  false,
+		},
+		{
+			name:    "duplicate headers",
+			headers: []string{"id", "name", "name", "name_2", "name"},
+			fields:  []string{"1", "first", "second", "explicit", "third"},
+			want: map[string]string{
+				"id": "1", "name": "first", "name_3": "second", "name_2": "explicit", "name_4": "third",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "short record",
+			headers: []string{"name", "age"},
+			fields:  []string{"Bob"},
+			want:    map[string]string{"name": "Bob", "age": ""},
+			wantOK:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := csv.NewDocument().SetHeaders(tt.headers).AddRecord(tt.fields)
+			rec, _ := doc.GetRecord(0)
+			got, ok := rec.Map()
+			if ok != tt.wantOK {
+				t.Fatalf("Map() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if got != nil {
+					t.Errorf("Map() = %v, want nil", got)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Map() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("Map()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+// TestDocumentToMarkdown tests Markdown table rendering
+func TestDocumentToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  *csv.Document
+		want string
+	}{
+		{
+			name: "two columns",
+			doc: csv.NewDocument().
+				SetHeaders([]string{"name", "age"}).
+				AddRecord([]string{"Alice", "30"}).
+				AddRecord([]string{"Bob", "25"}),
+			want: "| name | age |\n" +
+				"| :--- | :--- |\n" +
+				"| Alice | 30 |\n" +
+				"| Bob | 25 |\n",
+		},
+		{
+			name: "pipe and newline in cells",
+			doc: csv.NewDocument().
+				SetHeaders([]string{"expr", "note"}).
+				AddRecord([]string{"a|b", "two\nlines"}),
+			want: "| expr | note |\n" +
+				"| :--- | :--- |\n" +
+				"| a\\|b | two<br>lines |\n",
+		},
+		{
+			name: "first record as header",
+			doc: csv.NewDocument().
+				AddRecord([]string{"x", "y"}).
+				AddRecord([]string{"1"}),
+			want: "| x | y |\n" +
+				"| :--- | :--- |\n" +
+				"| 1 |  |\n",
+		},
+		{
+			name: "empty document",
+			doc:  csv.NewDocument(),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.doc.ToMarkdown()
+			if err != nil {
+				t.Fatalf("ToMarkdown() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDocumentToJSON tests JSON rendering with and without headers
+func TestDocumentToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  *csv.Document
+		want string
+	}{
+		{
+			name: "objects",
+			doc: csv.NewDocument().
+				SetHeaders([]string{"name", "age"}).
+				AddRecord([]string{"Alice", "30"}).
+				AddRecord([]string{"Bob"}),
+			want: `[{"name":"Alice","age":"30"},{"name":"Bob","age":""}]`,
+		},
+		{
+			name: "duplicate headers",
+			doc: csv.NewDocument().
+				SetHeaders([]string{"id", "id"}).
+				AddRecord([]string{"1", "2"}),
+			want: `[{"id":"1","id_2":"2"}]`,
+		},
+		{
+			name: "arrays without headers",
+			doc: csv.NewDocument().
+				AddRecord([]string{"a", "b,\"c\""}).
+				AddRecord([]string{"d"}),
+			want: `[["a","b,\"c\""],["d"]]`,
+		},
+		{
+			name: "empty",
+			doc:  csv.NewDocument(),
+			want: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.doc.ToJSON()
+			if err != nil {
+				t.Fatalf("ToJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFromJSONRoundTrip tests that ToJSON output converts back to the same Document
+func TestFromJSONRoundTrip(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "note"}).
+		AddRecord([]string{"Alice", "likes \"tea\", not coffee"}).
+		AddRecord([]string{"Bob", "line1\nline2"})
+
+	data, err := doc.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	got, err := csv.FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+
+	want, _ := doc.CSV()
+	if out, _ := got.CSV(); out != want {
+		t.Errorf("round trip CSV() = %q, want %q", out, want)
+	}
+}
+
+// TestFromJSONRagged tests header inference from objects with differing keys
+func TestFromJSONRagged(t *testing.T) {
+	input := `[
+		{"name": "Alice", "age": 30},
+		{"name": "Bob", "email": "bob@example.com"},
+		{"age": null, "active": true}
+	]`
+
+	doc, err := csv.FromJSON([]byte(input))
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+
+	wantHeaders := []string{"name", "age", "email", "active"}
+	if strings.Join(doc.Headers(), ",") != strings.Join(wantHeaders, ",") {
+		t.Errorf("Headers() = %v, want %v", doc.Headers(), wantHeaders)
+	}
+	wantRecords := [][]string{
+		{"Alice", "30", "", ""},
+		{"Bob", "", "bob@example.com", ""},
+		{"", "", "", "true"},
+	}
+	if doc.RecordCount() != len(wantRecords) {
+		t.Fatalf("RecordCount() = %d, want %d", doc.RecordCount(), len(wantRecords))
+	}
+	for i, want := range wantRecords {
+		rec, _ := doc.GetRecord(i)
+		if strings.Join(rec.Fields(), "|") != strings.Join(want, "|") {
+			t.Errorf("record %d = %q, want %q", i, rec.Fields(), want)
+		}
+	}
+}
+
+// TestFromJSONErrors tests rejection of JSON that is not an array of objects
+func TestFromJSONErrors(t *testing.T) {
+	inputs := []string{
+		``,
+		`{"name": "Alice"}`,
+		`[["a", "b"]]`,
+		`[{"name": "Alice"}`,
+		`[{"name": "Alice"}] []`,
+	}
+	for _, input := range inputs {
+		if _, err := csv.FromJSON([]byte(input)); err == nil {
+			t.Errorf("FromJSON(%q) expected error", input)
+		}
+	}
+}
+
+func TestDocumentColumnEditing(t *testing.T) {
+	newDoc := func() *csv.Document {
+		return csv.NewDocument().
+			SetHeaders([]string{"name", "price", "qty"}).
+			AddRecord([]string{"pen", "2", "3"}).
+			AddRecord([]string{"ink", "5"})
+	}
+
+	t.Run("add computed column", func(t *testing.T) {
+		doc := newDoc()
+		if err := doc.AddColumn("total", []string{"6", "0"}); err != nil {
+			t.Fatalf("AddColumn() error = %v", err)
+		}
+		if got := strings.Join(doc.Headers(), ","); got != "name,price,qty,total" {
+			t.Errorf("Headers() = %q", got)
+		}
+		for i, want := range []string{"6", "0"} {
+			rec, _ := doc.GetRecord(i)
+			if got, ok := rec.GetByName("total"); !ok || got != want {
+				t.Errorf("record %d total = %q, %v; want %q", i, got, ok, want)
+			}
+		}
+		if rec, _ := doc.GetRecord(1); strings.Join(rec.Fields(), ",") != "ink,5,,0" {
+			t.Errorf("short record = %q, want padded before new column", rec.Fields())
+		}
+	})
+
+	t.Run("remove column", func(t *testing.T) {
+		doc := newDoc()
+		filtered := doc.Filter(func(csv.Record) bool { return true })
+		if err := doc.RemoveColumn("price"); err != nil {
+			t.Fatalf("RemoveColumn() error = %v", err)
+		}
+		got, _ := doc.CSV()
+		if want := "name,qty\npen,3\nink\n"; got != want {
+			t.Errorf("CSV() = %q, want %q", got, want)
+		}
+		rec, _ := doc.GetRecord(0)
+		if _, ok := rec.GetByName("price"); ok {
+			t.Error("GetByName(\"price\") still found after RemoveColumn")
+		}
+
+		// Documents sharing records are unaffected
+		if got, _ := filtered.CSV(); got != "name,price,qty\npen,2,3\nink,5\n" {
+			t.Errorf("filtered CSV() = %q, want original columns", got)
+		}
+	})
+
+	t.Run("rename column", func(t *testing.T) {
+		doc := newDoc()
+		if err := doc.RenameColumn("qty", "quantity"); err != nil {
+			t.Fatalf("RenameColumn() error = %v", err)
+		}
+		rec, _ := doc.GetRecord(0)
+		if got, ok := rec.GetByName("quantity"); !ok || got != "3" {
+			t.Errorf("GetByName(\"quantity\") = %q, %v; want \"3\"", got, ok)
+		}
+		if _, ok := rec.GetByName("qty"); ok {
+			t.Error("GetByName(\"qty\") still found after RenameColumn")
+		}
+	})
+
+	errTests := []struct {
+		name string
+		edit func(*csv.Document) error
+		want string
+	}{
+		{"add wrong length", func(d *csv.Document) error { return d.AddColumn("x", []string{"1"}) }, "got 1 values for 2 records"},
+		{"add existing", func(d *csv.Document) error { return d.AddColumn("qty", []string{"1", "2"}) }, "already exists"},
+		{"remove missing", func(d *csv.Document) error { return d.RemoveColumn("x") }, "not found"},
+		{"rename missing", func(d *csv.Document) error { return d.RenameColumn("x", "y") }, "not found"},
+		{"rename to existing", func(d *csv.Document) error { return d.RenameColumn("qty", "name") }, "already exists"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.edit(newDoc()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentSetCell(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name", "email"}).
+		AddRecord([]string{"Alice", "ALICE@EXAMPLE.COM"}).
+		AddRecord([]string{"Bob"})
+	original := doc.Filter(func(csv.Record) bool { return true })
+
+	if err := doc.SetCell(0, "email", "alice@example.com"); err != nil {
+		t.Fatalf("SetCell() error = %v", err)
+	}
+	if err := doc.SetCellByIndex(1, 1, "bob@example.com"); err != nil {
+		t.Fatalf("SetCellByIndex() error = %v", err)
+	}
+
+	got, _ := doc.CSV()
+	if want := "name,email\nAlice,alice@example.com\nBob,bob@example.com\n"; got != want {
+		t.Errorf("CSV() = %q, want %q", got, want)
+	}
+	if rec, _ := doc.GetRecord(0); rec.Fields()[1] != "alice@example.com" {
+		t.Errorf("GetRecord(0) = %q, want updated email", rec.Fields())
+	}
+	if got, _ := original.CSV(); got != "name,email\nAlice,ALICE@EXAMPLE.COM\nBob\n" {
+		t.Errorf("filtered document changed: %q", got)
+	}
+
+	errTests := []struct {
+		name string
+		set  func() error
+		want string
+	}{
+		{"row out of range", func() error { return doc.SetCell(2, "name", "x") }, "row 2 out of range"},
+		{"negative row", func() error { return doc.SetCellByIndex(-1, 0, "x") }, "row -1 out of range"},
+		{"column out of range", func() error { return doc.SetCellByIndex(0, 2, "x") }, "column 2 out of range"},
+		{"unknown column", func() error { return doc.SetCell(0, "phone", "x") }, "not found"},
+		{"no headers", func() error { return csv.NewDocument().AddRecord([]string{"a"}).SetCell(0, "a", "x") }, "requires headers"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.set(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentEach(t *testing.T) {
+	doc := csv.NewDocument().
+		SetHeaders([]string{"name"}).
+		AddRecord([]string{"Alice"}).
+		AddRecord([]string{"Bob"}).
+		AddRecord([]string{"Carol"})
+
+	t.Run("full iteration", func(t *testing.T) {
+		var got []string
+		doc.Each(func(i int, r csv.Record) bool {
+			name, _ := r.GetByName("name")
+			got = append(got, fmt.Sprintf("%d:%s", i, name))
+			return true
+		})
+		if want := "0:Alice,1:Bob,2:Carol"; strings.Join(got, ",") != want {
+			t.Errorf("Each() visited %v, want %s", got, want)
+		}
+
+		got = nil
+		for i, r := range doc.All() {
+			got = append(got, fmt.Sprintf("%d:%s", i, r.Fields()[0]))
+		}
+		if want := "0:Alice,1:Bob,2:Carol"; strings.Join(got, ",") != want {
+			t.Errorf("All() visited %v, want %s", got, want)
+		}
+	})
+
+	t.Run("early termination", func(t *testing.T) {
+		calls := 0
+		doc.Each(func(i int, r csv.Record) bool {
+			calls++
+			return i < 1
+		})
+		if calls != 2 {
+			t.Errorf("Each() made %d calls, want 2", calls)
+		}
+
+		var got []string
+		for _, r := range doc.All() {
+			got = append(got, r.Fields()[0])
+			if len(got) == 2 {
+				break
+			}
+		}
+		if strings.Join(got, ",") != "Alice,Bob" {
+			t.Errorf("All() with break visited %v, want [Alice Bob]", got)
+		}
+	})
+
+	t.Run("no allocations", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			doc.Each(func(int, csv.Record) bool { return true })
+		})
+		if allocs != 0 {
+			t.Errorf("Each() allocated %v times per run, want 0", allocs)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package csv
+
+import (
+	"bufio"
+	"io"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// EncodingAuto selects the input encoding from its byte order mark for
+// ReaderOptions.Encoding: UTF-16LE or UTF-16BE when the input starts with the
+// matching mark, and UTF-8 otherwise.
+const EncodingAuto = "auto"
+
+// validEncoding reports whether encoding is a supported ReaderOptions.Encoding.
+func validEncoding(encoding string) bool {
+	switch encoding {
+	case "", EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE, EncodingLatin1, EncodingAuto:
+		return true
+	}
+	return false
+}
+
+// newDecodingReader returns a reader that yields the contents of r, in the
+// given encoding, as UTF-8. A UTF-16 byte order mark matching the encoding is
+// skipped; a UTF-8 one is left for the parser to strip.
+func newDecodingReader(r io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case "", EncodingUTF8:
+		return r, nil
+	case EncodingLatin1:
+		return &latin1Reader{r: r}, nil
+	}
+	if !validEncoding(encoding) {
+		return nil, &OptionsError{Field: "Encoding", Message: "unknown encoding " + encoding}
+	}
+
+	br := bufio.NewReader(r)
+	prefix, _ := br.Peek(2)
+	little := len(prefix) == 2 && prefix[0] == 0xFF && prefix[1] == 0xFE
+	big := len(prefix) == 2 && prefix[0] == 0xFE && prefix[1] == 0xFF
+
+	switch {
+	case encoding == EncodingAuto && !little && !big:
+		return br, nil
+	case encoding == EncodingAuto:
+		encoding = EncodingUTF16LE
+		if big {
+			encoding = EncodingUTF16BE
+		}
+	}
+	bigEndian := encoding == EncodingUTF16BE
+	if (bigEndian && big) || (!bigEndian && little) {
+		_, _ = br.Discard(2)
+	}
+	return &utf16Reader{r: br, bigEndian: bigEndian}, nil
+}
+
+// copyRunes copies the longest prefix of whole UTF-8 sequences in src that
+// fits in dst, so that a reader decoding its input rune by rune never sees a
+// sequence split across reads. It copies a partial sequence only when dst is
+// too small for even one.
+func copyRunes(dst, src []byte) int {
+	n := copy(dst, src)
+	if n == len(src) {
+		return n
+	}
+	end := n
+	for end > 0 && !utf8.RuneStart(src[end]) {
+		end--
+	}
+	if end == 0 {
+		return n
+	}
+	return end
+}
+
+// utf16Reader decodes a UTF-16 stream to UTF-8. Unpaired surrogates and a
+// trailing odd byte are replaced with U+FFFD.
+type utf16Reader struct {
+	r         io.Reader
+	bigEndian bool
+	raw       [4096]byte
+	pending   int    // undecoded bytes carried over at the start of raw
+	out       []byte // decoded UTF-8 not yet returned
+	err       error
+}
+
+// Read implements io.Reader.
+func (d *utf16Reader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.fill()
+	}
+	n := copyRunes(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// fill reads more input and decodes every complete code unit. A trailing
+// odd byte or high surrogate is kept for the next call, since its pair may
+// not have arrived yet.
+func (d *utf16Reader) fill() {
+	n, err := d.r.Read(d.raw[d.pending:])
+	end := d.pending + n
+	out := d.out[:0]
+
+	i := 0
+decode:
+	for i+2 <= end {
+		u := d.unit(i)
+		if u >= 0xD800 && u < 0xDC00 {
+			// High surrogate, valid only when followed by a low one
+			if i+4 > end {
+				if err == nil {
+					break decode
+				}
+			} else if r := utf16.DecodeRune(rune(u), rune(d.unit(i+2))); r != unicode.ReplacementChar {
+				out = utf8.AppendRune(out, r)
+				i += 4
+				continue
+			}
+		}
+		r := rune(u)
+		if utf16.IsSurrogate(r) {
+			r = unicode.ReplacementChar
+		}
+		out = utf8.AppendRune(out, r)
+		i += 2
+	}
+
+	d.pending = copy(d.raw[:], d.raw[i:end])
+	if err != nil && d.pending > 0 {
+		// Truncated code unit at the end of the input
+		out = utf8.AppendRune(out, unicode.ReplacementChar)
+		d.pending = 0
+	}
+	d.out, d.err = out, err
+}
+
+// unit returns the UTF-16 code unit at offset i of raw.
+func (d *utf16Reader) unit(i int) uint16 {
+	if d.bigEndian {
+		return uint16(d.raw[i])<<8 | uint16(d.raw[i+1])
+	}
+	return uint16(d.raw[i+1])<<8 | uint16(d.raw[i])
+}
+
+// latin1Reader decodes an ISO-8859-1 stream to UTF-8.
+type latin1Reader struct {
+	r   io.Reader
+	raw [2048]byte
+	out []byte // decoded UTF-8 not yet returned
+	err error
+}
+
+// Read implements io.Reader.
+func (d *latin1Reader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		n, err := d.r.Read(d.raw[:])
+		out := d.out[:0]
+		for _, b := range d.raw[:n] {
+			// Every byte is a valid ISO-8859-1 code point
+			out = utf8.AppendRune(out, rune(b))
+		}
+		d.out, d.err = out, err
+	}
+	n := copyRunes(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
@@ -0,0 +1,131 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// isUTF16 reports whether data starts with a UTF-16 byte order mark.
+func isUTF16(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xFF, 0xFE}) || bytes.HasPrefix(data, []byte{0xFE, 0xFF})
+}
+
+func TestParseReaderWithOptions_Encoding(t *testing.T) {
+	text := "name,city,note\nJosé,Zürich,\"café, 😀\"\n名前,東京,x\n"
+	want := [][]string{
+		{"name", "city", "note"},
+		{"José", "Zürich", "café, 😀"},
+		{"名前", "東京", "x"},
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		data     []byte
+	}{
+		{"UTF-16LE with BOM", EncodingUTF16LE, encodeUTF16(text, false, true)},
+		{"UTF-16LE without BOM", EncodingUTF16LE, encodeUTF16(text, false, false)},
+		{"UTF-16BE with BOM", EncodingUTF16BE, encodeUTF16(text, true, true)},
+		{"auto UTF-16LE", EncodingAuto, encodeUTF16(text, false, true)},
+		{"auto UTF-16BE", EncodingAuto, encodeUTF16(text, true, true)},
+		{"auto UTF-8", EncodingAuto, []byte(text)},
+		{"auto UTF-8 with BOM", EncodingAuto, []byte("\ufeff" + text)},
+		{"UTF-8", EncodingUTF8, []byte(text)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultReaderOptions()
+			opts.Encoding = tt.encoding
+
+			node, err := ParseReaderWithOptions(bytes.NewReader(tt.data), opts)
+			if err != nil {
+				t.Fatalf("ParseReaderWithOptions() error = %v", err)
+			}
+			if got := NodeToRecords(node); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseReaderWithOptions() = %q, want %q", got, want)
+			}
+
+			// Plain UTF-8 is passed through undecoded
+			if tt.encoding == EncodingUTF8 || tt.encoding == EncodingAuto && !isUTF16(tt.data) {
+				return
+			}
+
+			// Code units and surrogate pairs split across reads
+			node, err = ParseReaderWithOptions(iotest.OneByteReader(bytes.NewReader(tt.data)), opts)
+			if err != nil {
+				t.Fatalf("ParseReaderWithOptions() one byte at a time error = %v", err)
+			}
+			if got := NodeToRecords(node); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseReaderWithOptions() one byte at a time = %q, want %q", got, want)
+			}
+		})
+	}
+
+	t.Run("large input", func(t *testing.T) {
+		opts := DefaultReaderOptions()
+		opts.Encoding = EncodingAuto
+
+		// Decoded output spans many reads with multi-byte runes at the edges
+		node, err := ParseReaderWithOptions(bytes.NewReader(encodeUTF16("h1,h2\n"+strings.Repeat("名前,東京😀\n", 5000), false, true)), opts)
+		if err != nil {
+			t.Fatalf("ParseReaderWithOptions() error = %v", err)
+		}
+		records := NodeToRecords(node)
+		if len(records) != 5001 {
+			t.Fatalf("ParseReaderWithOptions() got %d records, want 5001", len(records))
+		}
+		for i, record := range records[1:] {
+			if !reflect.DeepEqual(record, []string{"名前", "東京😀"}) {
+				t.Fatalf("record %d = %q, want [\"名前\" \"東京😀\"]", i, record)
+			}
+		}
+	})
+
+	t.Run("Latin-1", func(t *testing.T) {
+		opts := DefaultReaderOptions()
+		opts.Encoding = EncodingLatin1
+
+		node, err := ParseReaderWithOptions(bytes.NewReader([]byte("name\nJos\xe9\n")), opts)
+		if err != nil {
+			t.Fatalf("ParseReaderWithOptions() error = %v", err)
+		}
+		if got, want := NodeToRecords(node), [][]string{{"name"}, {"José"}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseReaderWithOptions() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid UTF-16", func(t *testing.T) {
+		opts := DefaultReaderOptions()
+		opts.Encoding = EncodingUTF16LE
+
+		// An unpaired surrogate and a truncated final code unit
+		data := append(encodeUTF16("a,b\nc,", false, true), 0x00, 0xD8, 'd', 0, 'x')
+		node, err := ParseReaderWithOptions(bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("ParseReaderWithOptions() error = %v", err)
+		}
+		want := [][]string{{"a", "b"}, {"c", "\ufffdd\ufffd"}}
+		if got := NodeToRecords(node); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseReaderWithOptions() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		opts := DefaultReaderOptions()
+		opts.Encoding = "EBCDIC"
+
+		_, err := ParseReaderWithOptions(bytes.NewReader([]byte("a\n")), opts)
+		var optsErr *OptionsError
+		if !errors.As(err, &optsErr) || optsErr.Field != "Encoding" {
+			t.Errorf("ParseReaderWithOptions() error = %v, want Encoding OptionsError", err)
+		}
+		if err := opts.Validate(); err == nil {
+			t.Error("Validate() with an unknown encoding: expected error")
+		}
+	})
+}
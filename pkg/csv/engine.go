@@ -0,0 +1,120 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shapestone/shape-core/pkg/ast"
+	"github.com/shapestone/shape-csv/internal/bom"
+	"github.com/shapestone/shape-csv/internal/fastparser"
+)
+
+// Engine selects the parser implementation used to read CSV.
+//
+// A fast engine is used only for plain RFC 4180 with a comma delimiter and
+// none of the options that add dialect features or checks; otherwise the
+// default engine is used. The fast engines agree with the default engine on
+// well-formed input, but differ on the following:
+//
+//   - A lone CR ends a record in the fast engines. The default engine does
+//     not treat it as a line break and drops the rest of the line.
+//   - A quote inside an unquoted field, as in a,b"c or a, "b", is an error
+//     in the fast engines. The default engine may instead end the record
+//     before the quote and start a new one.
+//   - Text after a closing quote, as in "a"x,b, is an error in the fast
+//     engines. The default engine starts a new record after the quote.
+//
+// Syntax errors from every engine are returned as *ParseError.
+type Engine int
+
+const (
+	// EngineDefault uses the standard parser, which supports every option.
+	EngineDefault Engine = iota
+	// EngineDFA uses a table-driven state machine parser.
+	EngineDFA
+	// EngineChunked uses a parser that scans unquoted fields eight bytes at a
+	// time.
+	EngineChunked
+)
+
+// String returns the string representation of Engine.
+func (e Engine) String() string {
+	switch e {
+	case EngineDefault:
+		return "default"
+	case EngineDFA:
+		return "dfa"
+	case EngineChunked:
+		return "chunked"
+	default:
+		return fmt.Sprintf("Engine(%d)", e)
+	}
+}
+
+// fastEngineSupported reports whether the DFA and chunked engines can honor
+// o. They parse plain RFC 4180 with a comma delimiter and double quotes, so
+// any option that changes the dialect or adds checks needs the default
+// engine.
+func (o ReaderOptions) fastEngineSupported() bool {
+	return o.Comma == ',' &&
+		(o.Quote == 0 || o.Quote == '"') &&
+		o.Comment == 0 &&
+		o.InlineComment == 0 &&
+		o.FieldsPerRecord < 0 &&
+		!o.LazyQuotes &&
+		!o.TrimLeadingSpace &&
+		!o.TrimTrailingSpace &&
+		o.SkipRows == 0 &&
+		o.RecordPrefix == "" &&
+		o.RecordSuffix == "" &&
+		o.MaxRecords == 0 &&
+		o.Timeout == 0 &&
+		o.UnbalancedQuoteMode == UnbalancedQuoteModeError &&
+		o.EscapeMode == EscapeModeRFC4180 &&
+		o.TerminatorLine == ""
+}
+
+// parseRecordsWithEngine parses data into records with engine. A leading byte
+// order mark is skipped. Syntax errors from the fast engines are returned as
+// *ParseError.
+func parseRecordsWithEngine(data []byte, engine Engine) ([][]string, error) {
+	var records [][]string
+	var err error
+	stripped := bom.Strip(data)
+	switch engine {
+	case EngineDFA:
+		records, err = fastparser.ParseDFA(stripped)
+	case EngineChunked:
+		records, err = fastparser.ParseChunked(stripped)
+	default:
+		return fastparser.Parse(data)
+	}
+
+	var syn *fastparser.SyntaxError
+	if errors.As(err, &syn) {
+		startLine, line, column := syn.Position(stripped)
+		return nil, &ParseError{
+			StartLine: startLine,
+			Line:      line,
+			Column:    column,
+			Offset:    len(data) - len(stripped) + syn.Offset,
+			Record:    syn.Record,
+			Err:       errors.New(syn.Msg),
+		}
+	}
+	return records, err
+}
+
+// parseWithEngine parses data into an AST with the engine selected in opts,
+// which must be a fast engine that supports opts.
+func parseWithEngine(data []byte, opts ReaderOptions) (ast.SchemaNode, error) {
+	records, err := parseRecordsWithEngine(data, opts.Engine)
+	if err != nil {
+		var perr *ParseError
+		if errors.As(err, &perr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("csv: %s engine: %w", opts.Engine, err)
+	}
+	return RecordsToNode(records)
+}
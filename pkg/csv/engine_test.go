@@ -0,0 +1,205 @@
+package csv_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+// engineCorpus is parsed by every engine and must give identical records.
+var engineCorpus = []struct {
+	name  string
+	input string
+}{
+	{"simple", "a,b,c\n1,2,3\n"},
+	{"no trailing newline", "a,b\n1,2"},
+	{"crlf", "a,b\r\n1,2\r\n"},
+	{"empty fields", "a,,c\n,,\n"},
+	{"quoted delimiter", "name,note\n\"Smith, Jane\",ok\n"},
+	{"escaped quotes", "a\n\"say \"\"hi\"\"\"\n"},
+	{"embedded newline", "a,b\n\"line1\nline2\",x\n"},
+	{"embedded crlf", "a,b\r\n\"line1\r\nline2\",x\r\n"},
+	{"empty lines", "a,b\n\n1,2\n\n"},
+	{"byte order mark", "\ufeffa,b\n1,2\n"},
+	{"unicode", "名前,都市\n太郎,東京\n"},
+	{"long unquoted fields", "abcdefghijklmnop,qrstuvwxyz0123456789\n" + strings.Repeat("x", 100) + ",y\n"},
+}
+
+// engineDivergences are inputs on which the fast engines differ from the
+// default engine, as documented on Engine. A nil fast result means the fast
+// engines report a *ParseError at line 1, column col.
+var engineDivergences = []struct {
+	name        string
+	input       string
+	defaultWant [][]string
+	fastWant    [][]string
+	col         int
+}{
+	{"lone cr", "a,b\rc,d\r", [][]string{{"a", "b"}}, [][]string{{"a", "b"}, {"c", "d"}}, 0},
+	{"quote after space", "a, \"b\"\n", [][]string{{"a", " "}, {"b"}}, nil, 4},
+	{"text after closing quote", "\"a\"x,b\n", [][]string{{"a"}, {"x", "b"}}, nil, 4},
+}
+
+func TestEngineDivergences(t *testing.T) {
+	for _, tt := range engineDivergences {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := csv.ParseWithOptions(tt.input, csv.DefaultReaderOptions())
+			if err != nil {
+				t.Fatalf("ParseWithOptions(default) error = %v", err)
+			}
+			if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, tt.defaultWant) {
+				t.Errorf("ParseWithOptions(default) = %q, want %q", got, tt.defaultWant)
+			}
+
+			for _, engine := range []csv.Engine{csv.EngineDFA, csv.EngineChunked} {
+				opts := csv.DefaultReaderOptions()
+				opts.Engine = engine
+				node, err := csv.ParseWithOptions(tt.input, opts)
+				if tt.fastWant == nil {
+					var perr *csv.ParseError
+					if !errors.As(err, &perr) {
+						t.Fatalf("ParseWithOptions(%s) error = %v (%T), want *ParseError", engine, err, err)
+					}
+					if perr.Line != 1 || perr.Column != tt.col || perr.Record != 1 {
+						t.Errorf("ParseWithOptions(%s) error at line %d, column %d, record %d, want 1, %d, 1",
+							engine, perr.Line, perr.Column, perr.Record, tt.col)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("ParseWithOptions(%s) error = %v", engine, err)
+				}
+				if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, tt.fastWant) {
+					t.Errorf("ParseWithOptions(%s) = %q, want %q", engine, got, tt.fastWant)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineParseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		startLine int
+		line      int
+		column    int
+		record    int
+	}{
+		{"quote mid-field", "a,b\"c\n", 1, 1, 4, 1},
+		{"quote in unquoted field", "a,b\nc,d\"e\n", 2, 2, 4, 2},
+		{"unclosed quote", "a,b\r\n\nc,\"d\ne\n", 3, 3, 3, 2},
+		{"after multiline field", "\"x\ny\",z\"w\n", 1, 2, 5, 1},
+	}
+
+	for _, tt := range tests {
+		for _, engine := range []csv.Engine{csv.EngineDFA, csv.EngineChunked} {
+			t.Run(tt.name+"/"+engine.String(), func(t *testing.T) {
+				opts := csv.DefaultReaderOptions()
+				opts.Engine = engine
+				_, err := csv.ParseWithOptions(tt.input, opts)
+				var perr *csv.ParseError
+				if !errors.As(err, &perr) {
+					t.Fatalf("ParseWithOptions() error = %v (%T), want *ParseError", err, err)
+				}
+				if perr.StartLine != tt.startLine || perr.Line != tt.line || perr.Column != tt.column || perr.Record != tt.record {
+					t.Errorf("ParseError = start line %d, line %d, column %d, record %d, want %d, %d, %d, %d",
+						perr.StartLine, perr.Line, perr.Column, perr.Record, tt.startLine, tt.line, tt.column, tt.record)
+				}
+			})
+		}
+	}
+}
+
+func TestEngineParity(t *testing.T) {
+	engines := []csv.Engine{csv.EngineDefault, csv.EngineDFA, csv.EngineChunked}
+
+	for _, tt := range engineCorpus {
+		t.Run(tt.name, func(t *testing.T) {
+			var want [][]string
+			for _, engine := range engines {
+				opts := csv.DefaultReaderOptions()
+				opts.Engine = engine
+
+				node, err := csv.ParseReaderWithOptions(strings.NewReader(tt.input), opts)
+				if err != nil {
+					t.Fatalf("ParseReaderWithOptions(%s) error = %v", engine, err)
+				}
+				got := csv.NodeToRecords(node)
+				if want == nil {
+					want = got
+				} else if !reflect.DeepEqual(got, want) {
+					t.Errorf("ParseReaderWithOptions(%s) = %q, want %q", engine, got, want)
+				}
+
+				node, err = csv.ParseWithOptions(tt.input, opts)
+				if err != nil {
+					t.Fatalf("ParseWithOptions(%s) error = %v", engine, err)
+				}
+				if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, want) {
+					t.Errorf("ParseWithOptions(%s) = %q, want %q", engine, got, want)
+				}
+
+				scanner := csv.NewScanner(strings.NewReader(tt.input)).SetEngine(engine)
+				var scanned [][]string
+				for scanner.Scan() {
+					scanned = append(scanned, scanner.Record().Fields())
+				}
+				if err := scanner.Err(); err != nil {
+					t.Fatalf("Scanner(%s) error = %v", engine, err)
+				}
+				if !reflect.DeepEqual(scanned, want) {
+					t.Errorf("Scanner(%s) = %q, want %q", engine, scanned, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineFallback(t *testing.T) {
+	// Options the fast engines cannot honor select the default engine
+	input := "# comment\na;b\n1;2\n"
+	want := [][]string{{"a", "b"}, {"1", "2"}}
+
+	for _, engine := range []csv.Engine{csv.EngineDFA, csv.EngineChunked} {
+		opts := csv.DefaultReaderOptions()
+		opts.Engine = engine
+		opts.Comma = ';'
+		opts.Comment = '#'
+
+		node, err := csv.ParseReaderWithOptions(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("ParseReaderWithOptions(%s) error = %v", engine, err)
+		}
+		if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseReaderWithOptions(%s) = %q, want %q", engine, got, want)
+		}
+	}
+}
+
+func TestEngineErrors(t *testing.T) {
+	for _, engine := range []csv.Engine{csv.EngineDefault, csv.EngineDFA, csv.EngineChunked} {
+		opts := csv.DefaultReaderOptions()
+		opts.Engine = engine
+		if _, err := csv.ParseReaderWithOptions(strings.NewReader("a,\"unclosed\n"), opts); err == nil {
+			t.Errorf("ParseReaderWithOptions(%s) with an unclosed quote: expected error", engine)
+		}
+	}
+}
+
+func TestEngineString(t *testing.T) {
+	tests := map[csv.Engine]string{
+		csv.EngineDefault: "default",
+		csv.EngineDFA:     "dfa",
+		csv.EngineChunked: "chunked",
+		csv.Engine(9):     "Engine(9)",
+	}
+	for engine, want := range tests {
+		if got := engine.String(); got != want {
+			t.Errorf("Engine(%d).String() = %q, want %q", int(engine), got, want)
+		}
+	}
+}
@@ -4,6 +4,9 @@ package csv
 import (
 	"errors"
 	"fmt"
+
+	"github.com/shapestone/shape-csv/internal/fastparser"
+	"github.com/shapestone/shape-csv/internal/parser"
 )
 
 // BadLineMode specifies how the parser handles malformed CSV lines.
@@ -32,8 +35,40 @@ func (m BadLineMode) String() string {
 	}
 }
 
+// UnbalancedQuoteMode specifies how the parser handles a quoted field that is
+// still open at the end of its line.
+type UnbalancedQuoteMode int
+
+const (
+	// UnbalancedQuoteModeError lets quoted fields span lines and returns an
+	// error if one is still open at the end of the input (default).
+	UnbalancedQuoteModeError UnbalancedQuoteMode = iota
+	// UnbalancedQuoteModeSkip drops a record whose quoted field is still open
+	// at the end of its line.
+	UnbalancedQuoteModeSkip
+	// UnbalancedQuoteModeBalanceAtLineEnd closes a quoted field that is still
+	// open at the end of its line, ending the record there.
+	UnbalancedQuoteModeBalanceAtLineEnd
+)
+
+// String returns the string representation of UnbalancedQuoteMode.
+func (m UnbalancedQuoteMode) String() string {
+	switch m {
+	case UnbalancedQuoteModeError:
+		return "error"
+	case UnbalancedQuoteModeSkip:
+		return "skip"
+	case UnbalancedQuoteModeBalanceAtLineEnd:
+		return "balance-at-line-end"
+	default:
+		return fmt.Sprintf("UnbalancedQuoteMode(%d)", m)
+	}
+}
+
 // ParseError represents a parsing error with position information.
 // It provides detailed context about where the error occurred in the CSV data.
+// Parse, ParseReader, ParseWithOptions and ParseReaderWithOptions return
+// their errors as *ParseError; use errors.As to inspect one.
 type ParseError struct {
 	// StartLine is the line where parsing started for this record (1-indexed).
 	StartLine int
@@ -41,6 +76,11 @@ type ParseError struct {
 	Line int
 	// Column is the column where the error occurred (1-indexed).
 	Column int
+	// Offset is the byte offset of the error in the input (0-indexed).
+	Offset int
+	// Record is the number of the record containing the error (1-indexed),
+	// counting records that failed to parse.
+	Record int
 	// Err is the underlying error.
 	Err error
 }
@@ -65,15 +105,94 @@ var (
 	ErrQuote = errors.New("bare \" in non-quoted-field")
 
 	// ErrFieldCount indicates a record has the wrong number of fields.
-	ErrFieldCount = errors.New("wrong number of fields")
+	ErrFieldCount = parser.ErrFieldCount
 
 	// ErrFieldTooLarge indicates a field exceeded MaxFieldSize.
-	ErrFieldTooLarge = errors.New("field exceeds maximum size")
+	ErrFieldTooLarge = parser.ErrFieldTooLarge
 
 	// ErrRecordTooLarge indicates a record exceeded MaxRecordSize.
-	ErrRecordTooLarge = errors.New("record exceeds maximum size")
+	ErrRecordTooLarge = parser.ErrRecordTooLarge
+
+	// ErrTimeout indicates parsing did not finish within ReaderOptions.Timeout.
+	ErrTimeout = parser.ErrTimeout
+
+	// ErrTooManyRecords indicates the input has more records than
+	// ReaderOptions.MaxRecords.
+	ErrTooManyRecords = parser.ErrTooManyRecords
 )
 
+// toParseError converts a positioned error from the internal parser into a
+// *ParseError. Other errors are returned unchanged.
+func toParseError(err error) error {
+	var posErr *parser.Error
+	if !errors.As(err, &posErr) {
+		return err
+	}
+	return &ParseError{
+		StartLine: posErr.StartLine,
+		Line:      posErr.Line,
+		Column:    posErr.Column,
+		Offset:    posErr.Offset,
+		Record:    posErr.Record,
+		Err:       posErr.Err,
+	}
+}
+
+// DecodeError reports a field that Unmarshal could not store in its struct
+// field, such as a non-numeric value in an int column. Unmarshal,
+// UnmarshalWithOptions and Scanner.Decode return conversion errors as
+// *DecodeError; use errors.As to inspect one.
+//
+// Example:
+//
+//	var derr *csv.DecodeError
+//	if errors.As(err, &derr) {
+//	    log.Printf("row %d, column %q: bad value %q", derr.Row, derr.Header, derr.Value)
+//	}
+type DecodeError struct {
+	// Row is the data row containing the field (1-indexed), not counting
+	// the header row.
+	Row int
+	// Column is the index of the field in its record (0-indexed).
+	Column int
+	// Header is the header of the column, or "" when there is none.
+	Header string
+	// Value is the field value that failed to decode.
+	Value string
+	// Err is the underlying conversion error.
+	Err error
+}
+
+// Error returns the conversion error message, which names the row and
+// column, followed by the column header.
+func (e *DecodeError) Error() string {
+	if e.Header == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (header %q)", e.Err, e.Header)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// toDecodeError converts a field error from the internal decoder into a
+// *DecodeError. Other errors are returned unchanged.
+func toDecodeError(err error) error {
+	var decErr *fastparser.DecodeError
+	if !errors.As(err, &decErr) {
+		return err
+	}
+	return &DecodeError{
+		Row:    decErr.Row,
+		Column: decErr.Column,
+		Header: decErr.Header,
+		Value:  decErr.Value,
+		Err:    decErr.Err,
+	}
+}
+
 // BadLineHandler is a callback function invoked when a bad line is encountered.
 // It receives the line number, the raw line content, and the error.
 // Return true to continue parsing, false to stop.
@@ -2,6 +2,7 @@ package csv_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/shapestone/shape-csv/pkg/csv"
@@ -106,3 +107,117 @@ func TestCommonErrors(t *testing.T) {
 		t.Error("ErrRecordTooLarge should not be nil")
 	}
 }
+
+func TestParseErrorPosition(t *testing.T) {
+	strict := csv.DefaultReaderOptions()
+	strict.FieldsPerRecord = 0
+
+	tests := []struct {
+		name      string
+		input     string
+		opts      *csv.ReaderOptions
+		wantErr   error
+		wantStart int
+		wantLine  int
+		wantCol   int
+		wantOff   int
+		wantRec   int
+	}{
+		{
+			name:      "unclosed quote",
+			input:     "a,b\nc,\"open\nd,e\n",
+			wantStart: 2,
+			wantLine:  2,
+			wantCol:   3,
+			wantOff:   6,
+			wantRec:   2,
+		},
+		{
+			name:      "field count mismatch",
+			input:     "a,b\nc,d\ne,f,g\n",
+			opts:      &strict,
+			wantErr:   csv.ErrFieldCount,
+			wantStart: 3,
+			wantLine:  3,
+			wantCol:   1,
+			wantOff:   8,
+			wantRec:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.opts != nil {
+				_, err = csv.ParseWithOptions(tt.input, *tt.opts)
+			} else {
+				_, err = csv.Parse(tt.input)
+			}
+
+			var pe *csv.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error = %v (%T), want *csv.ParseError", err, err)
+			}
+			if pe.StartLine != tt.wantStart || pe.Line != tt.wantLine || pe.Column != tt.wantCol {
+				t.Errorf("StartLine, Line, Column = %d, %d, %d, want %d, %d, %d",
+					pe.StartLine, pe.Line, pe.Column, tt.wantStart, tt.wantLine, tt.wantCol)
+			}
+			if pe.Offset != tt.wantOff {
+				t.Errorf("Offset = %d, want %d", pe.Offset, tt.wantOff)
+			}
+			if pe.Record != tt.wantRec {
+				t.Errorf("Record = %d, want %d", pe.Record, tt.wantRec)
+			}
+			if pe.Err == nil {
+				t.Error("Err is nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("errors.Is(err, %v) = false", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	type Person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+	input := []byte("name,age\nAlice,30\nBob,41\nCarol,abc\n")
+
+	for name, unmarshal := range map[string]func() error{
+		"Unmarshal": func() error {
+			var people []Person
+			return csv.Unmarshal(input, &people)
+		},
+		"UnmarshalWithOptions": func() error {
+			var people []Person
+			return csv.UnmarshalWithOptions(input, &people, csv.DefaultReaderOptions())
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := unmarshal()
+			var derr *csv.DecodeError
+			if !errors.As(err, &derr) {
+				t.Fatalf("error = %v, want *DecodeError", err)
+			}
+			if derr.Row != 3 || derr.Column != 1 || derr.Header != "age" || derr.Value != "abc" {
+				t.Errorf("DecodeError = %+v, want row 3, column 1, header age, value abc", derr)
+			}
+			if derr.Err == nil || !strings.Contains(err.Error(), `"age"`) {
+				t.Errorf("Error() = %q, want the underlying error and header", err)
+			}
+		})
+	}
+
+	scanner := csv.NewScanner(strings.NewReader(string(input))).SetHasHeaders(true)
+	var p Person
+	var err error
+	for scanner.Scan() && err == nil {
+		err = scanner.Decode(&p)
+	}
+	var derr *csv.DecodeError
+	if !errors.As(err, &derr) || derr.Value != "abc" {
+		t.Errorf("Scanner.Decode() error = %v, want *DecodeError for \"abc\"", err)
+	}
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// ParseGlob parses every file matching pattern, in name order, into a single
+// Document. The first row of the first file becomes the document headers, and
+// the first row of each later file must repeat them exactly; it is skipped.
+// Every file is read as having a header row, whatever opts.HasHeader says.
+// Empty files are ignored. Patterns use the syntax of filepath.Match.
+//
+// Returns an error if the pattern is malformed, matches no files, a file cannot
+// be read or parsed, or a file's header differs from the first file's.
+//
+// Example:
+//
+//	doc, err := csv.ParseGlob("exports/*.csv", csv.DefaultReaderOptions())
+func ParseGlob(pattern string, opts ReaderOptions) (*Document, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("csv: ParseGlob: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("csv: ParseGlob: no files match %q", pattern)
+	}
+	sort.Strings(paths)
+	opts.HasHeader = true
+
+	result := NewDocument()
+	var headerPath string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("csv: ParseGlob: %w", err)
+		}
+		doc, err := ParseDocumentWithOptions(string(data), opts)
+		if err != nil {
+			return nil, fmt.Errorf("csv: ParseGlob: %s: %w", path, err)
+		}
+		header := doc.headers
+		if len(header) == 0 {
+			continue
+		}
+
+		if headerPath == "" {
+			headerPath = path
+			result.SetHeaders(header)
+		} else if !slices.Equal(header, result.headers) {
+			return nil, fmt.Errorf("csv: ParseGlob: header %q in %s does not match %q in %s",
+				header, path, result.headers, headerPath)
+		}
+		result.records = append(result.records, doc.records...)
+	}
+
+	return result, nil
+}
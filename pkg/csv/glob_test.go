@@ -0,0 +1,82 @@
+package csv_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+// writeFiles creates the named files with the given contents in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseGlob(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"b.csv":     "name,age\nCarol,41\n",
+		"a.csv":     "name,age\nAlice,30\nBob,25\n",
+		"empty.csv": "",
+		"notes.txt": "ignored\n",
+	})
+
+	doc, err := csv.ParseGlob(filepath.Join(dir, "*.csv"), csv.DefaultReaderOptions())
+	if err != nil {
+		t.Fatalf("ParseGlob() error = %v", err)
+	}
+
+	got, _ := doc.CSV()
+	want := "name,age\nAlice,30\nBob,25\nCarol,41\n"
+	if got != want {
+		t.Errorf("ParseGlob() CSV() = %q, want %q", got, want)
+	}
+}
+
+func TestParseGlobErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		pattern string
+		wantMsg string
+	}{
+		{
+			name: "header mismatch",
+			files: map[string]string{
+				"a.csv": "name,age\nAlice,30\n",
+				"b.csv": "name,email\nBob,bob@example.com\n",
+			},
+			pattern: "*.csv",
+			wantMsg: "does not match",
+		},
+		{
+			name:    "no matches",
+			pattern: "*.csv",
+			wantMsg: "no files match",
+		},
+		{
+			name:    "bad pattern",
+			pattern: "[",
+			wantMsg: "syntax error in pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			_, err := csv.ParseGlob(filepath.Join(dir, tt.pattern), csv.DefaultReaderOptions())
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("ParseGlob() error = %v, want error containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/shapestone/shape-csv/internal/fastparser"
+	"github.com/shapestone/shape-csv/internal/parser"
+)
+
+// HeaderError reports a header row that does not match a struct's columns.
+type HeaderError struct {
+	Missing []string // struct columns absent from the header
+	Unknown []string // header columns with no matching struct field
+}
+
+func (e *HeaderError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing columns "+quoteList(e.Missing))
+	}
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown columns "+quoteList(e.Unknown))
+	}
+	return "csv: header mismatch: " + strings.Join(parts, "; ")
+}
+
+// headerKey returns the key under which a column name is matched to a struct
+// field, lowercased unless CaseSensitiveHeaders is set.
+func (o ReaderOptions) headerKey(name string) string {
+	if o.CaseSensitiveHeaders {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// quoteList formats names as a comma-separated list of quoted strings.
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// ValidateHeader checks that the header row of data contains a column for every
+// field of the struct type described by v, without decoding the remaining rows.
+// v may be a struct, a slice of structs, or a pointer to either.
+//
+// Columns are found exactly as Unmarshal finds them, including the fields of
+// embedded structs and the dotted columns, such as "address.city", of a
+// struct field tagged "recurse". Column names, and the names listed by an "alias=" tag
+// option, are matched case-insensitively unless opts.CaseSensitiveHeaders is
+// set. Fields tagged "-" and unexported fields are ignored; opts.FallbackTag
+// is honored. If opts.DisallowUnknownColumns is set, header columns that do
+// not map to any field are also reported. A struct bound by column index tags,
+// such as `csv:"3"`, only needs a header row to be present.
+//
+// A mismatch is returned as a *HeaderError listing the offending columns.
+//
+// Example:
+//
+//	if err := csv.ValidateHeader(data, &people, csv.DefaultReaderOptions()); err != nil {
+//	    return err // e.g. csv: header mismatch: missing columns "email"
+//	}
+//	err := csv.Unmarshal(data, &people)
+func ValidateHeader(data []byte, v interface{}, opts ReaderOptions) error {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("csv: ValidateHeader expects a struct or slice of structs, got %T", v)
+	}
+	columns, positional, err := fastparser.StructColumns(t, opts.decodeOptions())
+	if err != nil {
+		return err
+	}
+
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	p := parser.NewParserWithOptions(string(data), opts.parserOptions())
+	header, err := p.NextRecord()
+	if errors.Is(err, io.EOF) {
+		return errors.New("csv: ValidateHeader: no header row")
+	}
+	if err != nil {
+		return err
+	}
+	if positional {
+		// Fields bound by column index do not depend on the header names
+		return nil
+	}
+
+	present := make(map[string]bool, len(header))
+	for _, name := range header {
+		present[opts.headerKey(name)] = true
+	}
+
+	var herr HeaderError
+	known := make(map[string]bool)
+	for _, col := range columns {
+		found := false
+		for _, name := range append([]string{col.Name}, col.Aliases...) {
+			known[opts.headerKey(name)] = true
+			found = found || present[opts.headerKey(name)]
+		}
+		if !found {
+			herr.Missing = append(herr.Missing, col.Name)
+		}
+	}
+
+	if opts.DisallowUnknownColumns {
+		for _, name := range header {
+			if !known[opts.headerKey(name)] {
+				herr.Unknown = append(herr.Unknown, name)
+			}
+		}
+	}
+
+	if len(herr.Missing) > 0 || len(herr.Unknown) > 0 {
+		return &herr
+	}
+	return nil
+}
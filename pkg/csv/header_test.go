@@ -0,0 +1,219 @@
+package csv_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestValidateHeader(t *testing.T) {
+	type Person struct {
+		Name     string `csv:"name"`
+		Age      int    `csv:"age"`
+		Internal string `csv:"-"`
+		Email    string
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		strict      bool
+		exactCase   bool
+		wantMissing []string
+		wantUnknown []string
+	}{
+		{
+			name: "matching header",
+			data: "name,age,email\nAlice,30,a@example.com\n",
+		},
+		{
+			name: "case-insensitive match",
+			data: "NAME,Age,Email\n",
+		},
+		{
+			name:        "case-sensitive mismatch",
+			data:        "NAME,age,Email\n",
+			exactCase:   true,
+			wantMissing: []string{"name"},
+		},
+		{
+			name:        "missing column",
+			data:        "name,email\nAlice,a@example.com\n",
+			wantMissing: []string{"age"},
+		},
+		{
+			name: "extra column allowed",
+			data: "name,age,email,phone\n",
+		},
+		{
+			name:        "extra column rejected in strict mode",
+			data:        "name,age,email,phone\n",
+			strict:      true,
+			wantUnknown: []string{"phone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.DisallowUnknownColumns = tt.strict
+			opts.CaseSensitiveHeaders = tt.exactCase
+
+			err := csv.ValidateHeader([]byte(tt.data), &[]Person{}, opts)
+			if tt.wantMissing == nil && tt.wantUnknown == nil {
+				if err != nil {
+					t.Errorf("ValidateHeader() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var herr *csv.HeaderError
+			if !errors.As(err, &herr) {
+				t.Fatalf("ValidateHeader() error = %v, want *HeaderError", err)
+			}
+			if !reflect.DeepEqual(herr.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %q, want %q", herr.Missing, tt.wantMissing)
+			}
+			if !reflect.DeepEqual(herr.Unknown, tt.wantUnknown) {
+				t.Errorf("Unknown = %q, want %q", herr.Unknown, tt.wantUnknown)
+			}
+			for _, col := range append(tt.wantMissing, tt.wantUnknown...) {
+				if !strings.Contains(err.Error(), col) {
+					t.Errorf("error %q does not name column %q", err, col)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHeader_Errors(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+
+	if err := csv.ValidateHeader([]byte(""), &[]struct{ A string }{}, opts); err == nil {
+		t.Error("ValidateHeader() should fail on empty input")
+	}
+	if err := csv.ValidateHeader([]byte("a\n"), &[]string{}, opts); err == nil {
+		t.Error("ValidateHeader() should fail on a non-struct target")
+	}
+}
+
+func TestValidateHeader_Embedded(t *testing.T) {
+	type Base struct {
+		ID      int    `csv:"id"`
+		Created string `csv:"created"`
+	}
+	type Audited struct {
+		Base
+		Editor string `csv:"editor"`
+	}
+	type User struct {
+		*Audited
+		Name    string `csv:"name"`
+		Created string `csv:"created_at"`
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		wantMissing []string
+	}{
+		{
+			name: "embedded columns present",
+			data: "id,created,editor,name,created_at\n",
+		},
+		{
+			name:        "embedded column missing",
+			data:        "id,editor,name,created_at\n",
+			wantMissing: []string{"created"},
+		},
+		{
+			name:        "only outer columns",
+			data:        "name,created_at\n",
+			wantMissing: []string{"id", "created", "editor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := csv.ValidateHeader([]byte(tt.data), &[]User{}, csv.DefaultReaderOptions())
+			if tt.wantMissing == nil {
+				if err != nil {
+					t.Errorf("ValidateHeader() error = %v, want nil", err)
+				}
+				return
+			}
+			var herr *csv.HeaderError
+			if !errors.As(err, &herr) {
+				t.Fatalf("ValidateHeader() error = %v, want *HeaderError", err)
+			}
+			if !reflect.DeepEqual(herr.Missing, tt.wantMissing) {
+				t.Errorf("Missing = %q, want %q", herr.Missing, tt.wantMissing)
+			}
+		})
+	}
+
+	// A header accepted by ValidateHeader decodes into the embedded fields
+	data := []byte("id,created,editor,name,created_at\n7,2024-01-01,bob,Alice,today\n")
+	var users []User
+	if err := csv.Unmarshal(data, &users); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(users) != 1 || users[0].ID != 7 || users[0].Editor != "bob" || users[0].Name != "Alice" {
+		t.Errorf("Unmarshal() = %+v, want ID 7, Editor bob, Name Alice", users)
+	}
+}
+
+func TestValidateHeader_Recurse(t *testing.T) {
+	type Address struct {
+		Street string `csv:"street"`
+		City   string `csv:"city"`
+	}
+	type User struct {
+		Name    string  `csv:"name"`
+		Address Address `csv:"address,recurse"`
+	}
+
+	opts := csv.DefaultReaderOptions()
+	if err := csv.ValidateHeader([]byte("name,address.street,address.city\n"), &[]User{}, opts); err != nil {
+		t.Errorf("ValidateHeader() error = %v, want nil", err)
+	}
+
+	err := csv.ValidateHeader([]byte("name,address.city\n"), &[]User{}, opts)
+	var herr *csv.HeaderError
+	if !errors.As(err, &herr) {
+		t.Fatalf("ValidateHeader() error = %v, want *HeaderError", err)
+	}
+	if want := []string{"address.street"}; !reflect.DeepEqual(herr.Missing, want) {
+		t.Errorf("Missing = %q, want %q", herr.Missing, want)
+	}
+
+	// Dotted columns are known to strict validation
+	opts.DisallowUnknownColumns = true
+	if err := csv.ValidateHeader([]byte("name,address.street,address.city\n"), &[]User{}, opts); err != nil {
+		t.Errorf("ValidateHeader() strict error = %v, want nil", err)
+	}
+}
+
+func TestValidateHeader_Positional(t *testing.T) {
+	type Row struct {
+		Name string `csv:"0"`
+		Age  int    `csv:"1"`
+	}
+
+	opts := csv.DefaultReaderOptions()
+	opts.DisallowUnknownColumns = true
+	if err := csv.ValidateHeader([]byte("full name,years\nAlice,30\n"), &[]Row{}, opts); err != nil {
+		t.Errorf("ValidateHeader() error = %v, want nil", err)
+	}
+
+	type Mixed struct {
+		Name string `csv:"0"`
+		Age  int    `csv:"age"`
+	}
+	if err := csv.ValidateHeader([]byte("name,age\n"), &[]Mixed{}, opts); err == nil {
+		t.Error("ValidateHeader() should fail on a struct mixing index and name tags")
+	}
+}
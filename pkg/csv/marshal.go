@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/shapestone/shape-csv/internal/fastparser"
 )
 
 // Buffer pool for marshaling to reduce allocations
@@ -57,7 +59,18 @@ type Unmarshaler interface {
 // encoding if the field has an empty value, defined as false, 0, a nil pointer,
 // a nil interface value, and any empty array, slice, map, or string.
 // Note: In CSV, omitempty means the field is still included in the row, but
-// with an empty value. This maintains consistent column structure.
+// with an empty value. This maintains consistent column structure. If the
+// field is empty in every row, the whole column is dropped from the output
+// (unless OmitHeader is set in MarshalWithOptions, where the columns must
+// line up with an existing file).
+//
+// The "percent" option on a float field writes the value as a percentage,
+// so 0.45 is encoded as "45%". Unmarshal reverses this.
+//
+// A slice field, such as []string or []int, is written as a single column
+// with its elements joined by the "split=" tag option, or MultiValueSeparator
+// if none is given, so []int{1, 2, 3} is encoded as "1|2|3". Unmarshal splits
+// the column back into a slice.
 //
 // As a special case, if the field tag is "-", the field is always omitted.
 //
@@ -77,7 +90,7 @@ type Unmarshaler interface {
 //
 // Anonymous struct fields are currently not supported.
 //
-// Map and slice fields (other than []byte) are not supported.
+// Map fields are not supported; slice fields are joined as described above.
 //
 // Pointer values encode as the value pointed to. A nil pointer encodes as
 // an empty string.
@@ -85,6 +98,127 @@ type Unmarshaler interface {
 // The CSV header row is auto-generated from struct field names or tags,
 // and is sorted alphabetically for deterministic output.
 func Marshal(v interface{}) ([]byte, error) {
+	return marshalWithOptions(v, DefaultWriterOptions())
+}
+
+// MarshalWithOptions is like Marshal but writes using the given writer options.
+// Comma and UseCRLF control the delimiter and line terminator, and OmitHeader
+// suppresses the header row:
+//
+//	opts := csv.DefaultWriterOptions()
+//	opts.OmitHeader = true // append rows to an existing file
+//	data, err := csv.MarshalWithOptions(rows, opts)
+//
+// With QuoteEmptyFields, empty strings are written as "" while nil pointers
+// and omitted values are written as truly empty cells, so the distinction
+// survives a round trip through Unmarshal into pointer fields:
+//
+//	opts := csv.DefaultWriterOptions()
+//	opts.QuoteEmptyFields = true
+//	data, err := csv.MarshalWithOptions(rows, opts)
+func MarshalWithOptions(v interface{}, opts WriterOptions) ([]byte, error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return marshalWithOptions(v, opts)
+}
+
+// MarshalSorted is like MarshalWithOptions but emits the rows ordered by the
+// column named sortColumn. With numeric, cells are compared as float64 and a
+// cell that is not a number is an error; otherwise they are compared as
+// strings. The sort is stable, so rows with equal keys keep their input order.
+// Nil pointers in v are skipped, as with Marshal.
+//
+// All rows are buffered in memory while sorting. For inputs too large for
+// that, sort externally before marshaling; to sort already-parsed records,
+// use Document.SortByColumn.
+//
+// Example:
+//
+//	data, err := csv.MarshalSorted(orders, "date", false, true, csv.DefaultWriterOptions())
+func MarshalSorted(v interface{}, sortColumn string, numeric bool, ascending bool, opts WriterOptions) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || v == nil {
+		return nil, fmt.Errorf("csv: MarshalSorted(nil)")
+	}
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("csv: MarshalSorted expects slice, got %s", rv.Type())
+	}
+
+	elemType := rv.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: MarshalSorted expects slice of structs, got slice of %s", elemType)
+	}
+
+	var column *fieldEntry
+	fields := marshalFields(elemType)
+	for i := range fields {
+		if fields[i].name == sortColumn {
+			column = &fields[i]
+			break
+		}
+	}
+	if column == nil {
+		return nil, fmt.Errorf("csv: sort column %q not found", sortColumn)
+	}
+
+	// Extract the sort key of every non-nil row
+	type sortRow struct {
+		row    reflect.Value
+		text   string
+		number float64
+	}
+	rows := make([]sortRow, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if isPtr && row.IsNil() {
+			continue
+		}
+		fieldVal := reflect.Indirect(row).Field(column.index)
+
+		text, _, err := column.marshalValue(fieldVal)
+		if err != nil {
+			return nil, fmt.Errorf("csv: error marshaling field %s: %w", column.name, err)
+		}
+
+		r := sortRow{row: row, text: text}
+		if numeric {
+			r.number, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil {
+				return nil, fmt.Errorf("csv: cannot sort by %q: row %d value %q is not numeric", sortColumn, i+1, text)
+			}
+		}
+		rows = append(rows, r)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if !ascending {
+			a, b = b, a
+		}
+		if numeric {
+			return a.number < b.number
+		}
+		return a.text < b.text
+	})
+
+	sorted := reflect.MakeSlice(rv.Type(), len(rows), len(rows))
+	for i, r := range rows {
+		sorted.Index(i).Set(r.row)
+	}
+
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return marshalWithOptions(sorted.Interface(), opts)
+}
+
+// marshalWithOptions implements Marshal and MarshalWithOptions.
+func marshalWithOptions(v interface{}, opts WriterOptions) ([]byte, error) {
 	// Validate input
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() || v == nil {
@@ -110,52 +244,38 @@ func Marshal(v interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("csv: Marshal expects slice of structs, got slice of %s", elemType)
 	}
 
-	// Build field information
-	type fieldEntry struct {
-		name      string
-		index     int
-		omitEmpty bool
-	}
-
-	var fields []fieldEntry
-	for i := 0; i < elemType.NumField(); i++ {
-		field := elemType.Field(i)
-
-		// Skip unexported fields
-		if field.PkgPath != "" {
-			continue
-		}
+	fields := marshalFields(elemType)
 
-		info := getFieldInfo(field)
+	// Mark columns listed in ForceQuoteColumns
+	if _, err := markForceQuote(fields, opts); err != nil {
+		return nil, err
+	}
 
-		// Skip fields with "-" tag
-		if info.skip {
-			continue
+	// Drop omitempty columns that are empty in every row
+	if !opts.OmitHeader {
+		kept := fields[:0]
+		for _, field := range fields {
+			if !field.omitEmpty || !columnIsEmpty(rv, field.index) {
+				kept = append(kept, field)
+			}
 		}
-
-		fields = append(fields, fieldEntry{
-			name:      info.name,
-			index:     i,
-			omitEmpty: info.omitEmpty,
-		})
+		fields = kept
 	}
 
-	// Sort fields by name for deterministic output
-	sort.Slice(fields, func(i, j int) bool {
-		return fields[i].name < fields[j].name
-	})
-
 	buf := getBuffer()
 	defer putBuffer(buf)
 
 	// Write header row
-	for i, field := range fields {
-		if i > 0 {
-			buf.WriteByte(',')
+	if !opts.OmitHeader {
+		buf.WriteString(opts.RecordPrefix)
+		for i, field := range fields {
+			if i > 0 {
+				buf.WriteRune(opts.Comma)
+			}
+			writeFieldWithOptions(buf, field.name, opts, field.forceQuote)
 		}
-		writeField(buf, field.name)
+		writeRecordEnd(buf, opts)
 	}
-	buf.WriteByte('\n')
 
 	// Write data rows
 	for rowIdx := 0; rowIdx < rv.Len(); rowIdx++ {
@@ -170,26 +290,11 @@ func Marshal(v interface{}) ([]byte, error) {
 			row = row.Elem()
 		}
 
-		// Write each field
-		for i, field := range fields {
-			if i > 0 {
-				buf.WriteByte(',')
-			}
-
-			fieldVal := row.Field(field.index)
-
-			// Handle omitempty
-			if field.omitEmpty && isEmptyValue(fieldVal) {
-				// Write empty field (maintains column structure)
-				continue
-			}
-
-			// Convert field value to string and write
-			if err := marshalFieldValue(fieldVal, buf); err != nil {
-				return nil, fmt.Errorf("csv: error marshaling field %s: %w", field.name, err)
-			}
+		buf.WriteString(opts.RecordPrefix)
+		if err := writeStructRow(buf, row, fields, opts); err != nil {
+			return nil, err
 		}
-		buf.WriteByte('\n')
+		writeRecordEnd(buf, opts)
 	}
 
 	// Make a copy of the bytes since we're returning the buffer to the pool
@@ -198,72 +303,200 @@ func Marshal(v interface{}) ([]byte, error) {
 	return result, nil
 }
 
-// marshalFieldValue marshals a single field value to the buffer
-func marshalFieldValue(rv reflect.Value, buf *bytes.Buffer) error {
+// fieldEntry describes a struct field written as a CSV column.
+type fieldEntry struct {
+	name       string
+	index      int
+	omitEmpty  bool
+	percent    bool
+	split      string // separator joining the elements of a slice field
+	forceQuote bool   // set per call from WriterOptions.ForceQuoteColumns
+}
+
+// marshalValue converts fieldVal, the value of the column's struct field, to
+// its CSV string, applying the column's tag options.
+func (f fieldEntry) marshalValue(fieldVal reflect.Value) (value string, ok bool, err error) {
+	switch {
+	case f.percent:
+		return marshalPercentValue(fieldVal)
+	case fieldVal.Kind() == reflect.Slice && fieldVal.Type().Elem().Kind() != reflect.Uint8:
+		return marshalSliceValue(fieldVal, f.split)
+	}
+	return marshalFieldValue(fieldVal)
+}
+
+// marshalFields returns the columns written for struct type t, in the order
+// given by the struct type cache. The caller may modify the result.
+func marshalFields(t reflect.Type) []fieldEntry {
+	columns := fastparser.WriteColumns(t)
+	fields := make([]fieldEntry, len(columns))
+	for i, c := range columns {
+		fields[i] = fieldEntry{
+			name:      c.Name,
+			index:     c.Index,
+			omitEmpty: c.OmitEmpty,
+			percent:   c.Percent,
+			split:     c.Split,
+		}
+	}
+	return fields
+}
+
+// markForceQuote sets forceQuote on the fields listed in opts.ForceQuoteColumns
+// and returns the force mask for the header row.
+func markForceQuote(fields []fieldEntry, opts WriterOptions) ([]bool, error) {
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.name
+	}
+	force, err := forceQuoteMask(header, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range fields {
+		fields[i].forceQuote = force != nil && force[i]
+	}
+	return force, nil
+}
+
+// writeStructRow writes the given columns of struct value row to buf,
+// without a line terminator.
+func writeStructRow(buf *bytes.Buffer, row reflect.Value, fields []fieldEntry, opts WriterOptions) error {
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteRune(opts.Comma)
+		}
+
+		fieldVal := row.Field(field.index)
+
+		// Handle omitempty
+		if field.omitEmpty && isEmptyValue(fieldVal) {
+			// Write empty field (maintains column structure)
+			continue
+		}
+
+		// Convert field value to string and write
+		value, ok, err := field.marshalValue(fieldVal)
+		if err != nil {
+			return fmt.Errorf("csv: error marshaling field %s: %w", field.name, err)
+		}
+		if ok {
+			writeFieldWithOptions(buf, value, opts, field.forceQuote)
+		}
+	}
+	return nil
+}
+
+// columnIsEmpty reports whether the struct field at index is empty in every
+// element of the slice rv. Nil struct pointers are ignored.
+func columnIsEmpty(rv reflect.Value, index int) bool {
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		if !isEmptyValue(row.Field(index)) {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalFieldValue converts a single field value to its CSV string.
+// ok is false for nil pointers and interfaces, which have no value and are
+// written as empty cells.
+func marshalFieldValue(rv reflect.Value) (value string, ok bool, err error) {
 	// Handle invalid values
 	if !rv.IsValid() {
-		return nil // Empty field
+		return "", false, nil // Empty field
 	}
 
 	// Handle pointers
 	if rv.Kind() == reflect.Ptr {
 		if rv.IsNil() {
-			return nil // Empty field for nil pointer
+			return "", false, nil // Empty field for nil pointer
 		}
-		return marshalFieldValue(rv.Elem(), buf)
+		return marshalFieldValue(rv.Elem())
 	}
 
 	// Handle interface
 	if rv.Kind() == reflect.Interface {
 		if rv.IsNil() {
-			return nil // Empty field
+			return "", false, nil // Empty field
 		}
-		return marshalFieldValue(rv.Elem(), buf)
+		return marshalFieldValue(rv.Elem())
 	}
 
 	switch rv.Kind() {
 	case reflect.String:
-		writeField(buf, rv.String())
-		return nil
+		return rv.String(), true, nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		writeField(buf, strconv.FormatInt(rv.Int(), 10))
-		return nil
+		return strconv.FormatInt(rv.Int(), 10), true, nil
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		writeField(buf, strconv.FormatUint(rv.Uint(), 10))
-		return nil
+		return strconv.FormatUint(rv.Uint(), 10), true, nil
 
 	case reflect.Float32, reflect.Float64:
-		writeField(buf, strconv.FormatFloat(rv.Float(), 'g', -1, 64))
-		return nil
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), true, nil
 
 	case reflect.Bool:
-		writeField(buf, strconv.FormatBool(rv.Bool()))
-		return nil
+		return strconv.FormatBool(rv.Bool()), true, nil
 
 	default:
-		return fmt.Errorf("unsupported type %s", rv.Type())
+		return "", false, fmt.Errorf("unsupported type %s", rv.Type())
 	}
 }
 
-// writeField writes a CSV field to the buffer with proper escaping
-func writeField(buf *bytes.Buffer, value string) {
-	// Check if field needs quoting
-	needsQuoting := strings.ContainsAny(value, ",\"\n\r")
-
-	if needsQuoting {
-		buf.WriteByte('"')
-		// Escape quotes by doubling them
-		for _, ch := range value {
-			if ch == '"' {
-				buf.WriteString(`""`)
-			} else {
-				buf.WriteRune(ch)
-			}
+// marshalSliceValue converts a slice field to a single CSV string by joining
+// its elements with sep, so []int{1, 2, 3} is written as "1|2|3". Unmarshal
+// splits the value back on the same separator.
+func marshalSliceValue(rv reflect.Value, sep string) (value string, ok bool, err error) {
+	values := make([]string, rv.Len())
+	for i := range values {
+		values[i], _, err = marshalFieldValue(rv.Index(i))
+		if err != nil {
+			return "", false, err
+		}
+	}
+	return JoinField(values, sep), true, nil
+}
+
+// marshalPercentValue converts a float field tagged with the "percent" option,
+// so 0.45 is written as "45%".
+func marshalPercentValue(rv reflect.Value) (value string, ok bool, err error) {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "", false, nil // Empty field for nil pointer
 		}
-		buf.WriteByte('"')
-	} else {
-		buf.WriteString(value)
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+		return "", false, fmt.Errorf("percent option requires a float field, got %s", rv.Type())
+	}
+
+	return formatPercent(rv.Float(), rv.Type().Bits()), true, nil
+}
+
+// formatPercent formats a fraction as a percentage string, using the shortest
+// decimal that round-trips at bitSize (32 for float32, 64 for float64).
+// The multiplication by 100 is done on the decimal representation so that
+// 0.45 formats as "45%" rather than "45.00000000000001%".
+func formatPercent(f float64, bitSize int) string {
+	s := strconv.FormatFloat(f, 'e', -1, bitSize)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	e, err := strconv.Atoi(exp)
+	if err != nil {
+		// NaN and Inf have no exponent
+		return strconv.FormatFloat(f*100, 'g', -1, bitSize) + "%"
+	}
+	scaled, err := strconv.ParseFloat(mantissa+"e"+strconv.Itoa(e+2), bitSize)
+	if err != nil {
+		return strconv.FormatFloat(f*100, 'g', -1, bitSize) + "%"
 	}
+	return strconv.FormatFloat(scaled, 'f', -1, bitSize) + "%"
 }
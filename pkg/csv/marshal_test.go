@@ -1,6 +1,7 @@
 package csv
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -327,3 +328,338 @@ func TestMarshalOmitEmptyAdvanced(t *testing.T) {
 		})
 	}
 }
+
+// TestMarshalPercent tests the percent tag option round-trip
+func TestMarshalPercent(t *testing.T) {
+	type Rate struct {
+		Name string  `csv:"name"`
+		Rate float64 `csv:"rate,percent"`
+	}
+
+	input := []Rate{
+		{Name: "A", Rate: 0.45},
+		{Name: "B", Rate: 0.125},
+		{Name: "C", Rate: 1},
+	}
+
+	out, err := Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := "name,rate\nA,45%\nB,12.5%\nC,100%\n"
+	if string(out) != want {
+		t.Errorf("Marshal() = %q, want %q", out, want)
+	}
+
+	var got []Rate
+	if err := Unmarshal(out, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for i := range input {
+		if got[i] != input[i] {
+			t.Errorf("round-trip[%d] = %+v, want %+v", i, got[i], input[i])
+		}
+	}
+
+	// float32 fields format at their own precision
+	type Rate32 struct {
+		Rate float32 `csv:"rate,percent"`
+	}
+	input32 := []Rate32{{Rate: 0.07}, {Rate: 0.333}}
+	out, err = Marshal(input32)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := "rate\n7%\n33.3%\n"; string(out) != want {
+		t.Errorf("Marshal() = %q, want %q", out, want)
+	}
+	var got32 []Rate32
+	if err := Unmarshal(out, &got32); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got32, input32) {
+		t.Errorf("round-trip = %+v, want %+v", got32, input32)
+	}
+
+	if _, err := Marshal([]struct {
+		N int `csv:"n,percent"`
+	}{{N: 1}}); err == nil {
+		t.Error("Marshal() should reject the percent option on a non-float field")
+	}
+}
+
+// TestMarshalSliceFields tests that slice fields are joined into a single
+// column and split back by Unmarshal
+func TestMarshalSliceFields(t *testing.T) {
+	type Item struct {
+		Name   string   `csv:"name"`
+		Scores []int    `csv:"scores,split=|"`
+		Tags   []string `csv:"tags,split=;"`
+	}
+
+	input := []Item{
+		{Name: "widget", Scores: []int{1, 2, 3}, Tags: []string{"red", "a,b"}},
+		{Name: "gadget", Scores: []int{42}, Tags: []string{}},
+	}
+
+	out, err := Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := "name,scores,tags\nwidget,1|2|3,\"red;a,b\"\ngadget,42,\n"
+	if string(out) != want {
+		t.Errorf("Marshal() = %q, want %q", out, want)
+	}
+
+	var got []Item
+	if err := Unmarshal(out, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, input) {
+		t.Errorf("round-trip = %+v, want %+v", got, input)
+	}
+
+	// Without a split option the elements are joined with MultiValueSeparator
+	out, err = Marshal([]struct {
+		IDs []int `csv:"ids"`
+	}{{IDs: []int{7, 8}}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := "ids\n7" + MultiValueSeparator + "8\n"; string(out) != want {
+		t.Errorf("Marshal() = %q, want %q", out, want)
+	}
+}
+
+// TestMarshalQuoteEmptyFields tests that empty strings and nil pointers
+// stay distinguishable through a round trip
+func TestMarshalQuoteEmptyFields(t *testing.T) {
+	type Row struct {
+		ID   int     `csv:"id"`
+		Note *string `csv:"note"`
+	}
+
+	empty := ""
+	text := "hi"
+	rows := []Row{
+		{ID: 1, Note: &empty},
+		{ID: 2, Note: nil},
+		{ID: 3, Note: &text},
+	}
+
+	opts := DefaultWriterOptions()
+	opts.QuoteEmptyFields = true
+	data, err := MarshalWithOptions(rows, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+
+	want := "id,note\n1,\"\"\n2,\n3,hi\n"
+	if string(data) != want {
+		t.Errorf("MarshalWithOptions() = %q, want %q", data, want)
+	}
+
+	var got []Row
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Unmarshal() returned %d rows, want 3", len(got))
+	}
+	if got[0].Note == nil || *got[0].Note != "" {
+		t.Errorf("row 1 note = %v, want pointer to empty string", got[0].Note)
+	}
+	if got[1].Note != nil {
+		t.Errorf("row 2 note = %q, want nil", *got[1].Note)
+	}
+	if got[2].Note == nil || *got[2].Note != "hi" {
+		t.Errorf("row 3 note = %v, want pointer to \"hi\"", got[2].Note)
+	}
+}
+
+// TestMarshalWithOptionsNoHeader tests headerless output for appending rows
+func TestMarshalWithOptionsNoHeader(t *testing.T) {
+	type Row struct {
+		Name string `csv:"name"`
+		Note string `csv:"note"`
+	}
+	rows := []Row{
+		{Name: "Alice", Note: "a;b"},
+		{Name: "Bob", Note: `say "hi"`},
+	}
+
+	opts := DefaultWriterOptions()
+	opts.OmitHeader = true
+	opts.Comma = ';'
+	opts.UseCRLF = true
+
+	data, err := MarshalWithOptions(rows, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+
+	want := "Alice;\"a;b\"\r\nBob;\"say \"\"hi\"\"\"\r\n"
+	if string(data) != want {
+		t.Errorf("MarshalWithOptions() = %q, want %q", data, want)
+	}
+}
+
+// TestMarshalWithOptionsZeroValueHeader tests that hand-built options keep the
+// header row
+func TestMarshalWithOptionsZeroValueHeader(t *testing.T) {
+	type Row struct {
+		Name string `csv:"name"`
+		Note string `csv:"note,omitempty"`
+	}
+
+	data, err := MarshalWithOptions([]Row{{Name: "Alice"}}, WriterOptions{Comma: ';'})
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+	if want := "name\nAlice\n"; string(data) != want {
+		t.Errorf("MarshalWithOptions() = %q, want %q", data, want)
+	}
+}
+
+// TestMarshalOmitEmptyColumns tests that omitempty renders zero values as
+// empty cells and drops a column only when every row is empty
+func TestMarshalOmitEmptyColumns(t *testing.T) {
+	type Record struct {
+		Name   string `csv:"name"`
+		Count  int    `csv:"count,omitempty"`
+		Active bool   `csv:"active,omitempty"`
+		Notes  string `csv:"notes,omitempty"`
+	}
+
+	tests := []struct {
+		name  string
+		input []Record
+		want  string
+	}{
+		{
+			name: "int zero and bool false render empty",
+			input: []Record{
+				{Name: "A", Count: 0, Active: false, Notes: "x"},
+				{Name: "B", Count: 3, Active: true, Notes: ""},
+			},
+			want: "active,count,name,notes\n,,A,x\ntrue,3,B,\n",
+		},
+		{
+			name: "all-empty columns dropped",
+			input: []Record{
+				{Name: "A"},
+				{Name: "B", Notes: "kept"},
+			},
+			want: "name,notes\nA,\nB,kept\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Without a header the column layout must stay fixed
+	opts := DefaultWriterOptions()
+	opts.OmitHeader = true
+	got, err := MarshalWithOptions([]Record{{Name: "A"}}, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+	if want := ",,A,\n"; string(got) != want {
+		t.Errorf("MarshalWithOptions() = %q, want %q", got, want)
+	}
+}
+
+// TestMarshalSorted tests emitting rows ordered by a column
+func TestMarshalSorted(t *testing.T) {
+	type Item struct {
+		Name  string `csv:"name"`
+		Count int    `csv:"count"`
+	}
+	items := []*Item{
+		{Name: "pear", Count: 10},
+		{Name: "apple", Count: 9},
+		nil,
+		{Name: "fig", Count: 100},
+		{Name: "banana", Count: 9},
+	}
+
+	tests := []struct {
+		name      string
+		column    string
+		numeric   bool
+		ascending bool
+		want      string
+	}{
+		{
+			name:      "lexical ascending",
+			column:    "name",
+			ascending: true,
+			want:      "count,name\n9,apple\n9,banana\n100,fig\n10,pear\n",
+		},
+		{
+			name:      "lexical sort of numbers",
+			column:    "count",
+			ascending: true,
+			want:      "count,name\n10,pear\n100,fig\n9,apple\n9,banana\n",
+		},
+		{
+			name:      "numeric ascending is stable",
+			column:    "count",
+			numeric:   true,
+			ascending: true,
+			want:      "count,name\n9,apple\n9,banana\n10,pear\n100,fig\n",
+		},
+		{
+			name:    "numeric descending",
+			column:  "count",
+			numeric: true,
+			want:    "count,name\n100,fig\n10,pear\n9,apple\n9,banana\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalSorted(items, tt.column, tt.numeric, tt.ascending, DefaultWriterOptions())
+			if err != nil {
+				t.Fatalf("MarshalSorted() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalSorted() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The input slice is not reordered
+	if items[0].Name != "pear" {
+		t.Errorf("MarshalSorted() modified its input: first item = %q", items[0].Name)
+	}
+}
+
+// TestMarshalSortedErrors tests missing and non-numeric sort columns
+func TestMarshalSortedErrors(t *testing.T) {
+	type Item struct {
+		Name string `csv:"name"`
+	}
+	items := []Item{{Name: "b"}, {Name: "a"}}
+
+	if _, err := MarshalSorted(items, "missing", false, true, DefaultWriterOptions()); err == nil {
+		t.Error("MarshalSorted() expected error for missing column")
+	}
+	if _, err := MarshalSorted(items, "name", true, true, DefaultWriterOptions()); err == nil {
+		t.Error("MarshalSorted() expected error for non-numeric column")
+	}
+	if _, err := MarshalSorted([]string{"a"}, "name", false, true, DefaultWriterOptions()); err == nil {
+		t.Error("MarshalSorted() expected error for non-struct slice")
+	}
+}
@@ -0,0 +1,90 @@
+package csv
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MergeDocuments concatenates the records of docs, in order, into a new
+// Document. Every document must have the same headers, or every document
+// must have none; the result takes the shared headers. Merging no documents
+// returns an empty Document. The records are shared with docs, not copied.
+//
+// Returns an error if a document is nil or its headers differ from the first
+// document's. Use UnionColumns to merge documents whose columns differ.
+//
+// Example:
+//
+//	report, err := csv.MergeDocuments(january, february, march)
+func MergeDocuments(docs ...*Document) (*Document, error) {
+	result := NewDocument()
+	for i, doc := range docs {
+		if doc == nil {
+			return nil, fmt.Errorf("csv: MergeDocuments: document %d is nil", i)
+		}
+		if i == 0 {
+			result.SetHeaders(doc.headers)
+		} else if !slices.Equal(doc.headers, result.headers) {
+			return nil, fmt.Errorf("csv: MergeDocuments: headers %q of document %d do not match %q of document 0",
+				doc.headers, i, result.headers)
+		}
+		result.records = append(result.records, doc.records...)
+	}
+	return result, nil
+}
+
+// UnionColumns concatenates the records of docs, in order, into a new
+// Document whose headers are every column name found in docs, in the order
+// each is first seen. Fields are aligned by header name, and a record gets an
+// empty cell for each column its document lacks. If a document repeats a
+// header name, the first occurrence is used.
+//
+// Returns an error if a document is nil or has no headers.
+//
+// Example:
+//
+//	// name,email     name,phone          name,email,phone
+//	// Alice,a@x  +   Bob,555-0100  =>    Alice,a@x,
+//	//                                    Bob,,555-0100
+//	merged, err := csv.UnionColumns(crm, billing)
+func UnionColumns(docs ...*Document) (*Document, error) {
+	var headers []string
+	seen := make(map[string]bool)
+	for i, doc := range docs {
+		if doc == nil {
+			return nil, fmt.Errorf("csv: UnionColumns: document %d is nil", i)
+		}
+		if len(doc.headers) == 0 {
+			return nil, fmt.Errorf("csv: UnionColumns: document %d has no headers", i)
+		}
+		for _, name := range doc.headers {
+			if !seen[name] {
+				seen[name] = true
+				headers = append(headers, name)
+			}
+		}
+	}
+
+	result := NewDocument().SetHeaders(headers)
+	for _, doc := range docs {
+		indices := make([]int, len(headers))
+		for i, name := range headers {
+			if idx, ok := doc.columnIndex(name); ok {
+				indices[i] = idx
+			} else {
+				indices[i] = -1
+			}
+		}
+
+		for _, record := range doc.records {
+			fields := make([]string, len(headers))
+			for i, idx := range indices {
+				if idx >= 0 {
+					fields[i] = cell(record, idx)
+				}
+			}
+			result.AddRecord(fields)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package csv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+// documentRecords returns the fields of every record in doc.
+func documentRecords(doc *csv.Document) [][]string {
+	var out [][]string
+	for _, r := range doc.Records() {
+		out = append(out, r.Fields())
+	}
+	return out
+}
+
+func TestMergeDocuments(t *testing.T) {
+	jan := csv.NewDocument().
+		SetHeaders([]string{"month", "total"}).
+		AddRecord([]string{"jan", "10"})
+	feb := csv.NewDocument().
+		SetHeaders([]string{"month", "total"}).
+		AddRecord([]string{"feb", "12"}).
+		AddRecord([]string{"feb", "3"})
+
+	got, err := csv.MergeDocuments(jan, feb)
+	if err != nil {
+		t.Fatalf("MergeDocuments() error = %v", err)
+	}
+	if want := []string{"month", "total"}; !reflect.DeepEqual(got.Headers(), want) {
+		t.Errorf("Headers() = %q, want %q", got.Headers(), want)
+	}
+	want := [][]string{{"jan", "10"}, {"feb", "12"}, {"feb", "3"}}
+	if !reflect.DeepEqual(documentRecords(got), want) {
+		t.Errorf("records = %q, want %q", documentRecords(got), want)
+	}
+
+	// Headerless documents merge as long as none has headers
+	a := csv.NewDocument().AddRecord([]string{"1", "2"})
+	b := csv.NewDocument().AddRecord([]string{"3"})
+	got, err = csv.MergeDocuments(a, b)
+	if err != nil {
+		t.Fatalf("MergeDocuments() headerless error = %v", err)
+	}
+	if want := [][]string{{"1", "2"}, {"3"}}; !reflect.DeepEqual(documentRecords(got), want) {
+		t.Errorf("headerless records = %q, want %q", documentRecords(got), want)
+	}
+
+	got, err = csv.MergeDocuments()
+	if err != nil || got.RecordCount() != 0 {
+		t.Errorf("MergeDocuments() with no documents = %d records, %v; want 0, nil", got.RecordCount(), err)
+	}
+}
+
+func TestMergeDocumentsErrors(t *testing.T) {
+	doc := csv.NewDocument().SetHeaders([]string{"a", "b"}).AddRecord([]string{"1", "2"})
+
+	tests := []struct {
+		name string
+		docs []*csv.Document
+	}{
+		{
+			name: "different headers",
+			docs: []*csv.Document{doc, csv.NewDocument().SetHeaders([]string{"a", "c"})},
+		},
+		{
+			name: "reordered headers",
+			docs: []*csv.Document{doc, csv.NewDocument().SetHeaders([]string{"b", "a"})},
+		},
+		{
+			name: "headers and no headers",
+			docs: []*csv.Document{doc, csv.NewDocument().AddRecord([]string{"1", "2"})},
+		},
+		{
+			name: "nil document",
+			docs: []*csv.Document{doc, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := csv.MergeDocuments(tt.docs...); err == nil {
+				t.Error("MergeDocuments() expected error")
+			}
+		})
+	}
+}
+
+func TestUnionColumns(t *testing.T) {
+	crm := csv.NewDocument().
+		SetHeaders([]string{"name", "email"}).
+		AddRecord([]string{"Alice", "alice@example.com"}).
+		AddRecord([]string{"Carol"})
+	billing := csv.NewDocument().
+		SetHeaders([]string{"phone", "name"}).
+		AddRecord([]string{"555-0100", "Bob"})
+
+	got, err := csv.UnionColumns(crm, billing)
+	if err != nil {
+		t.Fatalf("UnionColumns() error = %v", err)
+	}
+	if want := []string{"name", "email", "phone"}; !reflect.DeepEqual(got.Headers(), want) {
+		t.Errorf("Headers() = %q, want %q", got.Headers(), want)
+	}
+	want := [][]string{
+		{"Alice", "alice@example.com", ""},
+		{"Carol", "", ""},
+		{"Bob", "", "555-0100"},
+	}
+	if !reflect.DeepEqual(documentRecords(got), want) {
+		t.Errorf("records = %q, want %q", documentRecords(got), want)
+	}
+
+	if _, err := csv.UnionColumns(crm, csv.NewDocument().AddRecord([]string{"x"})); err == nil {
+		t.Error("UnionColumns() with a headerless document expected error")
+	}
+	if _, err := csv.UnionColumns(crm, nil); err == nil {
+		t.Error("UnionColumns() with a nil document expected error")
+	}
+}
@@ -2,11 +2,17 @@
 package csv
 
 import (
+	"bufio"
+	"errors"
 	"io"
+	"strings"
+	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/shapestone/shape-core/pkg/ast"
 	"github.com/shapestone/shape-core/pkg/tokenizer"
+	"github.com/shapestone/shape-csv/internal/bom"
 	"github.com/shapestone/shape-csv/internal/parser"
 )
 
@@ -18,11 +24,27 @@ type ReaderOptions struct {
 	// Default: ','
 	Comma rune
 
+	// Quote is the character that encloses quoted fields, such as '\'' for
+	// dialects that quote with single quotes. Inside a quoted field it is
+	// escaped by doubling it.
+	// Default: 0 ('"')
+	Quote rune
+
 	// Comment, if not 0, is the comment character. Lines beginning with the
 	// Comment character without preceding whitespace are ignored.
 	// Default: 0 (disabled)
 	Comment rune
 
+	// InlineComment, if not 0, starts a comment that runs to the end of the
+	// line, as in "a,b,c # note". Outside quoted fields, the character, the
+	// rest of its line, and any spaces or tabs before it are discarded before
+	// the line is split into fields; inside quoted fields it is literal. It
+	// must differ from Comma, the quote character and Comment. When it is
+	// set, ParseReaderWithOptions reads the whole input into memory before
+	// parsing rather than streaming it.
+	// Default: 0 (disabled)
+	InlineComment rune
+
 	// FieldsPerRecord is the expected number of fields per record.
 	// If positive, each record must have exactly this many fields.
 	// If 0, the first record determines the expected field count.
@@ -30,6 +52,13 @@ type ReaderOptions struct {
 	// Default: 0
 	FieldsPerRecord int
 
+	// NormalizeFieldCount pads records with too few fields with empty fields
+	// and truncates records with too many to the count set by FieldsPerRecord,
+	// instead of reporting ErrFieldCount through OnBadLine. It has no effect
+	// when FieldsPerRecord is negative.
+	// Default: false
+	NormalizeFieldCount bool
+
 	// LazyQuotes controls whether a quote may appear in an unquoted field
 	// and a non-doubled quote may appear in a quoted field.
 	// Default: false
@@ -40,10 +69,185 @@ type ReaderOptions struct {
 	// Default: false
 	TrimLeadingSpace bool
 
+	// TrimTrailingSpace controls whether trailing spaces and tabs in an
+	// unquoted field are ignored. Quoted fields are left unchanged.
+	// Default: false
+	TrimTrailingSpace bool
+
+	// HasHeader reports that the first record is a header row rather than
+	// data. ParseDocumentWithOptions and ParseSections make it the document
+	// headers, Sample and ParseTail never drop or sample it, and
+	// VerifyRowCount leaves it out of the count. Functions that return plain records, such
+	// as ParseWithOptions and ReadAll, return it as the first record.
+	// UnmarshalWithOptions always reads a header row unless the struct is
+	// positional. The Scanner is configured with SetHasHeaders instead.
+	// Default: false
+	HasHeader bool
+
+	// SkipRows is the number of physical lines to discard before the header
+	// or first record, such as metadata lines at the top of a vendor export.
+	// Lines are counted as they appear in the input; quotes and comment
+	// characters in skipped lines are ignored.
+	// Default: 0
+	SkipRows int
+
+	// RecordPrefix and RecordSuffix, if set, are removed from the start and
+	// end of each record before it is parsed, so that output framed with the
+	// matching WriterOptions reads back as plain records. A record without
+	// the prefix or suffix is parsed unchanged. Line breaks inside quoted
+	// fields do not start a new record. With either set,
+	// ParseReaderWithOptions reads the whole input into memory before
+	// parsing rather than streaming it.
+	// Default: "" (disabled)
+	RecordPrefix string
+	RecordSuffix string
+
+	// MaxRecords, if positive, caps the number of records parsed, guarding
+	// against oversized untrusted input. Parsing fails with ErrTooManyRecords
+	// as soon as one more record is found.
+	// Default: 0 (no limit)
+	MaxRecords int
+
+	// Timeout, if positive, limits the total time spent parsing, so that a
+	// single pathological input cannot stall a batch job. The clock is
+	// checked every few thousand tokens, including inside long records and
+	// quoted fields, and parsing fails with ErrTimeout once the limit has
+	// passed, even with OnBadLine set to skip or warn. The limit starts when
+	// parsing starts.
+	//
+	// Timeout applies to the functions that parse with ReaderOptions, such
+	// as ParseWithOptions, ParseReaderWithOptions, ReadAll,
+	// ParseDocumentWithOptions, UnmarshalWithOptions, Sample and ParseTail;
+	// a fast Engine is not used when it is set. ParseRegex, Parse,
+	// ParseReader, Unmarshal and the Scanner have no time limit; use
+	// Scanner.ScanContext with a context deadline instead.
+	// Default: 0 (no limit)
+	Timeout time.Duration
+
+	// ProgressCallback, if not nil, is called by ParseReaderWithOptions and
+	// ReadAll with the number of input bytes read so far, about every 64KB
+	// and once more at the end of the input. totalBytes is the input size
+	// when the reader is an *os.File, *bytes.Reader or *strings.Reader, and
+	// -1 otherwise. Byte counts are of the raw input, before any decoding.
+	// Default: nil
+	ProgressCallback func(bytesRead, totalBytes int64)
+
+	// UnbalancedQuoteMode selects the recovery for a quoted field still open
+	// at the end of its line, as happens with a line holding an odd number of
+	// quotes. With UnbalancedQuoteModeSkip the record is dropped; with
+	// UnbalancedQuoteModeBalanceAtLineEnd the quote is closed at the line
+	// break. Either mode stops quoted fields from spanning lines.
+	// Default: UnbalancedQuoteModeError (quoted fields may span lines and one
+	// left open at the end of the input is an error)
+	UnbalancedQuoteMode UnbalancedQuoteMode
+
+	// Engine selects the parser used by ParseWithOptions and
+	// ParseReaderWithOptions. EngineDFA and EngineChunked parse plain RFC 4180
+	// only; the default engine is used instead whenever Comma is not ',' or
+	// any of Quote (other than '"'), Comment, InlineComment, FieldsPerRecord
+	// (other than negative), LazyQuotes, TrimLeadingSpace, TrimTrailingSpace,
+	// SkipRows, RecordPrefix, RecordSuffix, MaxRecords, Timeout,
+	// UnbalancedQuoteMode, EscapeMode or TerminatorLine is set. The fast
+	// engines read the whole input into memory, and differ from the default
+	// engine on some malformed input, as described on Engine.
+	// Default: EngineDefault
+	Engine Engine
+
 	// ReuseRecord controls whether calls to Read may return a slice sharing
 	// the backing array of the previous call's returned slice for performance.
 	// Default: false
 	ReuseRecord bool
+
+	// DecimalSeparator is the decimal separator used when decoding float
+	// fields with UnmarshalWithOptions, e.g. ',' for "1234,56".
+	// Default: 0 ('.')
+	DecimalSeparator rune
+
+	// ThousandsSeparator, if not 0, is removed from float fields before
+	// decoding with UnmarshalWithOptions. Fields tagged "currency" default to
+	// ',' (or '.' when DecimalSeparator is ',').
+	// Default: 0 (none)
+	ThousandsSeparator rune
+
+	// CurrencySymbol is removed from fields tagged "currency" before decoding.
+	// If empty, any Unicode currency symbol is removed.
+	// Default: ""
+	CurrencySymbol string
+
+	// StrictNumeric makes UnmarshalWithOptions return an error for numeric
+	// fields whose cells contain any whitespace, such as " 42 ", including
+	// fields tagged "currency", which otherwise ignore whitespace such as the
+	// space in "1.234,56 €". When false, numeric cells are parsed as they
+	// are; set TrimLeadingSpace and TrimTrailingSpace to accept padded
+	// numbers.
+	// Default: false
+	StrictNumeric bool
+
+	// EscapeMode selects how escape sequences in fields are handled. With
+	// EscapeModeBackslash, sequences such as \n, \t and \\ are decoded to
+	// newline, tab and backslash in quoted and unquoted fields, and an escaped
+	// delimiter, quote or line break, such as a\,b or "x\"y", is kept in the
+	// field instead of ending it. A doubled quote is still an escaped quote.
+	// Default: EscapeModeRFC4180 (no escapes; quotes are escaped by doubling)
+	EscapeMode EscapeMode
+
+	// EscapeChar is the escape character used with EscapeModeBackslash.
+	// Default: 0 ('\\')
+	EscapeChar rune
+	// DisallowUnknownColumns makes ValidateHeader also reject header columns
+	// that do not map to any struct field.
+	// Default: false
+	DisallowUnknownColumns bool
+
+	// CaseSensitiveHeaders makes UnmarshalWithOptions and ValidateHeader
+	// match header columns to struct field names exactly, so "Price" and
+	// "price" can map to different fields.
+	// Default: false (case-insensitive, as Unmarshal matches)
+	CaseSensitiveHeaders bool
+
+	// FallbackTag names a struct tag, such as "json", that is consulted for
+	// fields without a csv tag when decoding with UnmarshalWithOptions or
+	// checking with ValidateHeader. Its name and "-" are honored, so API
+	// structs can double as CSV targets without re-tagging.
+	// Default: "" (csv tag, then field name)
+	FallbackTag string
+
+	// Encoding is the text encoding of the input read by
+	// ParseReaderWithOptions, which decodes it to UTF-8 before parsing:
+	// EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE, EncodingLatin1, or
+	// EncodingAuto to choose UTF-16 from a byte order mark and UTF-8
+	// otherwise. A byte order mark matching the encoding is skipped.
+	// ParseWithOptions takes text that is already decoded and ignores it.
+	// Default: "" (UTF-8)
+	Encoding string
+
+	// AutoDetectDelimiter makes ParseWithOptions and ParseReaderWithOptions
+	// detect the delimiter with DetectDelimiter before parsing. If detection
+	// fails, Comma is used.
+	// Default: false
+	AutoDetectDelimiter bool
+
+	// AutoNameColumns makes ParseDocumentWithOptions name the columns of
+	// headerless data col1, col2, ... so that Record.GetByName works. The
+	// number of names is the widest record's field count. All rows are kept
+	// as data records. It is ignored when HasHeader is set.
+	// Default: false
+	AutoNameColumns bool
+	// TerminatorLine, if not empty, is a sentinel line such as "." or "[EOF]"
+	// that marks the end of the data, as in protocol-framed streams. Parsing
+	// stops at the first line exactly equal to it; the sentinel is not
+	// returned as a record and any input after it is ignored.
+	// Default: "" (disabled)
+	TerminatorLine string
+	// NullValues lists tokens, such as "NA", "NULL" or "-", that mean a missing
+	// value when decoding structs with UnmarshalWithOptions. Matching cells
+	// leave scalar fields at their zero value and pointer fields nil.
+	// Default: nil (none)
+	NullValues []string
+
+	// NullValuesIgnoreCase makes NullValues match case-insensitively.
+	// Default: false (exact match)
+	NullValuesIgnoreCase bool
 }
 
 // DefaultReaderOptions returns the default reader configuration.
@@ -67,9 +271,52 @@ type WriterOptions struct {
 	// Default: ','
 	Comma rune
 
+	// Quote is the character used to quote fields that need it. Embedded
+	// quote characters are escaped by doubling them.
+	// Default: 0 ('"')
+	Quote rune
+
 	// UseCRLF controls whether to use \r\n (true) or \n (false) as the line terminator.
 	// Default: false (use \n)
 	UseCRLF bool
+
+	// FlushEveryN, if positive, makes a streaming Writer flush its buffer after
+	// every N records. This bounds latency for network sinks at some cost in
+	// throughput.
+	// Default: 0 (flush only when the buffer fills or Flush is called)
+	FlushEveryN int
+
+	// QuoteEmptyFields writes empty strings as "" so they can be told apart
+	// from absent values (such as nil pointers), which are written as empty
+	// cells.
+	// Default: false
+	QuoteEmptyFields bool
+
+	// OmitHeader suppresses the header row written by MarshalWithOptions,
+	// Schema.Marshal, Writer.WriteStruct and Writer.WriteMap. Set it when
+	// appending rows to an existing file.
+	// Default: false (the header is written)
+	OmitHeader bool
+
+	// ForceQuoteColumns names columns whose fields, including the header, are
+	// always quoted; other columns are quoted only when required. Columns are
+	// matched against the header: the struct column names for Marshal and
+	// Writer.WriteStruct, the header passed to Writer.SetHeader, the first
+	// record written to a Writer or rendered by RenderWithOptions, or the
+	// Document headers. With OmitHeader, a Writer has no header row to take,
+	// so Write fails unless SetHeader was called. Naming a column that is not
+	// in the header is an error.
+	// Default: nil (minimal quoting)
+	ForceQuoteColumns []string
+
+	// RecordPrefix and RecordSuffix are written before and after each record,
+	// including the header, outside any quoting and before the line
+	// terminator. They frame records for line-based protocols, such as
+	// "data: " for server-sent events. Read framed output back by setting the
+	// same values in ReaderOptions.
+	// Default: "" (no framing)
+	RecordPrefix string
+	RecordSuffix string
 }
 
 // DefaultWriterOptions returns the default writer configuration.
@@ -89,17 +336,22 @@ func DefaultWriterOptions() WriterOptions {
 //	opts.TrimLeadingSpace = true
 //	node, err := csv.ParseWithOptions("name\tage\nAlice\t30", opts)
 func ParseWithOptions(input string, opts ReaderOptions) (ast.SchemaNode, error) {
-	p := parser.NewParserWithOptions(input, parser.Options{
-		Comma:            opts.Comma,
-		Comment:          opts.Comment,
-		FieldsPerRecord:  opts.FieldsPerRecord,
-		LazyQuotes:       opts.LazyQuotes,
-		TrimLeadingSpace: opts.TrimLeadingSpace,
-	})
-	return p.Parse()
+	input, opts = prepareInput(input, opts)
+	if opts.Engine != EngineDefault && opts.fastEngineSupported() {
+		return parseWithEngine([]byte(input), opts)
+	}
+	p := parser.NewParserWithOptions(input, opts.parserOptions())
+	node, err := p.Parse()
+	if err != nil {
+		return nil, toParseError(err)
+	}
+	return node, nil
 }
 
 // ParseReaderWithOptions parses CSV format into an AST from an io.Reader with custom options.
+// The input is streamed through the parser, except that RecordPrefix,
+// RecordSuffix, InlineComment and a fast Engine need the whole input and read
+// it into memory first.
 //
 // Example:
 //
@@ -107,15 +359,261 @@ func ParseWithOptions(input string, opts ReaderOptions) (ast.SchemaNode, error)
 //	opts.Comment = '#'  // Skip comment lines
 //	node, err := csv.ParseReaderWithOptions(file, opts)
 func ParseReaderWithOptions(reader io.Reader, opts ReaderOptions) (ast.SchemaNode, error) {
-	stream := tokenizer.NewStreamFromReader(reader)
-	p := parser.NewParserFromStreamWithOptions(stream, parser.Options{
-		Comma:            opts.Comma,
-		Comment:          opts.Comment,
-		FieldsPerRecord:  opts.FieldsPerRecord,
-		LazyQuotes:       opts.LazyQuotes,
-		TrimLeadingSpace: opts.TrimLeadingSpace,
-	})
-	return p.Parse()
+	reader, err := newDecodingReader(newProgressReader(reader, opts.ProgressCallback), opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if opts.AutoDetectDelimiter {
+		br := bufio.NewReaderSize(reader, detectSampleBytes)
+		sample, _ := br.Peek(detectSampleBytes)
+		if delim, err := DetectDelimiter(sample); err == nil {
+			opts.Comma = delim
+		}
+		reader = br
+	}
+	if opts.Engine != EngineDefault && opts.fastEngineSupported() {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return parseWithEngine(data, opts)
+	}
+	if opts.RecordPrefix != "" || opts.RecordSuffix != "" || opts.InlineComment != 0 {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		return ParseWithOptions(string(data), opts)
+	}
+	stream := tokenizer.NewStreamFromReader(bom.NewReader(reader))
+	p := parser.NewParserFromStreamWithOptions(stream, opts.parserOptions())
+	node, err := p.Parse()
+	if err != nil {
+		return nil, toParseError(err)
+	}
+	return node, nil
+}
+
+// ReadAll reads every remaining record from r, as a drop-in for
+// encoding/csv.Reader.ReadAll. Records are read as by ParseReaderWithOptions,
+// so Comma, Comment, FieldsPerRecord, LazyQuotes and the other options apply
+// the same way; a zero Comma defaults to ','. The header row, if any, is
+// returned as the first record. A field count mismatch is reported as a
+// *ParseError wrapping ErrFieldCount.
+//
+// Example:
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.Comment = '#'
+//	records, err := csv.ReadAll(file, opts)
+func ReadAll(r io.Reader, opts ReaderOptions) ([][]string, error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	node, err := ParseReaderWithOptions(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NodeToRecords(node), nil
+}
+
+// UnquoteField returns the value of raw, a single field as it appears in CSV
+// input, undoing the quoting applied by QuoteField. A field quoted with
+// opts.Quote has its enclosing quotes removed and doubled quotes collapsed;
+// an unquoted field is returned unchanged. With TrimLeadingSpace, leading
+// white space before the field is ignored.
+//
+// An error is returned for a quoted field that is never closed or has text
+// after its closing quote, and for an unquoted field containing the quote
+// character. With LazyQuotes, such stray quotes are kept as literal text.
+//
+// Example:
+//
+//	value, err := csv.UnquoteField(`"a""b"`, csv.DefaultReaderOptions())
+//	// value == `a"b`
+func UnquoteField(raw string, opts ReaderOptions) (string, error) {
+	quote := "\""
+	if opts.Quote != 0 {
+		quote = string(opts.Quote)
+	}
+	if opts.TrimLeadingSpace {
+		raw = strings.TrimLeftFunc(raw, unicode.IsSpace)
+	}
+
+	if !strings.HasPrefix(raw, quote) {
+		if !opts.LazyQuotes && strings.Contains(raw, quote) {
+			return "", errors.New("csv: UnquoteField: quote character in unquoted field")
+		}
+		return raw, nil
+	}
+
+	var sb strings.Builder
+	rest := raw[len(quote):]
+	for {
+		i := strings.Index(rest, quote)
+		if i < 0 {
+			return "", errors.New("csv: UnquoteField: unclosed quoted field")
+		}
+		sb.WriteString(rest[:i])
+		rest = rest[i+len(quote):]
+
+		switch {
+		case rest == "":
+			return sb.String(), nil
+		case strings.HasPrefix(rest, quote):
+			// Escaped quote
+			sb.WriteString(quote)
+			rest = rest[len(quote):]
+		case opts.LazyQuotes:
+			sb.WriteString(quote)
+		default:
+			return "", errors.New("csv: UnquoteField: text after closing quote")
+		}
+	}
+}
+
+// prepareInput applies the options handled before the parser runs: it
+// detects the delimiter with AutoDetectDelimiter and strips record framing and
+// inline comments. It returns the input and options to parse with.
+func prepareInput(input string, opts ReaderOptions) (string, ReaderOptions) {
+	if opts.AutoDetectDelimiter {
+		if delim, err := DetectDelimiter([]byte(input)); err == nil {
+			opts.Comma = delim
+		}
+	}
+	if opts.RecordPrefix != "" || opts.RecordSuffix != "" {
+		input = stripRecordFraming(input, opts)
+	}
+	if opts.InlineComment != 0 {
+		input = stripInlineComments(input, opts)
+	}
+	return input, opts
+}
+
+// stripRecordFraming removes opts.RecordPrefix and opts.RecordSuffix from each
+// record in input. Records end at line breaks outside quoted fields.
+func stripRecordFraming(input string, opts ReaderOptions) string {
+	quote := string(opts.Quote)
+	if opts.Quote == 0 {
+		quote = `"`
+	}
+	input = bom.StripString(input)
+
+	var sb strings.Builder
+	sb.Grow(len(input))
+	for pos := 0; pos < len(input); {
+		// Record content runs to the first line break outside quotes
+		start := pos
+		if opts.RecordPrefix != "" && strings.HasPrefix(input[pos:], opts.RecordPrefix) {
+			start += len(opts.RecordPrefix)
+		}
+		end, next := len(input), len(input)
+		inQuotes := false
+		for i := start; i < len(input); i++ {
+			if strings.HasPrefix(input[i:], quote) {
+				inQuotes = !inQuotes
+			} else if input[i] == '\n' && !inQuotes {
+				end, next = i, i+1
+				if end > start && input[end-1] == '\r' {
+					end--
+				}
+				break
+			}
+		}
+
+		sb.WriteString(strings.TrimSuffix(input[start:end], opts.RecordSuffix))
+		sb.WriteString(input[end:next])
+		pos = next
+	}
+	return sb.String()
+}
+
+// stripInlineComments removes opts.InlineComment comments from input, with
+// the spaces and tabs before them. Comment characters inside quoted fields
+// are kept; line terminators are preserved. The lines dropped by SkipRows and
+// Comment are left as they are, and quotes in them do not open quoted fields.
+func stripInlineComments(input string, opts ReaderOptions) string {
+	quote := opts.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(input))
+	inQuotes := false
+	lineStart := true
+	skip := opts.SkipRows
+	pending := 0 // start of the text not yet copied to sb
+	for i := 0; i < len(input); {
+		if lineStart && !inQuotes {
+			lineStart = false
+			r, _ := utf8.DecodeRuneInString(input[i:])
+			if skip > 0 || (opts.Comment != 0 && r == opts.Comment) {
+				// The parser drops the whole line, quotes included
+				if skip > 0 {
+					skip--
+				}
+				if nl := strings.IndexByte(input[i:], '\n'); nl >= 0 {
+					i += nl + 1
+					lineStart = true
+				} else {
+					i = len(input)
+				}
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if r == quote {
+			inQuotes = !inQuotes
+		} else if r == '\n' {
+			lineStart = true
+		} else if r == opts.InlineComment && !inQuotes {
+			sb.WriteString(strings.TrimRight(input[pending:i], " \t"))
+
+			// Skip to the line terminator, which is kept
+			end := len(input)
+			if nl := strings.IndexByte(input[i:], '\n'); nl >= 0 {
+				end = i + nl
+				if end > i && input[end-1] == '\r' {
+					end--
+				}
+			}
+			pending, i = end, end
+			continue
+		}
+		i += size
+	}
+	sb.WriteString(input[pending:])
+	return sb.String()
+}
+
+// parserOptions converts the reader options to internal parser options.
+func (o ReaderOptions) parserOptions() parser.Options {
+	popts := parser.Options{
+		Comma:               o.Comma,
+		Quote:               o.Quote,
+		Comment:             o.Comment,
+		FieldsPerRecord:     o.FieldsPerRecord,
+		NormalizeFieldCount: o.NormalizeFieldCount,
+		LazyQuotes:          o.LazyQuotes,
+		TrimLeadingSpace:    o.TrimLeadingSpace,
+		TrimTrailingSpace:   o.TrimTrailingSpace,
+		Terminator:          o.TerminatorLine,
+		MaxRecords:          o.MaxRecords,
+		SkipRows:            o.SkipRows,
+		UnbalancedQuotes:    parser.UnbalancedQuoteMode(o.UnbalancedQuoteMode),
+	}
+	if o.Timeout > 0 {
+		popts.Deadline = time.Now().Add(o.Timeout)
+	}
+	if o.EscapeMode == EscapeModeBackslash {
+		popts.Escape = o.EscapeChar
+		if popts.Escape == 0 {
+			popts.Escape = '\\'
+		}
+	}
+	return popts
 }
 
 // ValidateWithOptions checks if the input string is valid CSV with custom options.
@@ -200,6 +698,27 @@ func (o ReaderOptions) Validate() error {
 	if o.Comment == o.Comma {
 		return &OptionsError{Field: "Comment", Message: "comment character same as delimiter"}
 	}
+	if err := validateQuote(o.Quote, o.Comma); err != nil {
+		return err
+	}
+	if o.Quote != 0 && o.Quote == o.Comment {
+		return &OptionsError{Field: "Quote", Message: "quote character same as comment character"}
+	}
+	if o.InlineComment != 0 {
+		if !validDelim(o.InlineComment) {
+			return &OptionsError{Field: "InlineComment", Message: "invalid comment character"}
+		}
+		quote := o.Quote
+		if quote == 0 {
+			quote = '"'
+		}
+		if o.InlineComment == o.Comma || o.InlineComment == quote || o.InlineComment == o.Comment {
+			return &OptionsError{Field: "InlineComment", Message: "comment character same as delimiter, quote or comment character"}
+		}
+	}
+	if !validEncoding(o.Encoding) {
+		return &OptionsError{Field: "Encoding", Message: "unknown encoding " + o.Encoding}
+	}
 	return nil
 }
 
@@ -208,9 +727,32 @@ func (o WriterOptions) Validate() error {
 	if !validDelim(o.Comma) {
 		return &OptionsError{Field: "Comma", Message: "invalid delimiter"}
 	}
+	return validateQuote(o.Quote, o.Comma)
+}
+
+// validateQuote checks a Quote option against the delimiter. A zero quote
+// selects the default '"' and is always valid.
+func validateQuote(quote, comma rune) error {
+	if quote == 0 {
+		return nil
+	}
+	if quote == '\r' || quote == '\n' || !utf8.ValidRune(quote) || quote == utf8.RuneError {
+		return &OptionsError{Field: "Quote", Message: "invalid quote character"}
+	}
+	if quote == comma {
+		return &OptionsError{Field: "Quote", Message: "quote character same as delimiter"}
+	}
 	return nil
 }
 
+// quoteChar returns the quote character, defaulting to '"'.
+func (o WriterOptions) quoteChar() rune {
+	if o.Quote == 0 {
+		return '"'
+	}
+	return o.Quote
+}
+
 // OptionsError represents an invalid option configuration.
 type OptionsError struct {
 	Field   string
@@ -1,8 +1,11 @@
 package csv_test
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/shapestone/shape-core/pkg/ast"
 	"github.com/shapestone/shape-csv/pkg/csv"
@@ -206,6 +209,50 @@ func TestParseWithOptions_FieldsPerRecord(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions_NormalizeFieldCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  [][]string
+	}{
+		{
+			name:  "short row padded",
+			input: "a,b,c\n1\n",
+			want:  [][]string{{"a", "b", "c"}, {"1", "", ""}},
+		},
+		{
+			name:  "long row truncated",
+			input: "a,b,c\n1,2,3,4\n",
+			want:  [][]string{{"a", "b", "c"}, {"1", "2", "3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.FieldsPerRecord = 3
+			opts.NormalizeFieldCount = true
+
+			node, err := csv.ParseWithOptions(tt.input, opts)
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+
+			var got [][]string
+			for _, row := range node.(*ast.ArrayDataNode).Elements() {
+				var fields []string
+				for _, field := range row.(*ast.ArrayDataNode).Elements() {
+					fields = append(fields, field.(*ast.LiteralNode).Value().(string))
+				}
+				got = append(got, fields)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseWithOptions_TrimLeadingSpace(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -255,6 +302,125 @@ func TestParseWithOptions_TrimLeadingSpace(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions_TrimTrailingSpace(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		trim    bool
+		wantVal string
+	}{
+		{
+			name:    "no trim",
+			input:   "Alice  ,30",
+			trim:    false,
+			wantVal: "Alice  ",
+		},
+		{
+			name:    "trim enabled",
+			input:   "Alice  ,30",
+			trim:    true,
+			wantVal: "Alice",
+		},
+		{
+			name:    "quoted field untouched",
+			input:   "\"Alice  \",30",
+			trim:    true,
+			wantVal: "Alice  ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.TrimTrailingSpace = tt.trim
+
+			node, err := csv.ParseWithOptions(tt.input, opts)
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+
+			arr := node.(*ast.ArrayDataNode)
+			row := arr.Elements()[0].(*ast.ArrayDataNode)
+			field := row.Elements()[0].(*ast.LiteralNode)
+			got := field.Value().(string)
+
+			if got != tt.wantVal {
+				t.Errorf("got %q, want %q", got, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions_MaxRecords(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.MaxRecords = 2
+
+	if _, err := csv.ParseWithOptions("a\nb\n", opts); err != nil {
+		t.Errorf("ParseWithOptions() at the limit error = %v", err)
+	}
+	if _, err := csv.ParseWithOptions("a\nb\nc\n", opts); !errors.Is(err, csv.ErrTooManyRecords) {
+		t.Errorf("ParseWithOptions() over the limit error = %v, want ErrTooManyRecords", err)
+	}
+}
+
+func TestParseWithOptions_SkipRows(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.SkipRows = 2
+
+	node, err := csv.ParseWithOptions("Exported by Tool v2\n\"unbalanced\nname,age\nAlice,30\n", opts)
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+	want := [][]string{{"name", "age"}, {"Alice", "30"}}
+	if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWithOptions() = %q, want %q", got, want)
+	}
+}
+
+func TestParseWithOptions_UnbalancedQuoteMode(t *testing.T) {
+	// The second line has three quotes: the field opened by the first is
+	// still open at the line break.
+	input := "id,note,n\n1,\"abc\"\",2\n3,ok,4\n"
+
+	tests := []struct {
+		mode    csv.UnbalancedQuoteMode
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			mode:    csv.UnbalancedQuoteModeError,
+			wantErr: true,
+		},
+		{
+			mode: csv.UnbalancedQuoteModeSkip,
+			want: [][]string{{"id", "note", "n"}, {"3", "ok", "4"}},
+		},
+		{
+			mode: csv.UnbalancedQuoteModeBalanceAtLineEnd,
+			want: [][]string{{"id", "note", "n"}, {"1", "abc\",2"}, {"3", "ok", "4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.FieldsPerRecord = -1
+			opts.UnbalancedQuoteMode = tt.mode
+
+			node, err := csv.ParseWithOptions(input, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseWithOptions_LazyQuotes(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -289,6 +455,106 @@ func TestParseWithOptions_LazyQuotes(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions_BackslashEscapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  csv.EscapeMode
+		want  []string
+	}{
+		{
+			name:  "newline and backslash",
+			input: `line1\nline2,C:\\temp` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{"line1\nline2", `C:\temp`},
+		},
+		{
+			name:  "tab",
+			input: `a\tb,c` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{"a\tb", "c"},
+		},
+		{
+			name:  "escaped delimiter",
+			input: `a\,b,c` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{"a,b", "c"},
+		},
+		{
+			name:  "escaped quote",
+			input: `say \"hi\",c` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{`say "hi"`, "c"},
+		},
+		{
+			name:  "escaped line break",
+			input: "a\\\nb,c\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{"a\nb", "c"},
+		},
+		{
+			name:  "escaped backslash before delimiter",
+			input: `C:\\,d` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{`C:\`, "d"},
+		},
+		{
+			name:  "newline in quoted field",
+			input: `"a\nb",c` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{"a\nb", "c"},
+		},
+		{
+			name:  "escaped quote in quoted field",
+			input: `"x\"y",z` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{`x"y`, "z"},
+		},
+		{
+			name:  "escaped backslash before closing quote",
+			input: `"C:\\",d` + "\n",
+			mode:  csv.EscapeModeBackslash,
+			want:  []string{`C:\`, "d"},
+		},
+		{
+			name:  "quoted field with default escapes",
+			input: `"a\nb",c` + "\n",
+			mode:  csv.EscapeModeRFC4180,
+			want:  []string{`a\nb`, "c"},
+		},
+		{
+			name:  "disabled by default",
+			input: `a\nb,c` + "\n",
+			mode:  csv.EscapeModeRFC4180,
+			want:  []string{`a\nb`, "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.EscapeMode = tt.mode
+
+			node, err := csv.ParseWithOptions(tt.input, opts)
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+			records := csv.NodeToRecords(node)
+			if len(records) != 1 {
+				t.Fatalf("got %d records, want 1", len(records))
+			}
+			if len(records[0]) != len(tt.want) {
+				t.Fatalf("got fields %q, want %q", records[0], tt.want)
+			}
+			for i := range tt.want {
+				if records[0][i] != tt.want[i] {
+					t.Errorf("field %d = %q, want %q", i, records[0][i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestRenderWithOptions_CustomDelimiter(t *testing.T) {
 	// Create a simple AST
 	field1 := ast.NewLiteralNode("a", ast.ZeroPosition())
@@ -377,6 +643,43 @@ func TestRenderWithOptions_UseCRLF(t *testing.T) {
 	}
 }
 
+func TestOptions_Quote(t *testing.T) {
+	ropts := csv.DefaultReaderOptions()
+	ropts.Quote = '\''
+	node, err := csv.ParseWithOptions("'a,b',c\n'it''s',d\n", ropts)
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+	records := csv.NodeToRecords(node)
+	want := [][]string{{"a,b", "c"}, {"it's", "d"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("ParseWithOptions() = %q, want %q", records, want)
+	}
+
+	wopts := csv.DefaultWriterOptions()
+	wopts.Quote = '\''
+	out, err := csv.RenderWithOptions(node, wopts)
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	if got, want := string(out), "'a,b',c\n'it''s',d\n"; got != want {
+		t.Errorf("RenderWithOptions() = %q, want %q", got, want)
+	}
+
+	// The streaming Writer quotes with the same character
+	var buf strings.Builder
+	w := csv.NewWriter(&buf, wopts)
+	if err := w.Write([]string{"it's", `say "hi"`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "'it''s',say \"hi\"\n"; got != want {
+		t.Errorf("Writer.Write() = %q, want %q", got, want)
+	}
+}
+
 func TestOptionsValidation(t *testing.T) {
 	t.Run("reader options validation", func(t *testing.T) {
 		tests := []struct {
@@ -404,6 +707,41 @@ func TestOptionsValidation(t *testing.T) {
 				opts:    csv.ReaderOptions{Comma: ',', Comment: ','},
 				wantErr: true,
 			},
+			{
+				name:    "single quote",
+				opts:    csv.ReaderOptions{Comma: ',', Quote: '\''},
+				wantErr: false,
+			},
+			{
+				name:    "quote same as comma",
+				opts:    csv.ReaderOptions{Comma: ';', Quote: ';'},
+				wantErr: true,
+			},
+			{
+				name:    "quote same as comment",
+				opts:    csv.ReaderOptions{Comma: ',', Comment: '#', Quote: '#'},
+				wantErr: true,
+			},
+			{
+				name:    "inline comment",
+				opts:    csv.ReaderOptions{Comma: ',', InlineComment: '#'},
+				wantErr: false,
+			},
+			{
+				name:    "inline comment same as comma",
+				opts:    csv.ReaderOptions{Comma: ';', InlineComment: ';'},
+				wantErr: true,
+			},
+			{
+				name:    "inline comment same as custom quote",
+				opts:    csv.ReaderOptions{Comma: ',', Quote: '\'', InlineComment: '\''},
+				wantErr: true,
+			},
+			{
+				name:    "inline comment same as comment",
+				opts:    csv.ReaderOptions{Comma: ',', Comment: '#', InlineComment: '#'},
+				wantErr: true,
+			},
 		}
 
 		for _, tt := range tests {
@@ -432,6 +770,11 @@ func TestOptionsValidation(t *testing.T) {
 				opts:    csv.WriterOptions{Comma: '\n'},
 				wantErr: true,
 			},
+			{
+				name:    "invalid quote - newline",
+				opts:    csv.WriterOptions{Comma: ',', Quote: '\n'},
+				wantErr: true,
+			},
 		}
 
 		for _, tt := range tests {
@@ -529,3 +872,180 @@ func TestReaderPositionTracking(t *testing.T) {
 		t.Errorf("InputOffset() after SetOffset = %d, want 100", reader.InputOffset())
 	}
 }
+
+func TestParseWithOptions_TerminatorLine(t *testing.T) {
+	input := "name,age\nAlice,30\n.\nthis is trailer text, not \"csv\n"
+
+	opts := csv.DefaultReaderOptions()
+	opts.TerminatorLine = "."
+
+	node, err := csv.ParseWithOptions(input, opts)
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+	records := csv.NodeToRecords(node)
+	if len(records) != 2 || records[1][0] != "Alice" {
+		t.Errorf("ParseWithOptions() records = %v, want header and Alice", records)
+	}
+
+	node, err = csv.ParseReaderWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("ParseReaderWithOptions() error = %v", err)
+	}
+	if got := len(csv.NodeToRecords(node)); got != 2 {
+		t.Errorf("ParseReaderWithOptions() got %d records, want 2", got)
+	}
+}
+
+func TestParseWithOptions_Timeout(t *testing.T) {
+	input := strings.Repeat("alpha,\"beta\",gamma,delta\n", 200000)
+
+	opts := csv.DefaultReaderOptions()
+	opts.Timeout = time.Nanosecond
+
+	start := time.Now()
+	_, err := csv.ParseWithOptions(input, opts)
+	if !errors.Is(err, csv.ErrTimeout) {
+		t.Fatalf("ParseWithOptions() error = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ParseWithOptions() took %v to time out", elapsed)
+	}
+
+	_, err = csv.ParseReaderWithOptions(strings.NewReader(input), opts)
+	if !errors.Is(err, csv.ErrTimeout) {
+		t.Errorf("ParseReaderWithOptions() error = %v, want ErrTimeout", err)
+	}
+
+	opts.Timeout = time.Minute
+	if _, err := csv.ParseWithOptions("a,b\nc,d\n", opts); err != nil {
+		t.Errorf("ParseWithOptions() with generous timeout error = %v", err)
+	}
+}
+
+func TestParseWithOptions_InlineComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		comment rune
+		skip    int
+		want    [][]string
+	}{
+		{
+			name:  "trailing comment",
+			input: "a,b,c # note\n",
+			want:  [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:  "comment inside quotes is literal",
+			input: "\"x # y\",b # note, with \"quote\"\nc,d\n",
+			want:  [][]string{{"x # y", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "comment-only line",
+			input: "# header comment\na,b\r\n  # indented\nc,d#tight\r\n",
+			want:  [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "comment after quoted field with line break",
+			input: "\"multi\nline\" # note\nz\n",
+			want:  [][]string{{"multi\nline"}, {"z"}},
+		},
+		{
+			name:    "quote in comment line",
+			input:   "; it's \"odd\na,b # note\nc,d\n",
+			comment: ';',
+			want:    [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "quote in skipped row",
+			input: "exported \"today\na,b # note\nc,d\n",
+			skip:  1,
+			want:  [][]string{{"a", "b"}, {"c", "d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.InlineComment = '#'
+			opts.Comment = tt.comment
+			opts.SkipRows = tt.skip
+
+			node, err := csv.ParseWithOptions(tt.input, opts)
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+			if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() = %q, want %q", got, tt.want)
+			}
+
+			node, err = csv.ParseReaderWithOptions(strings.NewReader(tt.input), opts)
+			if err != nil {
+				t.Fatalf("ParseReaderWithOptions() error = %v", err)
+			}
+			if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReaderWithOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnquoteField(t *testing.T) {
+	lazy := csv.DefaultReaderOptions()
+	lazy.LazyQuotes = true
+	single := csv.DefaultReaderOptions()
+	single.Quote = '\''
+	trim := csv.DefaultReaderOptions()
+	trim.TrimLeadingSpace = true
+
+	tests := []struct {
+		name    string
+		raw     string
+		opts    csv.ReaderOptions
+		want    string
+		wantErr string
+	}{
+		{name: "escaped quote", raw: `"a""b"`, want: `a"b`},
+		{name: "unquoted passthrough", raw: "plain value", want: "plain value"},
+		{name: "quoted delimiter and newline", raw: "\"x,\ny\"", want: "x,\ny"},
+		{name: "empty quoted", raw: `""`, want: ""},
+		{name: "empty", raw: "", want: ""},
+		{name: "single quote char", raw: "'it''s'", opts: single, want: "it's"},
+		{name: "leading space", raw: `  "a"`, opts: trim, want: "a"},
+		{name: "unterminated quote", raw: `"abc`, wantErr: "unclosed quoted field"},
+		{name: "text after closing quote", raw: `"a"b`, wantErr: "text after closing quote"},
+		{name: "bare quote", raw: `a"b`, wantErr: "quote character in unquoted field"},
+		{name: "lazy bare quote", raw: `a"b`, opts: lazy, want: `a"b`},
+		{name: "lazy stray quote", raw: `"a"b"`, opts: lazy, want: `a"b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			if opts.Comma == 0 {
+				opts = csv.DefaultReaderOptions()
+			}
+			got, err := csv.UnquoteField(tt.raw, opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UnquoteField(%q) error = %v, want %q", tt.raw, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnquoteField(%q) error = %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("UnquoteField(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+
+			// Round trip through QuoteField
+			if tt.opts.Comma == 0 {
+				if back, _ := csv.UnquoteField(csv.QuoteField(got, csv.DefaultWriterOptions()), opts); back != got {
+					t.Errorf("round trip of %q = %q", got, back)
+				}
+			}
+		})
+	}
+}
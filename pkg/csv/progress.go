@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// progressInterval is the number of bytes read between progress callbacks.
+const progressInterval = 64 * 1024
+
+// progressReader reports the bytes read from r to fn about every
+// progressInterval bytes and once more at the end of the input.
+type progressReader struct {
+	r        io.Reader
+	fn       func(bytesRead, totalBytes int64)
+	total    int64
+	read     int64
+	reported int64
+}
+
+// newProgressReader wraps r to report progress to fn. It returns r unchanged
+// if fn is nil.
+func newProgressReader(r io.Reader, fn func(bytesRead, totalBytes int64)) io.Reader {
+	if fn == nil {
+		return r
+	}
+	return &progressReader{r: r, fn: fn, total: readerSize(r)}
+}
+
+// Read implements io.Reader.
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.read-p.reported >= progressInterval || (err == io.EOF && p.read > p.reported) {
+		p.reported = p.read
+		p.fn(p.read, p.total)
+	}
+	return n, err
+}
+
+// readerSize returns the number of bytes left to read from r, or -1 if it
+// cannot be known without reading.
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case *bytes.Reader:
+		return int64(r.Len())
+	case *strings.Reader:
+		return int64(r.Len())
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return fi.Size() - offset
+	case *contextReader:
+		return readerSize(r.r)
+	}
+	return -1
+}
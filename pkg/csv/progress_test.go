@@ -0,0 +1,93 @@
+package csv_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+// progressInput returns CSV data spanning several progress intervals.
+func progressInput() []byte {
+	var sb strings.Builder
+	sb.WriteString("id,name,note\n")
+	for sb.Len() < 300*1024 {
+		sb.WriteString("12345,some name,a longer note\n")
+	}
+	return []byte(sb.String())
+}
+
+// checkProgress verifies that reports increase monotonically and end at total.
+func checkProgress(t *testing.T, reads, totals []int64, wantTotal int64) {
+	t.Helper()
+	if len(reads) < 2 {
+		t.Fatalf("callback fired %d times, want several", len(reads))
+	}
+	for i := range reads {
+		if totals[i] != wantTotal {
+			t.Errorf("report %d: totalBytes = %d, want %d", i, totals[i], wantTotal)
+		}
+		if i > 0 && reads[i] <= reads[i-1] {
+			t.Errorf("report %d: bytesRead = %d, not above previous %d", i, reads[i], reads[i-1])
+		}
+	}
+	if last := reads[len(reads)-1]; last != wantTotal {
+		t.Errorf("final bytesRead = %d, want %d", last, wantTotal)
+	}
+}
+
+func TestParseReaderWithOptions_ProgressCallback(t *testing.T) {
+	data := progressInput()
+	var reads, totals []int64
+
+	opts := csv.DefaultReaderOptions()
+	opts.ProgressCallback = func(bytesRead, totalBytes int64) {
+		reads = append(reads, bytesRead)
+		totals = append(totals, totalBytes)
+	}
+	if _, err := csv.ParseReaderWithOptions(bytes.NewReader(data), opts); err != nil {
+		t.Fatalf("ParseReaderWithOptions() error = %v", err)
+	}
+	checkProgress(t, reads, totals, int64(len(data)))
+}
+
+func TestScannerProgressCallback(t *testing.T) {
+	data := progressInput()
+	var reads, totals []int64
+
+	scanner := csv.NewScanner(bytes.NewReader(data)).SetHasHeaders(true).
+		SetProgressCallback(func(bytesRead, totalBytes int64) {
+			reads = append(reads, bytesRead)
+			totals = append(totals, totalBytes)
+		})
+	for scanner.Scan() {
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	checkProgress(t, reads, totals, int64(len(data)))
+}
+
+func TestProgressCallbackUnknownSize(t *testing.T) {
+	data := progressInput()
+	var reads, totals []int64
+
+	// A reader of unknown size reports a total of -1
+	r := io.MultiReader(bytes.NewReader(data))
+	scanner := csv.NewScanner(r).SetProgressCallback(func(bytesRead, totalBytes int64) {
+		reads = append(reads, bytesRead)
+		totals = append(totals, totalBytes)
+	})
+	for scanner.Scan() {
+	}
+	if len(reads) == 0 || reads[len(reads)-1] != int64(len(data)) {
+		t.Fatalf("reads = %v, want final report of %d", reads, len(data))
+	}
+	for i, total := range totals {
+		if total != -1 {
+			t.Errorf("report %d: totalBytes = %d, want -1", i, total)
+		}
+	}
+}
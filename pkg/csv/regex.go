@@ -0,0 +1,92 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxRegexLineSize caps the length of a single line handed to the field
+// separator expression in ParseRegex.
+const maxRegexLineSize = 1 << 20 // 1MB
+
+// ParseRegex parses data line by line, splitting each line into fields at every
+// match of fieldSep. It is intended for irregular formats such as log files where
+// fields are separated by runs of mixed whitespace rather than a single delimiter.
+//
+// Quoting is disabled in this mode: quote characters are ordinary field content
+// and records cannot span lines. The Comma and LazyQuotes options are ignored;
+// Comment, FieldsPerRecord, TrimLeadingSpace, and TrimTrailingSpace are honored. Empty lines are
+// skipped and a trailing \r is removed from each line.
+//
+// fieldSep must not match the empty string, since that would split a line between
+// every character. Go's regexp package runs in linear time, but each line is also
+// limited to 1MB to bound the work done per record.
+//
+// Example:
+//
+//	sep := regexp.MustCompile(`[ \t]+`)
+//	records, err := csv.ParseRegex([]byte("a  b\tc\n1 2   3"), sep, csv.DefaultReaderOptions())
+//	// records: [["a" "b" "c"] ["1" "2" "3"]]
+func ParseRegex(data []byte, fieldSep *regexp.Regexp, opts ReaderOptions) ([][]string, error) {
+	if fieldSep == nil {
+		return nil, errors.New("csv: ParseRegex requires a field separator expression")
+	}
+	if fieldSep.MatchString("") {
+		return nil, fmt.Errorf("csv: field separator %q matches the empty string", fieldSep.String())
+	}
+
+	records := make([][]string, 0, 16)
+	expectedFields := opts.FieldsPerRecord
+	lineNum := 0
+
+	for len(data) > 0 {
+		lineNum++
+		var line []byte
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			line, data = data, nil
+		}
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+
+		if len(line) == 0 {
+			continue
+		}
+		if len(line) > maxRegexLineSize {
+			return nil, fmt.Errorf("csv: line %d exceeds maximum size (%d > %d)", lineNum, len(line), maxRegexLineSize)
+		}
+
+		text := string(line)
+		if opts.Comment != 0 && strings.HasPrefix(text, string(opts.Comment)) {
+			continue
+		}
+
+		fields := fieldSep.Split(text, -1)
+		if opts.TrimLeadingSpace {
+			for i, f := range fields {
+				fields[i] = strings.TrimLeft(f, " \t")
+			}
+		}
+		if opts.TrimTrailingSpace {
+			for i, f := range fields {
+				fields[i] = strings.TrimRight(f, " \t")
+			}
+		}
+
+		if opts.FieldsPerRecord >= 0 {
+			if len(records) == 0 && opts.FieldsPerRecord == 0 {
+				expectedFields = len(fields)
+			} else if expectedFields > 0 && len(fields) != expectedFields {
+				return nil, fmt.Errorf("csv: record on line %d: wrong number of fields (got %d, expected %d)",
+					lineNum, len(fields), expectedFields)
+			}
+		}
+
+		records = append(records, fields)
+	}
+
+	return records, nil
+}
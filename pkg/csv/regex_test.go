@@ -0,0 +1,95 @@
+package csv_test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestParseRegex(t *testing.T) {
+	sep := regexp.MustCompile(`[ \t]+`)
+
+	tests := []struct {
+		name  string
+		input string
+		opts  func(*csv.ReaderOptions)
+		want  [][]string
+	}{
+		{
+			name:  "mixed whitespace runs",
+			input: "GET  /index.html\t200\nPOST \t /api   201\n",
+			want: [][]string{
+				{"GET", "/index.html", "200"},
+				{"POST", "/api", "201"},
+			},
+		},
+		{
+			name:  "quotes are literal",
+			input: `say "hello world"`,
+			want:  [][]string{{"say", `"hello`, `world"`}},
+		},
+		{
+			name:  "CRLF and blank lines",
+			input: "a b\r\n\r\nc d\r\n",
+			want:  [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:  "comment lines",
+			input: "# header comment\na b\n",
+			opts:  func(o *csv.ReaderOptions) { o.Comment = '#' },
+			want:  [][]string{{"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+			got, err := csv.ParseRegex([]byte(tt.input), sep, opts)
+			if err != nil {
+				t.Fatalf("ParseRegex() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRegex() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRegex_Errors(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+
+	t.Run("empty match", func(t *testing.T) {
+		_, err := csv.ParseRegex([]byte("a,b"), regexp.MustCompile(`,*`), opts)
+		if err == nil || !strings.Contains(err.Error(), "empty string") {
+			t.Errorf("ParseRegex() error = %v, want empty-match error", err)
+		}
+	})
+
+	t.Run("nil separator", func(t *testing.T) {
+		if _, err := csv.ParseRegex([]byte("a b"), nil, opts); err == nil {
+			t.Error("ParseRegex() should reject a nil separator")
+		}
+	})
+
+	t.Run("field count", func(t *testing.T) {
+		strict := opts
+		strict.FieldsPerRecord = 0
+		_, err := csv.ParseRegex([]byte("a b\nc d e"), regexp.MustCompile(` +`), strict)
+		if err == nil || !strings.Contains(err.Error(), "wrong number of fields") {
+			t.Errorf("ParseRegex() error = %v, want field count error", err)
+		}
+	})
+
+	t.Run("line too long", func(t *testing.T) {
+		line := strings.Repeat("a", 1<<20+1)
+		if _, err := csv.ParseRegex([]byte(line), regexp.MustCompile(` +`), opts); err == nil {
+			t.Error("ParseRegex() should reject an oversized line")
+		}
+	})
+}
@@ -127,25 +127,38 @@ func writeCSVField(buf *bytes.Buffer, value string) {
 
 // writeCSVFieldWithDelim writes a CSV field with a custom delimiter.
 func writeCSVFieldWithDelim(buf *bytes.Buffer, value string, delim rune) {
-	// Check if field needs quoting (contains delimiter, quotes, newlines, or carriage returns)
-	needsQuoting := strings.ContainsRune(value, delim) || strings.ContainsAny(value, "\"\n\r")
+	writeCSVFieldWithQuote(buf, value, delim, '"')
+}
+
+// writeCSVFieldWithQuote writes a CSV field with a custom delimiter and quote character.
+func writeCSVFieldWithQuote(buf *bytes.Buffer, value string, delim, quote rune) {
+	// Check if field needs quoting (contains delimiter, quotes, newlines, or carriage returns).
+	// A quote anywhere in the field forces quoting, not just at its edges, so
+	// that a value like a"b is written as "a""b" rather than left unparseable.
+	needsQuoting := strings.ContainsRune(value, delim) || strings.ContainsRune(value, quote) ||
+		strings.ContainsAny(value, "\n\r")
 
 	if needsQuoting {
-		buf.WriteByte('"')
-		// Escape quotes by doubling them
-		for _, ch := range value {
-			if ch == '"' {
-				buf.WriteString(`""`)
-			} else {
-				buf.WriteRune(ch)
-			}
-		}
-		buf.WriteByte('"')
+		writeQuotedField(buf, value, quote)
 	} else {
 		buf.WriteString(value)
 	}
 }
 
+// writeQuotedField writes value as a field quoted with quote, doubling any
+// embedded quote characters.
+func writeQuotedField(buf *bytes.Buffer, value string, quote rune) {
+	buf.WriteRune(quote)
+	// Escape quotes by doubling them
+	for _, ch := range value {
+		if ch == quote {
+			buf.WriteRune(quote)
+		}
+		buf.WriteRune(ch)
+	}
+	buf.WriteRune(quote)
+}
+
 // renderWithOptions converts an AST node to CSV bytes with custom options.
 func renderWithOptions(node ast.SchemaNode, opts WriterOptions) ([]byte, error) {
 	if node == nil {
@@ -153,36 +166,58 @@ func renderWithOptions(node ast.SchemaNode, opts WriterOptions) ([]byte, error)
 	}
 
 	var buf bytes.Buffer
+
+	// Forced quoting needs the header row and framing wraps whole records, so
+	// render record by record
+	if len(opts.ForceQuoteColumns) > 0 || opts.RecordPrefix != "" || opts.RecordSuffix != "" {
+		if opts.Comma == 0 {
+			opts.Comma = ','
+		}
+		records := NodeToRecords(node)
+		var header []string
+		if len(records) > 0 {
+			header = records[0]
+		}
+		force, err := forceQuoteMask(header, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			writeRecordWithOptions(&buf, record, opts, force)
+		}
+		return buf.Bytes(), nil
+	}
+
 	lineEnding := "\n"
 	if opts.UseCRLF {
 		lineEnding = "\r\n"
 	}
 
-	if err := renderNodeWithOptions(node, &buf, opts.Comma, lineEnding); err != nil {
+	if err := renderNodeWithOptions(node, &buf, opts.Comma, opts.quoteChar(), lineEnding); err != nil {
 		return nil, err
 	}
 
 	return buf.Bytes(), nil
 }
 
-// renderNodeWithOptions recursively renders an AST node with custom delimiter and line ending.
-func renderNodeWithOptions(node ast.SchemaNode, buf *bytes.Buffer, delim rune, lineEnding string) error {
+// renderNodeWithOptions recursively renders an AST node with custom delimiter, quote and line ending.
+func renderNodeWithOptions(node ast.SchemaNode, buf *bytes.Buffer, delim, quote rune, lineEnding string) error {
 	if node == nil {
 		return nil
 	}
 
 	switch n := node.(type) {
 	case *ast.ArrayDataNode:
-		return renderArrayDataWithOptions(n, buf, delim, lineEnding)
+		return renderArrayDataWithOptions(n, buf, delim, quote, lineEnding)
 	case *ast.LiteralNode:
-		return renderLiteralWithDelim(n, buf, delim)
+		return renderLiteralWithDelim(n, buf, delim, quote)
 	default:
 		return fmt.Errorf("unsupported node type for CSV rendering: %T", node)
 	}
 }
 
-// renderArrayDataWithOptions renders an ArrayDataNode with custom delimiter and line ending.
-func renderArrayDataWithOptions(node *ast.ArrayDataNode, buf *bytes.Buffer, delim rune, lineEnding string) error {
+// renderArrayDataWithOptions renders an ArrayDataNode with custom delimiter, quote and line ending.
+func renderArrayDataWithOptions(node *ast.ArrayDataNode, buf *bytes.Buffer, delim, quote rune, lineEnding string) error {
 	elements := node.Elements()
 	if len(elements) == 0 {
 		return nil
@@ -196,7 +231,7 @@ func renderArrayDataWithOptions(node *ast.ArrayDataNode, buf *bytes.Buffer, deli
 			if i > 0 {
 				buf.WriteString(lineEnding)
 			}
-			if err := renderNodeWithOptions(elem, buf, delim, lineEnding); err != nil {
+			if err := renderNodeWithOptions(elem, buf, delim, quote, lineEnding); err != nil {
 				return err
 			}
 		}
@@ -210,7 +245,7 @@ func renderArrayDataWithOptions(node *ast.ArrayDataNode, buf *bytes.Buffer, deli
 			if i > 0 {
 				buf.WriteRune(delim)
 			}
-			if err := renderNodeWithOptions(elem, buf, delim, lineEnding); err != nil {
+			if err := renderNodeWithOptions(elem, buf, delim, quote, lineEnding); err != nil {
 				return err
 			}
 		}
@@ -221,8 +256,8 @@ func renderArrayDataWithOptions(node *ast.ArrayDataNode, buf *bytes.Buffer, deli
 	}
 }
 
-// renderLiteralWithDelim renders a LiteralNode with a custom delimiter.
-func renderLiteralWithDelim(node *ast.LiteralNode, buf *bytes.Buffer, delim rune) error {
+// renderLiteralWithDelim renders a LiteralNode with a custom delimiter and quote character.
+func renderLiteralWithDelim(node *ast.LiteralNode, buf *bytes.Buffer, delim, quote rune) error {
 	value := node.Value()
 
 	// CSV fields are strings
@@ -236,6 +271,6 @@ func renderLiteralWithDelim(node *ast.LiteralNode, buf *bytes.Buffer, delim rune
 	}
 
 	// Write field with proper escaping
-	writeCSVFieldWithDelim(buf, fieldValue, delim)
+	writeCSVFieldWithQuote(buf, fieldValue, delim, quote)
 	return nil
 }
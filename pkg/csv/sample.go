@@ -0,0 +1,82 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+
+	"github.com/shapestone/shape-csv/internal/parser"
+)
+
+// Sample returns up to n data records chosen uniformly at random from data,
+// using reservoir sampling over a single pass so that only the sample is held
+// in memory rather than every parsed record. The chosen records keep their
+// order in the input. With opts.HasHeader, the header row is returned first
+// and is never sampled. The same seed always gives the same sample, and if
+// data has n records or fewer, all of them are returned.
+//
+// Records are read as by ParseWithOptions, except that FieldsPerRecord,
+// MaxRecords and OnBadLine are not applied.
+//
+// Example:
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.HasHeader = true
+//	preview, err := csv.Sample(data, 100, time.Now().UnixNano(), opts)
+func Sample(data []byte, n int, seed int64, opts ReaderOptions) ([][]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("csv: Sample: negative sample size %d", n)
+	}
+
+	input, opts := prepareInput(string(data), opts)
+	p := parser.NewParserWithOptions(input, opts.parserOptions())
+
+	var header []string
+	if opts.HasHeader {
+		record, err := p.NextRecord()
+		if errors.Is(err, io.EOF) {
+			return [][]string{}, nil
+		}
+		if err != nil {
+			return nil, toParseError(err)
+		}
+		header = record
+	}
+
+	type sampled struct {
+		index  int
+		fields []string
+	}
+	rng := rand.New(rand.NewSource(seed))
+	reservoir := make([]sampled, 0, n)
+	for seen := 0; ; seen++ {
+		record, err := p.NextRecord()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, toParseError(err)
+		}
+
+		// Keep the first n records, then replace with decreasing probability
+		if seen < n {
+			reservoir = append(reservoir, sampled{index: seen, fields: record})
+		} else if j := rng.Intn(seen + 1); j < n {
+			reservoir[j] = sampled{index: seen, fields: record}
+		}
+	}
+
+	sort.Slice(reservoir, func(i, j int) bool {
+		return reservoir[i].index < reservoir[j].index
+	})
+	result := make([][]string, 0, len(reservoir)+1)
+	if header != nil {
+		result = append(result, header)
+	}
+	for _, s := range reservoir {
+		result = append(result, s.fields)
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package csv_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestSample(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("id,value\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&sb, "%d,v%d\n", i, i)
+	}
+	data := []byte(sb.String())
+
+	opts := csv.DefaultReaderOptions()
+	opts.HasHeader = true
+
+	t.Run("deterministic with fixed seed", func(t *testing.T) {
+		first, err := csv.Sample(data, 10, 42, opts)
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		second, err := csv.Sample(data, 10, 42, opts)
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("Sample() with the same seed differs:\n%q\n%q", first, second)
+		}
+
+		if len(first) != 11 || !reflect.DeepEqual(first[0], []string{"id", "value"}) {
+			t.Fatalf("Sample() = %q, want header plus 10 records", first)
+		}
+		prev := -1
+		for _, record := range first[1:] {
+			var id int
+			fmt.Sscan(record[0], &id)
+			if id <= prev || record[1] != "v"+record[0] {
+				t.Errorf("Sample() record %q is out of order or malformed", record)
+			}
+			prev = id
+		}
+
+		other, _ := csv.Sample(data, 10, 7, opts)
+		if reflect.DeepEqual(first, other) {
+			t.Error("Sample() with a different seed returned the same records")
+		}
+	})
+
+	t.Run("n larger than record count", func(t *testing.T) {
+		got, err := csv.Sample([]byte("id\n1\n2\n3\n"), 10, 1, opts)
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		want := [][]string{{"id"}, {"1"}, {"2"}, {"3"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Sample() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		got, err := csv.Sample([]byte("a\nb\n"), 5, 1, csv.DefaultReaderOptions())
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		if want := [][]string{{"a"}, {"b"}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Sample() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := csv.Sample(data, -1, 1, opts); err == nil {
+			t.Error("Sample() expected error for negative n")
+		}
+		if _, err := csv.Sample([]byte("id\n\"open\n"), 5, 1, opts); err == nil {
+			t.Error("Sample() expected error for unclosed quote")
+		}
+	})
+}
@@ -2,9 +2,13 @@
 package csv
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ColumnType represents the expected type of a column.
@@ -31,6 +35,9 @@ type ColumnDefinition struct {
 	Required bool
 	// Default is the default value for empty fields.
 	Default string
+	// TrimSpace removes leading and trailing whitespace from values before
+	// they are validated, and from the data returned by Schema.Coerce.
+	TrimSpace bool
 	// Validator is an optional custom validation function.
 	Validator func(value string) error
 	// AllowedValues restricts values to a specific set.
@@ -39,6 +46,21 @@ type ColumnDefinition struct {
 	MinLength int
 	// MaxLength is the maximum string length (0 = no maximum).
 	MaxLength int
+	// MinValue is the inclusive minimum for ColumnTypeInt and ColumnTypeFloat
+	// values (nil = no minimum).
+	MinValue *float64
+	// MaxValue is the inclusive maximum for ColumnTypeInt and ColumnTypeFloat
+	// values (nil = no maximum).
+	MaxValue *float64
+	// Unique requires every non-empty value in the column to be distinct.
+	Unique bool
+	// Pattern is a regular expression that every non-empty value must match
+	// ("" = no pattern). Anchor it with ^ and $ to match the whole value.
+	Pattern string
+	// DateFormat is the time.Parse layout for ColumnTypeDate, ColumnTypeTime
+	// and ColumnTypeDateTime values, such as "02/01/2006" for European dates
+	// ("" = the default layouts of the date, time and datetime converters).
+	DateFormat string
 }
 
 // Schema defines the expected structure of CSV data.
@@ -51,6 +73,18 @@ type Schema struct {
 	AllowMissingColumns bool
 	// HeaderRequired indicates if CSV must have a header row.
 	HeaderRequired bool
+	// RowValidators are run on every data row after the per-column checks,
+	// for rules spanning several columns (e.g. end_date >= start_date).
+	// An error is recorded against the row with no column.
+	RowValidators []func(record []string, headers []string) error
+	// RowMapValidators are like RowValidators but receive the row's raw
+	// values keyed by header name, with "" for cells missing from a short
+	// row. They run after RowValidators.
+	RowMapValidators []func(row map[string]string) error
+	// Format maps column names to output formats used by Schema.Marshal and
+	// FormatRecord: a fmt verb such as "%.2f" for numbers, or a time layout
+	// such as "2006-01-02" for time.Time values.
+	Format map[string]string
 }
 
 // NewSchema creates a new empty schema.
@@ -86,9 +120,19 @@ func (s *Schema) AddRequiredColumn(name string, colType ColumnType) *Schema {
 	})
 }
 
+// Row values of a ValidationError that does not refer to a data row.
+const (
+	// HeaderRow marks an error in the header row.
+	HeaderRow = -1
+	// SchemaRow marks an error in the schema itself, such as a malformed
+	// Pattern.
+	SchemaRow = -2
+)
+
 // ValidationError represents a schema validation error.
 type ValidationError struct {
-	// Row is the row number (0-indexed, -1 for header).
+	// Row is the row number (0-indexed), HeaderRow for the header, or
+	// SchemaRow for an invalid schema.
 	Row int
 	// Column is the column name or index.
 	Column string
@@ -100,7 +144,10 @@ type ValidationError struct {
 
 // Error implements the error interface.
 func (e *ValidationError) Error() string {
-	if e.Row < 0 {
+	if e.Row == SchemaRow {
+		return fmt.Sprintf("schema error for column %q: %s", e.Column, e.Message)
+	}
+	if e.Row == HeaderRow {
 		return fmt.Sprintf("header validation error for column %q: %s", e.Column, e.Message)
 	}
 	return fmt.Sprintf("row %d, column %q: %s (value: %q)", e.Row, e.Column, e.Message, e.Value)
@@ -142,31 +189,67 @@ func (r *ValidationResult) AllErrors() string {
 
 // ValidateSchema validates CSV data against a schema.
 // data should be a slice of records ([][]string) where each record is a row of fields.
+// If a column Pattern does not compile, the result reports it with Row
+// SchemaRow and the data is not validated.
 func ValidateSchema(data [][]string, schema *Schema) *ValidationResult {
 	result := &ValidationResult{Valid: true}
 
+	patterns := schema.compilePatterns(result)
+	if !result.Valid {
+		return result
+	}
+
 	if len(data) == 0 {
 		if schema.HeaderRequired {
 			result.AddError(ValidationError{
-				Row:     -1,
+				Row:     HeaderRow,
 				Message: "CSV data is empty, header required",
 			})
 		}
 		return result
 	}
 
+	checker := newRowChecker(schema, patterns, data[0], result)
+	for rowIdx := 1; rowIdx < len(data); rowIdx++ {
+		checker.check(result, rowIdx, data[rowIdx])
+	}
+
+	return result
+}
+
+// rowChecker validates data rows against a schema once the header is known,
+// carrying the state that spans rows, such as the values seen in unique
+// columns.
+type rowChecker struct {
+	schema      *Schema
+	patterns    []*regexp.Regexp
+	header      []string
+	columnIndex map[string]int
+	seen        map[string]map[string]int // first row of each value in unique columns
+}
+
+// newRowChecker checks header against schema, recording any problems in
+// result, and returns a checker for the rows that follow it. patterns are the
+// compiled column patterns from compilePatterns.
+func newRowChecker(schema *Schema, patterns []*regexp.Regexp, header []string, result *ValidationResult) *rowChecker {
+	c := &rowChecker{
+		schema:      schema,
+		patterns:    patterns,
+		header:      header,
+		columnIndex: make(map[string]int),
+		seen:        make(map[string]map[string]int),
+	}
+
 	// Build column index map from header
-	header := data[0]
-	columnIndex := make(map[string]int)
 	for i, name := range header {
-		columnIndex[name] = i
+		c.columnIndex[name] = i
 	}
 
 	// Validate header has required columns
 	for _, col := range schema.Columns {
-		if _, exists := columnIndex[col.Name]; !exists && !schema.AllowMissingColumns {
+		if _, exists := c.columnIndex[col.Name]; !exists && !schema.AllowMissingColumns {
 			result.AddError(ValidationError{
-				Row:     -1,
+				Row:     HeaderRow,
 				Column:  col.Name,
 				Message: "required column not found in header",
 			})
@@ -182,7 +265,7 @@ func ValidateSchema(data [][]string, schema *Schema) *ValidationResult {
 		for _, name := range header {
 			if !schemaColumns[name] {
 				result.AddError(ValidationError{
-					Row:     -1,
+					Row:     HeaderRow,
 					Column:  name,
 					Message: "unexpected column not in schema",
 				})
@@ -190,110 +273,246 @@ func ValidateSchema(data [][]string, schema *Schema) *ValidationResult {
 		}
 	}
 
-	// Validate data rows
-	for rowIdx := 1; rowIdx < len(data); rowIdx++ {
-		row := data[rowIdx]
+	for _, col := range schema.Columns {
+		if col.Unique {
+			c.seen[col.Name] = make(map[string]int)
+		}
+	}
 
-		for _, col := range schema.Columns {
-			colIdx, exists := columnIndex[col.Name]
-			if !exists {
-				continue // Already reported as missing
-			}
+	return c
+}
 
-			var value string
-			if colIdx < len(row) {
-				value = row[colIdx]
-			}
+// check validates the data row numbered rowIdx, recording any failures in
+// result.
+func (c *rowChecker) check(result *ValidationResult, rowIdx int, row []string) {
+	for i, col := range c.schema.Columns {
+		colIdx, exists := c.columnIndex[col.Name]
+		if !exists {
+			continue // Already reported as missing
+		}
 
-			// Apply default for empty values
-			if value == "" && col.Default != "" {
-				value = col.Default
-			}
+		var value string
+		if colIdx < len(row) {
+			value = row[colIdx]
+		}
+
+		value = validateField(result, rowIdx, col, c.patterns[i], value)
 
-			// Required validation
-			if col.Required && value == "" {
+		// Uniqueness validation
+		if col.Unique && value != "" {
+			if firstRow, dup := c.seen[col.Name][value]; dup {
 				result.AddError(ValidationError{
 					Row:     rowIdx,
 					Column:  col.Name,
 					Value:   value,
-					Message: "required field is empty",
+					Message: fmt.Sprintf("duplicate value in unique column (rows %d and %d)", firstRow, rowIdx),
 				})
-				continue
+			} else {
+				c.seen[col.Name][value] = rowIdx
 			}
+		}
+	}
 
-			// Skip further validation for empty optional fields
-			if value == "" {
-				continue
-			}
+	// Cross-column validation
+	for _, validate := range c.schema.RowValidators {
+		if err := validate(row, c.header); err != nil {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Message: err.Error(),
+			})
+		}
+	}
 
-			// Type validation
-			if err := validateType(value, col.Type); err != nil {
-				result.AddError(ValidationError{
-					Row:     rowIdx,
-					Column:  col.Name,
-					Value:   value,
-					Message: err.Error(),
-				})
-			}
+	if len(c.schema.RowMapValidators) == 0 {
+		return
+	}
+	values := make(map[string]string, len(c.header))
+	for i, name := range c.header {
+		if i < len(row) {
+			values[name] = row[i]
+		} else {
+			values[name] = ""
+		}
+	}
+	for _, validate := range c.schema.RowMapValidators {
+		if err := validate(values); err != nil {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Message: err.Error(),
+			})
+		}
+	}
+}
 
-			// Allowed values validation
-			if len(col.AllowedValues) > 0 {
-				found := false
-				for _, allowed := range col.AllowedValues {
-					if value == allowed {
-						found = true
-						break
-					}
-				}
-				if !found {
-					result.AddError(ValidationError{
-						Row:     rowIdx,
-						Column:  col.Name,
-						Value:   value,
-						Message: fmt.Sprintf("value not in allowed set: %v", col.AllowedValues),
-					})
-				}
-			}
+// compilePatterns compiles the Pattern of each column, indexed like Columns,
+// with nil for columns without one. Invalid patterns are recorded in result.
+func (s *Schema) compilePatterns(result *ValidationResult) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(s.Columns))
+	for i, col := range s.Columns {
+		if col.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(col.Pattern)
+		if err != nil {
+			result.AddError(ValidationError{
+				Row:     SchemaRow,
+				Column:  col.Name,
+				Value:   col.Pattern,
+				Message: fmt.Sprintf("invalid pattern: %v", err),
+			})
+			continue
+		}
+		patterns[i] = re
+	}
+	return patterns
+}
 
-			// Length validation
-			if col.MinLength > 0 && len(value) < col.MinLength {
-				result.AddError(ValidationError{
-					Row:     rowIdx,
-					Column:  col.Name,
-					Value:   value,
-					Message: fmt.Sprintf("value length %d is less than minimum %d", len(value), col.MinLength),
-				})
-			}
-			if col.MaxLength > 0 && len(value) > col.MaxLength {
-				result.AddError(ValidationError{
-					Row:     rowIdx,
-					Column:  col.Name,
-					Value:   value,
-					Message: fmt.Sprintf("value length %d exceeds maximum %d", len(value), col.MaxLength),
-				})
-			}
+// validateField runs the per-field schema checks for a single value and records
+// any failures in result. pattern is the compiled col.Pattern, or nil. It
+// returns the value after default substitution.
+func validateField(result *ValidationResult, rowIdx int, col ColumnDefinition, pattern *regexp.Regexp, value string) string {
+	if col.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+
+	// Apply default for empty values
+	if value == "" && col.Default != "" {
+		value = col.Default
+	}
+
+	// Required validation
+	if col.Required && value == "" {
+		result.AddError(ValidationError{
+			Row:     rowIdx,
+			Column:  col.Name,
+			Value:   value,
+			Message: "required field is empty",
+		})
+		return value
+	}
+
+	// Skip further validation for empty optional fields
+	if value == "" {
+		return value
+	}
+
+	// Type validation
+	coerced, err := coerceValue(value, col.Type, col.DateFormat)
+	if err != nil {
+		result.AddError(ValidationError{
+			Row:     rowIdx,
+			Column:  col.Name,
+			Value:   value,
+			Message: err.Error(),
+		})
+	}
+
+	// Range validation for numeric columns
+	if err == nil && (col.MinValue != nil || col.MaxValue != nil) {
+		n, numeric := 0.0, true
+		switch v := coerced.(type) {
+		case int64:
+			n = float64(v)
+		case float64:
+			n = v
+		default:
+			numeric = false
+		}
+		if numeric && col.MinValue != nil && n < *col.MinValue {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Column:  col.Name,
+				Value:   value,
+				Message: fmt.Sprintf("value %s is less than minimum %g", value, *col.MinValue),
+			})
+		}
+		if numeric && col.MaxValue != nil && n > *col.MaxValue {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Column:  col.Name,
+				Value:   value,
+				Message: fmt.Sprintf("value %s exceeds maximum %g", value, *col.MaxValue),
+			})
+		}
+	}
 
-			// Custom validator
-			if col.Validator != nil {
-				if err := col.Validator(value); err != nil {
-					result.AddError(ValidationError{
-						Row:     rowIdx,
-						Column:  col.Name,
-						Value:   value,
-						Message: err.Error(),
-					})
-				}
+	// Allowed values validation
+	if len(col.AllowedValues) > 0 {
+		found := false
+		for _, allowed := range col.AllowedValues {
+			if value == allowed {
+				found = true
+				break
 			}
 		}
+		if !found {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Column:  col.Name,
+				Value:   value,
+				Message: fmt.Sprintf("value not in allowed set: %v", col.AllowedValues),
+			})
+		}
 	}
 
-	return result
+	// Length validation
+	if col.MinLength > 0 && len(value) < col.MinLength {
+		result.AddError(ValidationError{
+			Row:     rowIdx,
+			Column:  col.Name,
+			Value:   value,
+			Message: fmt.Sprintf("value length %d is less than minimum %d", len(value), col.MinLength),
+		})
+	}
+	if col.MaxLength > 0 && len(value) > col.MaxLength {
+		result.AddError(ValidationError{
+			Row:     rowIdx,
+			Column:  col.Name,
+			Value:   value,
+			Message: fmt.Sprintf("value length %d exceeds maximum %d", len(value), col.MaxLength),
+		})
+	}
+
+	// Pattern validation
+	if pattern != nil && !pattern.MatchString(value) {
+		result.AddError(ValidationError{
+			Row:     rowIdx,
+			Column:  col.Name,
+			Value:   value,
+			Message: fmt.Sprintf("value does not match pattern %q", col.Pattern),
+		})
+	}
+
+	// Custom validator
+	if col.Validator != nil {
+		if err := col.Validator(value); err != nil {
+			result.AddError(ValidationError{
+				Row:     rowIdx,
+				Column:  col.Name,
+				Value:   value,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return value
 }
 
-// validateType checks if a value matches the expected type.
-func validateType(value string, colType ColumnType) error {
+// coerceValue converts a non-empty value to the Go type for colType:
+// int64, float64, bool, time.Time, or string for ColumnTypeString and ColumnTypeAny.
+// If layout is not empty, date and time values are parsed with it instead of
+// the converters' default layouts.
+func coerceValue(value string, colType ColumnType, layout string) (interface{}, error) {
 	if colType == ColumnTypeAny || colType == ColumnTypeString {
-		return nil
+		return value, nil
+	}
+
+	if layout != "" && (colType == ColumnTypeDate || colType == ColumnTypeTime || colType == ColumnTypeDateTime) {
+		v, err := DateConverter{Format: layout}.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s (expected layout %s)", colType, value, layout)
+		}
+		return v, nil
 	}
 
 	registry := NewConverterRegistry()
@@ -301,37 +520,257 @@ func validateType(value string, colType ColumnType) error {
 	switch colType {
 	case ColumnTypeInt:
 		conv, _ := registry.Get("int")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid integer: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer: %s", value)
 		}
+		return v, nil
 	case ColumnTypeFloat:
 		conv, _ := registry.Get("float")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid float: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float: %s", value)
 		}
+		return v, nil
 	case ColumnTypeBool:
 		conv, _ := registry.Get("bool")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid boolean: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean: %s", value)
 		}
+		return v, nil
 	case ColumnTypeDate:
 		conv, _ := registry.Get("date")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid date: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %s", value)
 		}
+		return v, nil
 	case ColumnTypeTime:
 		conv, _ := registry.Get("time")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid time: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time: %s", value)
 		}
+		return v, nil
 	case ColumnTypeDateTime:
 		conv, _ := registry.Get("datetime")
-		if _, err := conv.Convert(value); err != nil {
-			return fmt.Errorf("invalid datetime: %s", value)
+		v, err := conv.Convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid datetime: %s", value)
+		}
+		return v, nil
+	}
+
+	return value, nil
+}
+
+// CoerceRecord validates a single data record against the schema and converts
+// each field to its column's Go type. This is the per-record counterpart of
+// ValidateSchema, intended for streaming loops that cannot buffer the whole file.
+//
+// fields are matched to Columns by position. Each returned value is one of
+// int64, float64, bool, time.Time, or string depending on the column type;
+// empty optional fields and fields that fail validation are returned as nil.
+// Errors reference the column name; Row is left as 0 since the record's
+// position in the file is known only to the caller.
+//
+// Example:
+//
+//	for scanner.Scan() {
+//	    values, result := schema.CoerceRecord(scanner.Record().Fields())
+//	    if !result.Valid {
+//	        // handle result.Errors
+//	    }
+//	    age := values[1].(int64)
+//	}
+func (s *Schema) CoerceRecord(fields []string) ([]interface{}, *ValidationResult) {
+	result := &ValidationResult{Valid: true}
+	values := make([]interface{}, len(s.Columns))
+
+	patterns := s.compilePatterns(result)
+	if !result.Valid {
+		return values, result
+	}
+
+	for i, col := range s.Columns {
+		var value string
+		if i < len(fields) {
+			value = fields[i]
 		}
+
+		errCount := len(result.Errors)
+		value = validateField(result, 0, col, patterns[i], value)
+		if len(result.Errors) > errCount || value == "" {
+			continue
+		}
+
+		values[i], _ = coerceValue(value, col.Type, col.DateFormat)
 	}
 
-	return nil
+	return values, result
+}
+
+// Coerce returns a cleaned copy of data, whose first record is the header,
+// along with the validation result for the cleaned data. In each schema
+// column, values are trimmed when TrimSpace is set, empty cells take the
+// column Default, and valid bool, int and float values are rewritten in
+// canonical form: "T", "yes" and "1" become "true", "+042" becomes "42", and
+// "1.50" becomes "1.5". Values that fail validation are left as they are and
+// reported in the result. data itself is not modified.
+//
+// Example:
+//
+//	cleaned, result := schema.Coerce(records)
+//	if !result.Valid {
+//	    fmt.Println(result.AllErrors())
+//	}
+func (s *Schema) Coerce(data [][]string) ([][]string, *ValidationResult) {
+	out := make([][]string, len(data))
+	for i, row := range data {
+		out[i] = append([]string(nil), row...)
+	}
+	if len(out) == 0 {
+		return out, ValidateSchema(out, s)
+	}
+
+	columnIndex := make(map[string]int)
+	for i, name := range out[0] {
+		columnIndex[name] = i
+	}
+	for _, col := range s.Columns {
+		colIdx, exists := columnIndex[col.Name]
+		if !exists {
+			continue
+		}
+		for _, row := range out[1:] {
+			if colIdx < len(row) {
+				row[colIdx] = coerceString(row[colIdx], col)
+			}
+		}
+	}
+
+	return out, ValidateSchema(out, s)
+}
+
+// coerceString cleans a single value for Coerce, leaving values that do not
+// convert to col's type unchanged apart from trimming and defaults.
+func coerceString(value string, col ColumnDefinition) string {
+	if col.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		return col.Default
+	}
+
+	coerced, err := coerceValue(value, col.Type, col.DateFormat)
+	if err != nil {
+		return value
+	}
+	switch v := coerced.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return value
+}
+
+// FormatRecord converts typed values to CSV fields, applying the column formats
+// in s.Format. values are matched to Columns by position; nil values become
+// empty fields. An invalid format for a value's type returns an error.
+func (s *Schema) FormatRecord(values []interface{}) ([]string, error) {
+	fields := make([]string, len(values))
+	for i, v := range values {
+		name := ""
+		if i < len(s.Columns) {
+			name = s.Columns[i].Name
+		}
+		field, err := formatValue(v, s.Format[name])
+		if err != nil {
+			return nil, fmt.Errorf("csv: column %q: %w", name, err)
+		}
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// Marshal writes rows of typed values as CSV, preceded by a header row of the
+// column names unless opts.OmitHeader is set. Values are formatted per column
+// using s.Format.
+//
+// Example:
+//
+//	schema.Format = map[string]string{"amount": "%.2f", "date": "2006-01-02"}
+//	data, err := schema.Marshal(rows, csv.DefaultWriterOptions())
+func (s *Schema) Marshal(rows [][]interface{}, opts WriterOptions) ([]byte, error) {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	header := make([]string, len(s.Columns))
+	for i, col := range s.Columns {
+		header[i] = col.Name
+	}
+	force, err := forceQuoteMask(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if !opts.OmitHeader {
+		writeRecordWithOptions(&buf, header, opts, force)
+	}
+
+	for rowIdx, row := range rows {
+		fields, err := s.FormatRecord(row)
+		if err != nil {
+			return nil, fmt.Errorf("%w (row %d)", err, rowIdx+1)
+		}
+		writeRecordWithOptions(&buf, fields, opts, force)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatValue formats a single value using spec, which is empty, a fmt verb
+// (for numbers, bools and strings) or a time layout (for time.Time).
+func formatValue(v interface{}, spec string) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	if t, ok := v.(time.Time); ok {
+		if spec == "" {
+			return t.Format(time.RFC3339), nil
+		}
+		if strings.Contains(spec, "%") {
+			return "", fmt.Errorf("invalid time layout %q", spec)
+		}
+		return t.Format(spec), nil
+	}
+
+	if spec == "" {
+		switch val := v.(type) {
+		case string:
+			return val, nil
+		case float64:
+			return strconv.FormatFloat(val, 'g', -1, 64), nil
+		case float32:
+			return strconv.FormatFloat(float64(val), 'g', -1, 32), nil
+		}
+		return fmt.Sprint(v), nil
+	}
+
+	if !strings.Contains(spec, "%") {
+		return "", fmt.Errorf("invalid format %q for %T", spec, v)
+	}
+	out := fmt.Sprintf(spec, v)
+	if strings.Contains(out, "%!") {
+		return "", fmt.Errorf("invalid format %q for %T", spec, v)
+	}
+	return out, nil
 }
 
 // SchemaFromStruct creates a schema from a struct type using csv tags.
@@ -0,0 +1,144 @@
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// schemaJSON is the JSON form of a Schema.
+type schemaJSON struct {
+	Columns             []columnJSON      `json:"columns"`
+	AllowExtraColumns   bool              `json:"allowExtraColumns,omitempty"`
+	AllowMissingColumns bool              `json:"allowMissingColumns,omitempty"`
+	HeaderRequired      bool              `json:"headerRequired"`
+	Format              map[string]string `json:"format,omitempty"`
+}
+
+// columnJSON is the JSON form of a ColumnDefinition, without its Validator.
+type columnJSON struct {
+	Name          string     `json:"name"`
+	Type          ColumnType `json:"type,omitempty"`
+	Required      bool       `json:"required,omitempty"`
+	Default       string     `json:"default,omitempty"`
+	TrimSpace     bool       `json:"trimSpace,omitempty"`
+	AllowedValues []string   `json:"allowedValues,omitempty"`
+	MinLength     int        `json:"minLength,omitempty"`
+	MaxLength     int        `json:"maxLength,omitempty"`
+	MinValue      *float64   `json:"minValue,omitempty"`
+	MaxValue      *float64   `json:"maxValue,omitempty"`
+	Unique        bool       `json:"unique,omitempty"`
+	Pattern       string     `json:"pattern,omitempty"`
+	DateFormat    string     `json:"dateFormat,omitempty"`
+}
+
+// MarshalJSON encodes the schema as JSON so that its rules can be shared and
+// loaded again with SchemaFromJSON. Column types are written by name, such as
+// "int" or "datetime". Column Validator functions, RowValidators and
+// RowMapValidators cannot be encoded and are omitted.
+//
+// Example:
+//
+//	data, err := json.MarshalIndent(schema, "", "  ")
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	out := schemaJSON{
+		Columns:             make([]columnJSON, len(s.Columns)),
+		AllowExtraColumns:   s.AllowExtraColumns,
+		AllowMissingColumns: s.AllowMissingColumns,
+		HeaderRequired:      s.HeaderRequired,
+		Format:              s.Format,
+	}
+	for i, col := range s.Columns {
+		out.Columns[i] = columnJSON{
+			Name:          col.Name,
+			Type:          col.Type,
+			Required:      col.Required,
+			Default:       col.Default,
+			TrimSpace:     col.TrimSpace,
+			AllowedValues: col.AllowedValues,
+			MinLength:     col.MinLength,
+			MaxLength:     col.MaxLength,
+			MinValue:      col.MinValue,
+			MaxValue:      col.MaxValue,
+			Unique:        col.Unique,
+			Pattern:       col.Pattern,
+			DateFormat:    col.DateFormat,
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a schema written by MarshalJSON, replacing the
+// columns and options of s. It returns an error for an unknown column type
+// or a Pattern that does not compile. A missing headerRequired defaults to
+// true, as with NewSchema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	in := schemaJSON{HeaderRequired: true}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("csv: invalid schema JSON: %w", err)
+	}
+
+	columns := make([]ColumnDefinition, len(in.Columns))
+	for i, col := range in.Columns {
+		if !validColumnType(col.Type) {
+			return fmt.Errorf("csv: schema column %q has unknown type %q", col.Name, col.Type)
+		}
+		if col.Pattern != "" {
+			if _, err := regexp.Compile(col.Pattern); err != nil {
+				return fmt.Errorf("csv: schema column %q has invalid pattern: %w", col.Name, err)
+			}
+		}
+		columns[i] = ColumnDefinition{
+			Name:          col.Name,
+			Type:          col.Type,
+			Required:      col.Required,
+			Default:       col.Default,
+			TrimSpace:     col.TrimSpace,
+			AllowedValues: col.AllowedValues,
+			MinLength:     col.MinLength,
+			MaxLength:     col.MaxLength,
+			MinValue:      col.MinValue,
+			MaxValue:      col.MaxValue,
+			Unique:        col.Unique,
+			Pattern:       col.Pattern,
+			DateFormat:    col.DateFormat,
+		}
+	}
+
+	*s = Schema{
+		Columns:             columns,
+		AllowExtraColumns:   in.AllowExtraColumns,
+		AllowMissingColumns: in.AllowMissingColumns,
+		HeaderRequired:      in.HeaderRequired,
+		Format:              in.Format,
+	}
+	return nil
+}
+
+// SchemaFromJSON decodes a schema written by Schema.MarshalJSON.
+//
+// Example:
+//
+//	schema, err := csv.SchemaFromJSON(data)
+//	if err != nil {
+//	    return err
+//	}
+//	result := csv.ValidateSchema(records, schema)
+func SchemaFromJSON(data []byte) (*Schema, error) {
+	schema := NewSchema()
+	if err := schema.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// validColumnType reports whether t is one of the ColumnType constants. The
+// empty type is accepted and validates like ColumnTypeAny.
+func validColumnType(t ColumnType) bool {
+	switch t {
+	case "", ColumnTypeString, ColumnTypeInt, ColumnTypeFloat, ColumnTypeBool,
+		ColumnTypeDate, ColumnTypeTime, ColumnTypeDateTime, ColumnTypeAny:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package csv
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/shapestone/shape-core/pkg/tokenizer"
+	"github.com/shapestone/shape-csv/internal/bom"
+	"github.com/shapestone/shape-csv/internal/parser"
+)
+
+// ValidatingScanner reads CSV records one at a time and validates each
+// against a Schema as it streams, so files too large to hold in memory can be
+// checked. The first record is the header, as for ValidateSchema, and data
+// rows are numbered from 1.
+//
+// Example usage:
+//
+//	scanner := schema.NewValidatingScanner(file, csv.DefaultReaderOptions())
+//	for scanner.Scan() {
+//	    if err := scanner.RecordError(); err != nil {
+//	        log.Println(err)
+//	        continue
+//	    }
+//	    // process scanner.Record()
+//	}
+//	if err := scanner.Err(); err != nil {
+//	    // handle read or parse error
+//	}
+//	fmt.Println(len(scanner.Errors()), "validation errors")
+type ValidatingScanner struct {
+	schema  *Schema
+	reader  io.Reader
+	opts    ReaderOptions
+	p       *parser.Parser
+	checker *rowChecker
+	started bool
+	done    bool
+
+	headers []string
+	record  []string
+	row     int
+	current *ValidationResult // errors for the current record
+	result  ValidationResult  // errors for the whole input
+	err     error
+}
+
+// NewValidatingScanner returns a ValidatingScanner that reads CSV from r with
+// opts and validates it against s. RecordPrefix and RecordSuffix are not
+// applied. Memory use does not grow with the input, apart from the values
+// kept to check Unique columns and the accumulated errors.
+func (s *Schema) NewValidatingScanner(r io.Reader, opts ReaderOptions) *ValidatingScanner {
+	return &ValidatingScanner{
+		schema: s,
+		reader: r,
+		opts:   opts,
+		result: ValidationResult{Valid: true},
+	}
+}
+
+// Scan advances to the next data record and validates it. It returns false
+// at the end of the input, on a read or parse error reported by Err, or when
+// the schema itself is invalid or the input has no header, which are reported
+// by Errors. A record that fails validation still returns true; check
+// RecordError.
+func (v *ValidatingScanner) Scan() bool {
+	if v.done {
+		return false
+	}
+	if !v.started {
+		v.started = true
+		if !v.start() {
+			v.done = true
+			return false
+		}
+	}
+
+	record, err := v.p.NextRecord()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			v.err = toParseError(err)
+		}
+		v.done = true
+		v.record = nil
+		v.current = nil
+		return false
+	}
+
+	v.row++
+	v.record = record
+	v.current = &ValidationResult{Valid: true}
+	v.checker.check(v.current, v.row, record)
+	for _, verr := range v.current.Errors {
+		v.result.AddError(verr)
+	}
+	return true
+}
+
+// start compiles the schema patterns and reads and checks the header. It
+// reports whether scanning can continue.
+func (v *ValidatingScanner) start() bool {
+	patterns := v.schema.compilePatterns(&v.result)
+	if !v.result.Valid {
+		return false
+	}
+
+	reader := v.reader
+	if v.opts.AutoDetectDelimiter {
+		br := bufio.NewReaderSize(reader, detectSampleBytes)
+		sample, _ := br.Peek(detectSampleBytes)
+		if delim, err := DetectDelimiter(sample); err == nil {
+			v.opts.Comma = delim
+		}
+		reader = br
+	}
+	stream := tokenizer.NewStreamFromReader(bom.NewReader(reader))
+	v.p = parser.NewParserFromStreamWithOptions(stream, v.opts.parserOptions())
+
+	header, err := v.p.NextRecord()
+	if errors.Is(err, io.EOF) {
+		if v.schema.HeaderRequired {
+			v.result.AddError(ValidationError{
+				Row:     HeaderRow,
+				Message: "CSV data is empty, header required",
+			})
+		}
+		return false
+	}
+	if err != nil {
+		v.err = toParseError(err)
+		return false
+	}
+
+	v.headers = header
+	v.checker = newRowChecker(v.schema, patterns, header, &v.result)
+	return true
+}
+
+// Record returns the current data record, with access by header name.
+func (v *ValidatingScanner) Record() Record {
+	return Record{fields: v.record, headers: v.headers}
+}
+
+// Headers returns the header row, available after the first call to Scan.
+func (v *ValidatingScanner) Headers() []string {
+	return v.headers
+}
+
+// Row returns the number of the current data record, starting at 1.
+func (v *ValidatingScanner) Row() int {
+	return v.row
+}
+
+// RecordError returns the validation errors for the current record as a
+// *ValidationResult, or nil if the record is valid.
+func (v *ValidatingScanner) RecordError() error {
+	if v.current == nil || v.current.Valid {
+		return nil
+	}
+	return v.current
+}
+
+// Errors returns every validation error found so far, including header
+// errors (Row HeaderRow) and schema errors (Row SchemaRow).
+func (v *ValidatingScanner) Errors() []ValidationError {
+	return v.result.Errors
+}
+
+// Err returns the read or parse error, if any, that stopped Scan. Validation
+// failures are reported by Errors and RecordError instead.
+func (v *ValidatingScanner) Err() error {
+	return v.err
+}
@@ -1,8 +1,11 @@
 package csv_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -215,6 +218,139 @@ func TestValidateSchema(t *testing.T) {
 		}
 	})
 
+	t.Run("numeric range validation", func(t *testing.T) {
+		minAge, maxAge := 0.0, 130.0
+		maxPrice := 99.5
+		schema := csv.NewSchema().
+			AddColumn(csv.ColumnDefinition{
+				Name:     "age",
+				Type:     csv.ColumnTypeInt,
+				MinValue: &minAge,
+				MaxValue: &maxAge,
+			}).
+			AddColumn(csv.ColumnDefinition{
+				Name:     "price",
+				Type:     csv.ColumnTypeFloat,
+				MaxValue: &maxPrice,
+			})
+
+		tests := []struct {
+			name       string
+			row        []string
+			wantColumn string
+			wantValue  string
+		}{
+			{name: "in range", row: []string{"30", "10.25"}},
+			{name: "inclusive bounds", row: []string{"0", "99.5"}},
+			{name: "below min", row: []string{"-1", "1"}, wantColumn: "age", wantValue: "-1"},
+			{name: "above max", row: []string{"131", "1"}, wantColumn: "age", wantValue: "131"},
+			{name: "float above max", row: []string{"1", "99.51"}, wantColumn: "price", wantValue: "99.51"},
+			{name: "no min bound", row: []string{"1", "-1000"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result := csv.ValidateSchema([][]string{{"age", "price"}, tt.row}, schema)
+				if tt.wantColumn == "" {
+					if !result.Valid {
+						t.Errorf("expected valid: %s", result.AllErrors())
+					}
+					return
+				}
+				if len(result.Errors) != 1 {
+					t.Fatalf("got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+				}
+				got := result.Errors[0]
+				if got.Row != 1 || got.Column != tt.wantColumn || got.Value != tt.wantValue {
+					t.Errorf("error = {Row: %d, Column: %q, Value: %q}, want {Row: 1, Column: %q, Value: %q}",
+						got.Row, got.Column, got.Value, tt.wantColumn, tt.wantValue)
+				}
+			})
+		}
+	})
+
+	t.Run("custom date format", func(t *testing.T) {
+		schema := csv.NewSchema().
+			AddColumn(csv.ColumnDefinition{
+				Name:       "shipped",
+				Type:       csv.ColumnTypeDate,
+				DateFormat: "02/01/2006",
+			}).
+			AddColumn(csv.ColumnDefinition{
+				Name: "ordered",
+				Type: csv.ColumnTypeDate,
+			})
+
+		result := csv.ValidateSchema([][]string{{"shipped", "ordered"}, {"31/12/2024", "2024-12-30"}}, schema)
+		if !result.Valid {
+			t.Errorf("expected valid: %s", result.AllErrors())
+		}
+
+		result = csv.ValidateSchema([][]string{{"shipped", "ordered"}, {"2024-12-31", "2024-12-30"}}, schema)
+		if len(result.Errors) != 1 || result.Errors[0].Column != "shipped" {
+			t.Fatalf("expected one shipped error, got %s", result.AllErrors())
+		}
+		if !strings.Contains(result.Errors[0].Message, "02/01/2006") {
+			t.Errorf("error message = %q, want expected layout", result.Errors[0].Message)
+		}
+
+		values, result := schema.CoerceRecord([]string{"31/12/2024", "2024-12-30"})
+		if !result.Valid {
+			t.Fatalf("CoerceRecord() unexpected errors: %s", result.AllErrors())
+		}
+		want := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		if got, ok := values[0].(time.Time); !ok || !got.Equal(want) {
+			t.Errorf("CoerceRecord() shipped = %v, want %v", values[0], want)
+		}
+	})
+
+	t.Run("pattern validation", func(t *testing.T) {
+		schema := csv.NewSchema().
+			AddColumn(csv.ColumnDefinition{
+				Name:    "sku",
+				Type:    csv.ColumnTypeString,
+				Pattern: `^[A-Z]{3}-\d{4}$`,
+			})
+
+		result := csv.ValidateSchema([][]string{{"sku"}, {"ABC-1234"}, {""}}, schema)
+		if !result.Valid {
+			t.Errorf("expected valid: %s", result.AllErrors())
+		}
+
+		result = csv.ValidateSchema([][]string{{"sku"}, {"ABC-1234"}, {"abc-12"}}, schema)
+		if len(result.Errors) != 1 {
+			t.Fatalf("got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+		}
+		if got := result.Errors[0]; got.Row != 2 || got.Column != "sku" || got.Value != "abc-12" {
+			t.Errorf("error = %+v, want row 2, column sku, value abc-12", got)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		schema := csv.NewSchema().
+			AddColumn(csv.ColumnDefinition{
+				Name:    "code",
+				Type:    csv.ColumnTypeString,
+				Pattern: `[unclosed`,
+			})
+
+		result := csv.ValidateSchema([][]string{{"code"}, {"x"}, {"y"}}, schema)
+		if len(result.Errors) != 1 {
+			t.Fatalf("got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+		}
+		got := result.Errors[0]
+		if got.Row != csv.SchemaRow || got.Column != "code" {
+			t.Errorf("error = %+v, want row SchemaRow, column code", got)
+		}
+		if !strings.Contains(got.Error(), "schema error") {
+			t.Errorf("Error() = %q, want schema error", got.Error())
+		}
+
+		if _, result := schema.CoerceRecord([]string{"x"}); result.Valid {
+			t.Error("CoerceRecord() expected invalid pattern error")
+		}
+	})
+
 	t.Run("custom validator", func(t *testing.T) {
 		schema := csv.NewSchema().
 			AddColumn(csv.ColumnDefinition{
@@ -288,6 +424,202 @@ func containsAt(s string) bool {
 	return false
 }
 
+func TestValidateSchemaUnique(t *testing.T) {
+	schema := csv.NewSchema().
+		AddColumn(csv.ColumnDefinition{Name: "id", Type: csv.ColumnTypeInt, Unique: true}).
+		AddSimpleColumn("name", csv.ColumnTypeString)
+
+	t.Run("distinct values pass", func(t *testing.T) {
+		data := [][]string{
+			{"id", "name"},
+			{"1", "John"},
+			{"2", "John"},
+			{"", "Jane"},
+			{"", "Jim"},
+		}
+
+		result := csv.ValidateSchema(data, schema)
+		if !result.Valid {
+			t.Errorf("expected valid, got errors: %s", result.AllErrors())
+		}
+	})
+
+	t.Run("duplicate value fails", func(t *testing.T) {
+		data := [][]string{
+			{"id", "name"},
+			{"1", "John"},
+			{"2", "Jane"},
+			{"1", "Jim"},
+		}
+
+		result := csv.ValidateSchema(data, schema)
+		if result.Valid || len(result.Errors) != 1 {
+			t.Fatalf("expected one duplicate error, got %v", result.Errors)
+		}
+		err := result.Errors[0]
+		if err.Row != 3 || err.Column != "id" || err.Value != "1" {
+			t.Errorf("unexpected error: %+v", err)
+		}
+		if !strings.Contains(err.Message, "rows 1 and 3") {
+			t.Errorf("message %q should cite both rows", err.Message)
+		}
+	})
+}
+
+func TestValidateSchemaRowValidators(t *testing.T) {
+	schema := csv.NewSchema().
+		AddSimpleColumn("start", csv.ColumnTypeInt).
+		AddSimpleColumn("end", csv.ColumnTypeInt)
+	schema.RowValidators = append(schema.RowValidators, func(record, headers []string) error {
+		values := make(map[string]string)
+		for i, h := range headers {
+			if i < len(record) {
+				values[h] = record[i]
+			}
+		}
+		if values["end"] < values["start"] {
+			return errors.New("end must not be before start")
+		}
+		return nil
+	})
+
+	t.Run("passing rows", func(t *testing.T) {
+		data := [][]string{
+			{"start", "end"},
+			{"1", "2"},
+			{"3", "3"},
+		}
+
+		result := csv.ValidateSchema(data, schema)
+		if !result.Valid {
+			t.Errorf("expected valid, got errors: %s", result.AllErrors())
+		}
+	})
+
+	t.Run("violating row", func(t *testing.T) {
+		data := [][]string{
+			{"start", "end"},
+			{"1", "2"},
+			{"5", "4"},
+		}
+
+		result := csv.ValidateSchema(data, schema)
+		if result.Valid || len(result.Errors) != 1 {
+			t.Fatalf("expected one row error, got %v", result.Errors)
+		}
+		if err := result.Errors[0]; err.Row != 2 || err.Column != "" {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+}
+
+func TestValidateSchemaRowMapValidators(t *testing.T) {
+	schema := csv.NewSchema().
+		AddSimpleColumn("start_date", csv.ColumnTypeDate).
+		AddSimpleColumn("end_date", csv.ColumnTypeDate)
+	schema.RowMapValidators = append(schema.RowMapValidators, func(row map[string]string) error {
+		start, err := time.Parse("2006-01-02", row["start_date"])
+		if err != nil {
+			return nil // reported by the column check
+		}
+		end, err := time.Parse("2006-01-02", row["end_date"])
+		if err != nil {
+			return nil
+		}
+		if !end.After(start) {
+			return fmt.Errorf("end_date %s must be after start_date %s", row["end_date"], row["start_date"])
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		row     []string
+		wantErr string
+	}{
+		{name: "ordered dates", row: []string{"2024-01-01", "2024-02-01"}},
+		{name: "end before start", row: []string{"2024-03-01", "2024-02-01"}, wantErr: "end_date 2024-02-01 must be after start_date 2024-03-01"},
+		{name: "same day", row: []string{"2024-03-01", "2024-03-01"}, wantErr: "must be after"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := csv.ValidateSchema([][]string{{"start_date", "end_date"}, tt.row}, schema)
+			if tt.wantErr == "" {
+				if !result.Valid {
+					t.Errorf("expected valid: %s", result.AllErrors())
+				}
+				return
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+			}
+			got := result.Errors[0]
+			if got.Row != 1 || got.Column != "" || !strings.Contains(got.Message, tt.wantErr) {
+				t.Errorf("error = %+v, want row 1 error containing %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaMarshalFormat(t *testing.T) {
+	schema := csv.NewSchema().
+		AddSimpleColumn("item", csv.ColumnTypeString).
+		AddSimpleColumn("amount", csv.ColumnTypeFloat).
+		AddSimpleColumn("date", csv.ColumnTypeDate)
+	schema.Format = map[string]string{
+		"amount": "%.2f",
+		"date":   "02/01/2006",
+	}
+
+	rows := [][]interface{}{
+		{"bolt", 1234.5, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)},
+		{"nut, small", 0.125, nil},
+	}
+
+	got, err := schema.Marshal(rows, csv.DefaultWriterOptions())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := "item,amount,date\nbolt,1234.50,07/03/2024\n\"nut, small\",0.12,\n"
+	if string(got) != want {
+		t.Errorf("Marshal() = %q, want %q", got, want)
+	}
+
+	t.Run("header options", func(t *testing.T) {
+		got, err := schema.Marshal(rows[:1], csv.WriterOptions{Comma: ';'})
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := "item;amount;date\nbolt;1234.50;07/03/2024\n"; string(got) != want {
+			t.Errorf("Marshal() with zero-value options = %q, want %q", got, want)
+		}
+
+		opts := csv.DefaultWriterOptions()
+		opts.OmitHeader = true
+		got, err = schema.Marshal(rows[:1], opts)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := "bolt,1234.50,07/03/2024\n"; string(got) != want {
+			t.Errorf("Marshal() with OmitHeader = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		bad := csv.NewSchema().AddSimpleColumn("amount", csv.ColumnTypeFloat)
+		bad.Format = map[string]string{"amount": "%d"}
+		if _, err := bad.Marshal([][]interface{}{{1.5}}, csv.DefaultWriterOptions()); err == nil {
+			t.Error("Marshal() should fail on a format that does not match the value")
+		}
+
+		bad.Format = map[string]string{"amount": "2006-01-02"}
+		if _, err := bad.Marshal([][]interface{}{{1.5}}, csv.DefaultWriterOptions()); err == nil {
+			t.Error("Marshal() should fail on a time layout for a number")
+		}
+	})
+}
+
 func TestValidationError(t *testing.T) {
 	t.Run("header error message", func(t *testing.T) {
 		err := csv.ValidationError{
@@ -606,3 +938,225 @@ func TestGoTypeToColumnType_UnsupportedType(t *testing.T) {
 		t.Errorf("unsupported type should map to ColumnTypeAny, got %v", schema.Columns[0].Type)
 	}
 }
+
+func TestSchemaJSON(t *testing.T) {
+	minQty, maxQty := 1.0, 99.0
+	schema := csv.NewSchema().
+		AddRequiredColumn("name", csv.ColumnTypeString).
+		AddColumn(csv.ColumnDefinition{
+			Name:          "status",
+			Type:          csv.ColumnTypeString,
+			AllowedValues: []string{"active", "inactive"},
+			MinLength:     2,
+			MaxLength:     8,
+			Validator:     func(string) error { return nil },
+		}).
+		AddColumn(csv.ColumnDefinition{Name: "qty", Type: csv.ColumnTypeInt, MinValue: &minQty, MaxValue: &maxQty}).
+		AddColumn(csv.ColumnDefinition{Name: "seen", Type: csv.ColumnTypeDateTime, Unique: true, Pattern: `^\d`})
+	schema.AllowExtraColumns = true
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"type":"datetime"`) {
+		t.Errorf("MarshalJSON() = %s, want type names", data)
+	}
+
+	got, err := csv.SchemaFromJSON(data)
+	if err != nil {
+		t.Fatalf("SchemaFromJSON() error = %v", err)
+	}
+
+	// Validators cannot round-trip
+	schema.Columns[1].Validator = nil
+	if !reflect.DeepEqual(got, schema) {
+		t.Errorf("SchemaFromJSON() = %+v, want %+v", got, schema)
+	}
+}
+
+func TestSchemaFromJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantMsg string
+	}{
+		{name: "unknown type", data: `{"columns":[{"name":"id","type":"integer"}]}`, wantMsg: `unknown type "integer"`},
+		{name: "invalid pattern", data: `{"columns":[{"name":"id","pattern":"("}]}`, wantMsg: "invalid pattern"},
+		{name: "malformed", data: `{"columns":`, wantMsg: "invalid schema JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := csv.SchemaFromJSON([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("SchemaFromJSON() error = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidatingScanner(t *testing.T) {
+	schema := csv.NewSchema().
+		AddRequiredColumn("name", csv.ColumnTypeString).
+		AddColumn(csv.ColumnDefinition{Name: "age", Type: csv.ColumnTypeInt})
+
+	t.Run("clean file", func(t *testing.T) {
+		input := "name,age\nAlice,30\nBob,25\nCarol,41\n"
+		scanner := schema.NewValidatingScanner(strings.NewReader(input), csv.DefaultReaderOptions())
+
+		var names []string
+		for scanner.Scan() {
+			if err := scanner.RecordError(); err != nil {
+				t.Errorf("row %d: unexpected error %v", scanner.Row(), err)
+			}
+			name, _ := scanner.Record().GetByName("name")
+			names = append(names, name)
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+		if want := []string{"Alice", "Bob", "Carol"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("names = %v, want %v", names, want)
+		}
+		if errs := scanner.Errors(); len(errs) != 0 {
+			t.Errorf("Errors() = %v, want none", errs)
+		}
+	})
+
+	t.Run("type error on row 3", func(t *testing.T) {
+		input := "name,age\nAlice,30\nBob,25\nCarol,forty\nDave,50\n"
+		scanner := schema.NewValidatingScanner(strings.NewReader(input), csv.DefaultReaderOptions())
+
+		var failed []int
+		rows := 0
+		for scanner.Scan() {
+			rows++
+			if err := scanner.RecordError(); err != nil {
+				failed = append(failed, scanner.Row())
+				if !strings.Contains(err.Error(), "invalid integer") {
+					t.Errorf("RecordError() = %v, want invalid integer", err)
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+		if rows != 4 || !reflect.DeepEqual(failed, []int{3}) {
+			t.Errorf("scanned %d rows with errors on %v, want 4 rows with errors on [3]", rows, failed)
+		}
+
+		errs := scanner.Errors()
+		if len(errs) != 1 || errs[0].Row != 3 || errs[0].Column != "age" || errs[0].Value != "forty" {
+			t.Errorf("Errors() = %+v, want one age error on row 3", errs)
+		}
+	})
+
+	t.Run("header errors", func(t *testing.T) {
+		scanner := schema.NewValidatingScanner(strings.NewReader("name\nAlice\n"), csv.DefaultReaderOptions())
+		for scanner.Scan() {
+		}
+		errs := scanner.Errors()
+		if len(errs) != 1 || errs[0].Row != -1 || errs[0].Column != "age" {
+			t.Errorf("Errors() = %+v, want missing age column", errs)
+		}
+
+		scanner = schema.NewValidatingScanner(strings.NewReader(""), csv.DefaultReaderOptions())
+		if scanner.Scan() || len(scanner.Errors()) != 1 {
+			t.Errorf("empty input: Errors() = %+v, want header required", scanner.Errors())
+		}
+	})
+}
+
+func TestSchemaCoerce(t *testing.T) {
+	schema := csv.NewSchema().
+		AddColumn(csv.ColumnDefinition{Name: "name", Type: csv.ColumnTypeString, Required: true, TrimSpace: true}).
+		AddColumn(csv.ColumnDefinition{Name: "active", Type: csv.ColumnTypeBool}).
+		AddColumn(csv.ColumnDefinition{Name: "qty", Type: csv.ColumnTypeInt, Required: true, Default: "1"}).
+		AddColumn(csv.ColumnDefinition{Name: "price", Type: csv.ColumnTypeFloat})
+
+	data := [][]string{
+		{"name", "active", "qty", "price"},
+		{"  Alice ", "T", "", "1.50"},
+		{"Bob", "no", "+042", "2"},
+		{"Carol", "maybe", "3", ""},
+	}
+
+	got, result := schema.Coerce(data)
+	want := [][]string{
+		{"name", "active", "qty", "price"},
+		{"Alice", "true", "1", "1.5"},
+		{"Bob", "false", "42", "2"},
+		{"Carol", "maybe", "3", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Coerce() data = %q, want %q", got, want)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("Coerce() got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+	}
+	if err := result.Errors[0]; err.Row != 3 || err.Column != "active" || err.Value != "maybe" {
+		t.Errorf("Coerce() error = %+v, want invalid boolean in row 3", err)
+	}
+
+	if data[1][0] != "  Alice " || data[1][1] != "T" {
+		t.Errorf("Coerce() modified its input: %q", data[1])
+	}
+}
+
+func TestSchemaCoerceRecord(t *testing.T) {
+	schema := csv.NewSchema().
+		AddSimpleColumn("id", csv.ColumnTypeInt).
+		AddSimpleColumn("score", csv.ColumnTypeFloat).
+		AddSimpleColumn("active", csv.ColumnTypeBool).
+		AddSimpleColumn("joined", csv.ColumnTypeDate).
+		AddSimpleColumn("name", csv.ColumnTypeString)
+
+	t.Run("typed values", func(t *testing.T) {
+		values, result := schema.CoerceRecord([]string{"42", "9.5", "yes", "2024-01-15", "Alice"})
+		if !result.Valid {
+			t.Fatalf("CoerceRecord() errors: %s", result.AllErrors())
+		}
+
+		want := []interface{}{
+			int64(42),
+			9.5,
+			true,
+			time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			"Alice",
+		}
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("CoerceRecord() = %#v, want %#v", values, want)
+		}
+	})
+
+	t.Run("empty optional field is nil", func(t *testing.T) {
+		values, result := schema.CoerceRecord([]string{"1", "", "false", "", "Bob"})
+		if !result.Valid {
+			t.Fatalf("CoerceRecord() errors: %s", result.AllErrors())
+		}
+		if values[1] != nil || values[3] != nil {
+			t.Errorf("empty fields should coerce to nil, got %#v and %#v", values[1], values[3])
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		values, result := schema.CoerceRecord([]string{"abc", "1.0", "true", "2024-01-15", "Carol"})
+		if result.Valid {
+			t.Fatal("CoerceRecord() should report a type mismatch")
+		}
+		if len(result.Errors) != 1 {
+			t.Fatalf("got %d errors, want 1: %s", len(result.Errors), result.AllErrors())
+		}
+		if result.Errors[0].Column != "id" {
+			t.Errorf("error column = %q, want %q", result.Errors[0].Column, "id")
+		}
+		if values[0] != nil {
+			t.Errorf("invalid field should coerce to nil, got %#v", values[0])
+		}
+		if values[1] != 1.0 {
+			t.Errorf("valid fields should still be coerced, got %#v", values[1])
+		}
+	})
+}
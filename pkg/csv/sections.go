@@ -0,0 +1,93 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/shapestone/shape-csv/internal/bom"
+)
+
+// ParseSections splits data into sections separated by one or more blank
+// lines and parses each section into its own Document, as found in merged
+// exports that concatenate several tables. The delimiter is detected for each
+// section with DetectDelimiter, falling back to opts.Comma, so a comma table
+// followed by a tab table parses correctly. With opts.HasHeader, the first row
+// of each section becomes that document's headers.
+//
+// Each document's Detected reports the options used for its section, with the
+// detected delimiter in Options.Comma. Blank lines inside quoted fields do not
+// end a section. Parse errors name the section, and their positions are
+// relative to its first line.
+//
+// Example:
+//
+//	docs, err := csv.ParseSections(data, csv.DefaultReaderOptions())
+//	for _, doc := range docs {
+//	    fmt.Printf("%q: %d records\n", doc.Detected().Options.Comma, doc.RecordCount())
+//	}
+func ParseSections(data []byte, opts ReaderOptions) ([]*Document, error) {
+	stripped := bom.Strip(data)
+	hasBOM := len(stripped) < len(data)
+
+	quote := opts.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+
+	var docs []*Document
+	for i, section := range splitSections(stripped, quote) {
+		sectionOpts := opts
+		if delim, err := DetectDelimiter(section); err == nil {
+			sectionOpts.Comma = delim
+		}
+
+		doc, err := ParseDocumentWithOptions(string(section), sectionOpts)
+		if err != nil {
+			return nil, fmt.Errorf("csv: ParseSections: section %d: %w", i+1, err)
+		}
+
+		doc.detected = &DetectedOptions{
+			Encoding:  EncodingUTF8,
+			BOM:       hasBOM,
+			HasHeader: opts.HasHeader,
+			Options:   sectionOpts,
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// splitSections returns the runs of lines in data separated by blank lines,
+// ignoring blank lines inside fields quoted with quote.
+func splitSections(data []byte, quote rune) [][]byte {
+	quoteBytes := []byte(string(quote))
+
+	var sections [][]byte
+	start, inQuote := -1, false
+	for pos := 0; pos < len(data); {
+		end, next := len(data), len(data)
+		if i := bytes.IndexByte(data[pos:], '\n'); i >= 0 {
+			end, next = pos+i, pos+i+1
+		}
+		line := data[pos:end]
+
+		if !inQuote && len(bytes.Trim(line, " \t\r")) == 0 {
+			if start >= 0 {
+				sections = append(sections, data[start:pos])
+				start = -1
+			}
+		} else if start < 0 {
+			start = pos
+		}
+
+		// Doubled quotes cancel out, so an odd count toggles the quoted state
+		if bytes.Count(line, quoteBytes)%2 == 1 {
+			inQuote = !inQuote
+		}
+		pos = next
+	}
+	if start >= 0 {
+		sections = append(sections, data[start:])
+	}
+	return sections
+}
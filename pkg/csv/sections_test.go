@@ -0,0 +1,54 @@
+package csv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestParseSections(t *testing.T) {
+	data := "name,note\nAlice,\"first\n\nline\"\nBob,x\n\n\n" +
+		"id\tcity\tzip\n1\tParis\t75001\n2\tOslo\t0150\n"
+
+	opts := csv.DefaultReaderOptions()
+	opts.HasHeader = true
+	docs, err := csv.ParseSections([]byte(data), opts)
+	if err != nil {
+		t.Fatalf("ParseSections() error = %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("ParseSections() returned %d documents, want 2", len(docs))
+	}
+
+	tests := []struct {
+		comma rune
+		want  string
+	}{
+		{',', "name,note\nAlice,\"first\n\nline\"\nBob,x\n"},
+		{'\t', "id,city,zip\n1,Paris,75001\n2,Oslo,0150\n"},
+	}
+	for i, tt := range tests {
+		doc := docs[i]
+		if got := doc.Detected().Options.Comma; got != tt.comma {
+			t.Errorf("section %d delimiter = %q, want %q", i+1, got, tt.comma)
+		}
+		if got, _ := doc.CSV(); got != tt.want {
+			t.Errorf("section %d CSV() = %q, want %q", i+1, got, tt.want)
+		}
+	}
+}
+
+func TestParseSectionsErrors(t *testing.T) {
+	data := "a,b\n1,2\n\nx;y\n\"open;z\n"
+
+	_, err := csv.ParseSections([]byte(data), csv.DefaultReaderOptions())
+	if err == nil || !strings.Contains(err.Error(), "section 2") {
+		t.Errorf("ParseSections() error = %v, want error naming section 2", err)
+	}
+
+	docs, err := csv.ParseSections(nil, csv.DefaultReaderOptions())
+	if err != nil || len(docs) != 0 {
+		t.Errorf("ParseSections(nil) = %d documents, %v; want none", len(docs), err)
+	}
+}
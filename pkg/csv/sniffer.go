@@ -2,9 +2,13 @@
 package csv
 
 import (
+	"bytes"
+	"errors"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/shapestone/shape-csv/internal/bom"
 )
 
 // Sniffer detects CSV dialect (delimiter, headers, etc.)
@@ -96,6 +100,85 @@ func (s *Sniffer) detectDelimiter() rune {
 	return best
 }
 
+// Limits on how much input DetectDelimiter samples.
+const (
+	detectSampleLines = 10
+	detectSampleBytes = 64 * 1024
+)
+
+// detectCandidates are the delimiters tried by DetectDelimiter, in
+// tie-breaking order.
+var detectCandidates = []rune{',', ';', '\t', '|'}
+
+// DetectDelimiter guesses the field delimiter of data by sampling its first
+// few non-comment lines (lines starting with '#' are skipped). Each candidate
+// (comma, semicolon, tab, pipe) is counted outside quoted regions, and the one
+// giving the most consistent field count across lines wins; ties go to the
+// field count, then to the candidate order above, so comma wins a draw.
+//
+// An error is returned if none of the candidates appears in the sample.
+func DetectDelimiter(data []byte) (rune, error) {
+	if len(data) > detectSampleBytes {
+		data = data[:detectSampleBytes]
+	}
+	data = bom.Strip(data)
+
+	// Split the sample into logical lines, keeping quoted newlines in place
+	var lines []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(data) && len(lines) < detectSampleLines; i++ {
+		switch data[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case '\n':
+			if !inQuotes {
+				lines = appendSampleLine(lines, data[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if len(lines) < detectSampleLines && start < len(data) {
+		lines = appendSampleLine(lines, data[start:])
+	}
+
+	best := rune(0)
+	bestConsistent, bestFields := 0, 0
+	for _, delim := range detectCandidates {
+		// Find the most common field count and how many lines share it
+		freq := make(map[int]int)
+		for _, line := range lines {
+			if n := countDelimiter(line, delim); n > 0 {
+				freq[n+1]++
+			}
+		}
+		consistent, fields := 0, 0
+		for n, c := range freq {
+			if c > consistent || (c == consistent && n > fields) {
+				consistent, fields = c, n
+			}
+		}
+		if consistent > bestConsistent || (consistent == bestConsistent && fields > bestFields) {
+			best, bestConsistent, bestFields = delim, consistent, fields
+		}
+	}
+
+	if best == 0 {
+		return 0, errors.New("csv: could not detect delimiter")
+	}
+	return best, nil
+}
+
+// appendSampleLine adds a sample line for delimiter detection, skipping blank
+// and comment lines.
+func appendSampleLine(lines []string, line []byte) []string {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(bytes.TrimSpace(line)) == 0 || line[0] == '#' {
+		return lines
+	}
+	return append(lines, string(line))
+}
+
 // countDelimiter counts occurrences of a delimiter, ignoring quoted sections.
 func countDelimiter(line string, delim rune) int {
 	count := 0
@@ -1,6 +1,7 @@
 package csv_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/shapestone/shape-csv/pkg/csv"
@@ -256,3 +257,58 @@ func TestSnifferAnalyzeCaching(t *testing.T) {
 		t.Error("header results should be consistent")
 	}
 }
+
+func TestDetectDelimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  rune
+	}{
+		{"comma", "name,age,city\nAlice,30,NYC\nBob,25,LA\n", ','},
+		{"semicolon", "name;age;city\nAlice;30;NYC\nBob;25;LA\n", ';'},
+		{"tab", "name\tage\tcity\nAlice\t30\tNYC\nBob\t25\tLA\n", '\t'},
+		{"pipe", "name|age|city\nAlice|30|NYC\nBob|25|LA\n", '|'},
+		{"quoted delimiters ignored", "a;b\n\"x,y,z\";1\n\"p,q\";2\n", ';'},
+		{"comments skipped", "# exported, by tool, v2\na;b\n1;2\n", ';'},
+		{"tie goes to comma", "a,b;c\nd,e;f\n", ','},
+		{"consistency beats count", "a;b;c,d\ne;f;g\nh;i;j,k,l,m\n", ';'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := csv.DetectDelimiter([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("DetectDelimiter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectDelimiter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := csv.DetectDelimiter([]byte("single\ncolumn\n")); err == nil {
+		t.Error("DetectDelimiter() should fail when no candidate appears")
+	}
+}
+
+func TestParseWithOptions_AutoDetectDelimiter(t *testing.T) {
+	opts := csv.DefaultReaderOptions()
+	opts.AutoDetectDelimiter = true
+
+	input := "name;age\nAlice;30\n"
+	node, err := csv.ParseWithOptions(input, opts)
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+	if records := csv.NodeToRecords(node); len(records) != 2 || len(records[0]) != 2 || records[1][1] != "30" {
+		t.Errorf("ParseWithOptions() = %q, want 2 records of 2 fields", records)
+	}
+
+	node, err = csv.ParseReaderWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("ParseReaderWithOptions() error = %v", err)
+	}
+	if records := csv.NodeToRecords(node); len(records) != 2 || len(records[0]) != 2 {
+		t.Errorf("ParseReaderWithOptions() = %q, want 2 records of 2 fields", records)
+	}
+}
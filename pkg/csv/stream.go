@@ -1,6 +1,8 @@
 package csv
 
 import (
+	"context"
+	"fmt"
 	"io"
 
 	"github.com/shapestone/shape-csv/internal/fastparser"
@@ -28,12 +30,16 @@ type Scanner struct {
 	reader      io.Reader
 	hasHeaders  bool
 	reuseRecord bool
+	engine      Engine
+	progress    func(bytesRead, totalBytes int64)
 	headers     []string
 	records     [][]string
 	index       int
 	err         error
 	parsed      bool
 	lastRecord  Record // reused when reuseRecord is true
+	data        []byte // parsed input, kept to compute line numbers on demand
+	lines       []int  // start line of each record, computed on the first Decode error
 }
 
 // NewScanner creates a new Scanner that reads CSV from the given io.Reader.
@@ -78,6 +84,35 @@ func (s *Scanner) SetReuseRecord(reuse bool) *Scanner {
 	return s
 }
 
+// SetEngine selects the parser used to read the input; see Engine. The
+// Scanner has no dialect options, so every engine is supported.
+// Returns the Scanner for method chaining.
+//
+// Example:
+//
+//	scanner := csv.NewScanner(reader).SetEngine(csv.EngineChunked)
+func (s *Scanner) SetEngine(engine Engine) *Scanner {
+	s.engine = engine
+	return s
+}
+
+// SetProgressCallback sets a function called with the number of input bytes
+// read so far, about every 64KB and once more at the end of the input, for
+// driving a progress bar. totalBytes is the input size when the reader is an
+// *os.File, *bytes.Reader or *strings.Reader, and -1 otherwise. It has the
+// same meaning as ReaderOptions.ProgressCallback.
+// Returns the Scanner for method chaining.
+//
+// Example:
+//
+//	scanner := csv.NewScanner(file).SetProgressCallback(func(read, total int64) {
+//	    bar.Set(read, total)
+//	})
+func (s *Scanner) SetProgressCallback(fn func(bytesRead, totalBytes int64)) *Scanner {
+	s.progress = fn
+	return s
+}
+
 // Scan advances the scanner to the next record.
 // It returns false when there are no more records or an error occurs.
 // After Scan returns false, the Err method will return any error that occurred.
@@ -108,6 +143,83 @@ func (s *Scanner) Scan() bool {
 	return s.index < len(s.records)
 }
 
+// ScanContext is like Scan but stops when ctx is canceled or its deadline
+// passes, in which case it returns false and Err returns ctx.Err(). The context
+// is checked before every record and while the input is being read, so a
+// canceled import stops promptly even on very large inputs.
+//
+// Example:
+//
+//	for scanner.ScanContext(r.Context()) {
+//	    // process scanner.Record()
+//	}
+//	if err := scanner.Err(); errors.Is(err, context.Canceled) {
+//	    // client went away
+//	}
+func (s *Scanner) ScanContext(ctx context.Context) bool {
+	if !s.parsed {
+		s.reader = &contextReader{ctx: ctx, r: s.reader}
+	}
+	select {
+	case <-ctx.Done():
+		s.err = ctx.Err()
+		return false
+	default:
+		return s.Scan()
+	}
+}
+
+// contextReader fails reads once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader.
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// Skip advances the scanner past the next n records without building a
+// Record for them, so the following call to Scan reads the record after the
+// skipped ones. Record boundaries still honor quoted fields, so a skipped
+// field containing a line break counts as part of one record. The header row
+// is never counted. Skip returns io.EOF, leaving the scanner at the end of
+// the input, if fewer than n records remain.
+//
+// Example:
+//
+//	// Resume an import after the first 5000 records
+//	scanner := csv.NewScanner(file).SetHasHeaders(true)
+//	if err := scanner.Skip(5000); err != nil {
+//	    return err
+//	}
+//	for scanner.Scan() {
+//	    // process scanner.Record()
+//	}
+func (s *Scanner) Skip(n int) error {
+	if n < 0 {
+		return fmt.Errorf("csv: Skip called with negative count %d", n)
+	}
+	if !s.parsed {
+		if err := s.parse(); err != nil {
+			s.err = err
+			return err
+		}
+		s.parsed = true
+	}
+
+	if remaining := len(s.records) - (s.index + 1); n > remaining {
+		s.index = len(s.records) - 1
+		return io.EOF
+	}
+	s.index += n
+	return nil
+}
+
 // Record returns the current record.
 // This should only be called after Scan() returns true.
 //
@@ -134,6 +246,61 @@ func (s *Scanner) Record() Record {
 	}
 }
 
+// Decode stores the current record in the struct pointed to by v, mapping
+// columns to fields by the scanner's headers with the same struct tags and
+// type conversions as Unmarshal. It requires SetHasHeaders(true), unless the
+// struct binds fields by column index tags such as `csv:"0"`, and should only
+// be called after Scan returns true. The struct is reset before decoding,
+// so one value can be reused for every record.
+//
+// Conversion errors report the line on which the record starts.
+//
+// Example:
+//
+//	scanner := csv.NewScanner(file).SetHasHeaders(true)
+//	var p Person
+//	for scanner.Scan() {
+//	    if err := scanner.Decode(&p); err != nil {
+//	        return err
+//	    }
+//	    process(p)
+//	}
+func (s *Scanner) Decode(v interface{}) error {
+	if s.index < 0 || s.index >= len(s.records) {
+		return fmt.Errorf("csv: Decode called without a current record")
+	}
+
+	var headers []string
+	if s.hasHeaders {
+		headers = s.headers
+	}
+	err := fastparser.DecodeRecord(headers, s.records[s.index], v, s.index, fastparser.DecodeOptions{})
+	if err != nil {
+		return fmt.Errorf("csv: record on line %d: %w", s.recordLine(), toDecodeError(err))
+	}
+	return nil
+}
+
+// recordLine returns the line on which the current record starts.
+func (s *Scanner) recordLine() int {
+	if s.lines == nil {
+		_, lines, err := fastparser.ParseWithLines(s.data)
+		if err != nil {
+			return 0
+		}
+		s.lines = lines
+	}
+	// The header row, if any, is the first parsed record
+	idx := s.index
+	if s.hasHeaders {
+		idx++
+	}
+	if idx < len(s.lines) {
+		return s.lines[idx]
+	}
+	return 0
+}
+
 // Err returns the error, if any, that was encountered during scanning.
 // It returns nil if no error occurred or at EOF.
 func (s *Scanner) Err() error {
@@ -152,16 +319,17 @@ func (s *Scanner) Headers() []string {
 // but for now we use the fast parser to read all records.
 func (s *Scanner) parse() error {
 	// Read all data from reader
-	data, err := io.ReadAll(s.reader)
+	data, err := io.ReadAll(newProgressReader(s.reader, s.progress))
 	if err != nil {
 		return err
 	}
 
 	// Parse CSV
-	allRecords, err := fastparser.Parse(data)
+	allRecords, err := parseRecordsWithEngine(data, s.engine)
 	if err != nil {
 		return err
 	}
+	s.data = data
 
 	// Handle headers
 	if s.hasHeaders && len(allRecords) > 0 {
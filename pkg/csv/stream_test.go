@@ -1,6 +1,10 @@
 package csv
 
 import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -407,3 +411,230 @@ func BenchmarkScanner(b *testing.B) {
 		}
 	}
 }
+
+// TestScannerDecode tests decoding consecutive records into one struct
+func TestScannerDecode(t *testing.T) {
+	type Person struct {
+		Name  string `csv:"name"`
+		Age   int    `csv:"age"`
+		Email *string
+	}
+
+	csvData := "name,age,email\nAlice,30,alice@example.com\n\nBob,25,\n"
+	scanner := NewScanner(strings.NewReader(csvData)).SetHasHeaders(true)
+
+	var got []Person
+	var p Person
+	for scanner.Scan() {
+		if err := scanner.Decode(&p); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, p)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("decoded %d records, want 2", len(got))
+	}
+	if got[0].Name != "Alice" || got[0].Age != 30 || got[0].Email == nil || *got[0].Email != "alice@example.com" {
+		t.Errorf("record 0 = %+v", got[0])
+	}
+	// The reused struct must not keep Alice's email
+	if got[1].Name != "Bob" || got[1].Age != 25 || got[1].Email != nil {
+		t.Errorf("record 1 = %+v, want Bob, 25, nil email", got[1])
+	}
+}
+
+// TestScannerDecodeError tests that conversion errors report the record's line
+func TestScannerDecodeError(t *testing.T) {
+	type Person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+
+	csvData := "name,age\nAlice,30\n\"Bob\nSmith\",25\nCarol,old\n"
+	scanner := NewScanner(strings.NewReader(csvData)).SetHasHeaders(true)
+
+	var p Person
+	var err error
+	for scanner.Scan() {
+		if err = scanner.Decode(&p); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		t.Fatal("Decode() expected a conversion error")
+	}
+	if !strings.Contains(err.Error(), "line 5") || !strings.Contains(err.Error(), `"old"`) {
+		t.Errorf("Decode() error = %v, want it to name line 5 and the value \"old\"", err)
+	}
+}
+
+// TestScannerDecodeColumnIndex tests decoding headerless data into a struct
+// bound by column index tags
+func TestScannerDecodeColumnIndex(t *testing.T) {
+	type Row struct {
+		Code  string `csv:"1"`
+		Count int    `csv:"2"`
+	}
+
+	scanner := NewScanner(strings.NewReader("x,A,1\ny,B,2\n"))
+	var got []Row
+	for scanner.Scan() {
+		var r Row
+		if err := scanner.Decode(&r); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got = append(got, r)
+	}
+	want := []Row{{Code: "A", Count: 1}, {Code: "B", Count: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+
+	// Conversion errors report the record's own line without a header offset
+	bad := NewScanner(strings.NewReader("x,A,1\ny,B,two\n"))
+	bad.Scan()
+	bad.Scan()
+	var r Row
+	if err := bad.Decode(&r); err == nil || !strings.Contains(err.Error(), "csv: record on line 2") {
+		t.Errorf("Decode() error = %v, want error on line 2", err)
+	}
+}
+
+// TestScannerDecodeMisuse tests Decode without headers or a current record
+func TestScannerDecodeMisuse(t *testing.T) {
+	type Row struct {
+		A string `csv:"a"`
+	}
+	var r Row
+
+	noHeaders := NewScanner(strings.NewReader("a\nx\n"))
+	noHeaders.Scan()
+	if err := noHeaders.Decode(&r); err == nil {
+		t.Error("Decode() without headers expected error")
+	}
+
+	scanner := NewScanner(strings.NewReader("a\nx\n")).SetHasHeaders(true)
+	if err := scanner.Decode(&r); err == nil {
+		t.Error("Decode() before Scan expected error")
+	}
+	scanner.Scan()
+	if err := scanner.Decode(r); err == nil {
+		t.Error("Decode() into non-pointer expected error")
+	}
+}
+
+// TestScannerScanContext tests that canceling the context stops the scan
+func TestScannerScanContext(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		sb.WriteString("a,b\n")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scanner := NewScanner(strings.NewReader(sb.String()))
+	count := 0
+	for scanner.ScanContext(ctx) {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+
+	if count != 10 {
+		t.Errorf("scanned %d records, want 10", count)
+	}
+	if !errors.Is(scanner.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", scanner.Err())
+	}
+}
+
+// TestScannerScanContextBeforeRead tests cancellation before any input is read
+func TestScannerScanContextBeforeRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	scanner := NewScanner(strings.NewReader("a,b\n"))
+	if scanner.ScanContext(ctx) {
+		t.Error("ScanContext() = true after cancel, want false")
+	}
+	if !errors.Is(scanner.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", scanner.Err())
+	}
+}
+
+// TestScannerScanContextComplete tests a full scan with a live context
+func TestScannerScanContextComplete(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("a\nb\nc\n"))
+	count := 0
+	for scanner.ScanContext(context.Background()) {
+		count++
+	}
+	if count != 3 || scanner.Err() != nil {
+		t.Errorf("scanned %d records with Err() = %v, want 3 and nil", count, scanner.Err())
+	}
+}
+
+// TestScannerSkip tests fast-forwarding past records before scanning
+func TestScannerSkip(t *testing.T) {
+	input := "id,note\n1,a\n2,\"multi\nline\"\n3,c\n4,d\n"
+	tests := []struct {
+		name    string
+		skip    int
+		want    []string // ids scanned after the skip
+		wantErr error
+	}{
+		{name: "skip none", skip: 0, want: []string{"1", "2", "3", "4"}},
+		{name: "skip into the middle", skip: 2, want: []string{"3", "4"}},
+		{name: "skip all", skip: 4, want: nil},
+		{name: "skip past the end", skip: 10, want: nil, wantErr: io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := NewScanner(strings.NewReader(input)).SetHasHeaders(true)
+			if err := scanner.Skip(tt.skip); err != tt.wantErr {
+				t.Fatalf("Skip(%d) error = %v, want %v", tt.skip, err, tt.wantErr)
+			}
+
+			var got []string
+			for scanner.Scan() {
+				id, _ := scanner.Record().GetByName("id")
+				got = append(got, id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("after Skip(%d) scanned ids %v, want %v", tt.skip, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestScannerSkipMidScan tests skipping between calls to Scan
+func TestScannerSkipMidScan(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("a\nb\nc\nd\ne\n"))
+	if !scanner.Scan() {
+		t.Fatal("Scan() = false, want true")
+	}
+	if err := scanner.Skip(2); err != nil {
+		t.Fatalf("Skip(2) error = %v", err)
+	}
+	if !scanner.Scan() {
+		t.Fatal("Scan() after Skip = false, want true")
+	}
+	if got := scanner.Record().Fields(); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("Record() after Skip = %v, want [d]", got)
+	}
+}
+
+// TestScannerSkipNegative tests that a negative count is rejected
+func TestScannerSkipNegative(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("a\n"))
+	if err := scanner.Skip(-1); err == nil {
+		t.Error("Skip(-1) error = nil, want error")
+	}
+}
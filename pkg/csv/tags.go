@@ -9,12 +9,14 @@ import (
 type fieldInfo struct {
 	name      string // CSV field name (empty means use Go field name)
 	omitEmpty bool   // omitempty option
-	skip      bool   // skip this field (tag is "-")
+	skip      bool   // skip this field (tag is "-", or an offset/line/raw record field)
+	percent   bool   // percent option: float 0.45 is written as "45%"
 }
 
 // parseTag parses a struct field's csv tag value
 // Format: "fieldname" or "fieldname,option1,option2"
-// Options: omitempty
+// Options: omitempty, percent, currency, intbool, offset, line, raw, recurse, split=sep,
+// alias=name1;name2, required
 // Special: "-" means skip field
 func parseTag(tag string) fieldInfo {
 	info := fieldInfo{}
@@ -32,9 +34,15 @@ func parseTag(tag string) fieldInfo {
 
 	// Parse options
 	for i := 1; i < len(parts); i++ {
-		switch strings.TrimSpace(parts[i]) {
+		opt := strings.TrimSpace(parts[i])
+		switch opt {
 		case "omitempty":
 			info.omitEmpty = true
+		case "percent":
+			info.percent = true
+		case "offset", "line", "raw":
+			// Populated from the record's position or source when unmarshaling; never a column
+			info.skip = true
 		}
 	}
 
@@ -0,0 +1,178 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/shapestone/shape-csv/internal/bom"
+	"github.com/shapestone/shape-csv/internal/parser"
+)
+
+// ParseTail returns the last n data records of data, in input order, without
+// parsing the records before them, for showing the end of a large log file.
+// With opts.HasHeader, the first record is also parsed and returned as header.
+//
+// Record boundaries are found by scanning backwards from the end of data. In
+// RFC 4180 input quote characters always come in pairs within a record, so a
+// line break is a record boundary exactly when the quotes after it are
+// balanced, and a line break inside a quoted field is never mistaken for one.
+// When the quotes in data are unbalanced overall, or opts sets Comment,
+// InlineComment, LazyQuotes, EscapeModeBackslash, an UnbalancedQuoteMode other
+// than UnbalancedQuoteModeError, SkipRows, RecordPrefix, RecordSuffix or
+// TerminatorLine, the backward scan cannot be trusted: every record is then
+// parsed forwards instead, keeping only the last n.
+//
+// As with Sample, FieldsPerRecord, MaxRecords and OnBadLine are not applied.
+//
+// Example:
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.HasHeader = true
+//	header, rows, err := csv.ParseTail(data, 20, opts)
+func ParseTail(data []byte, n int, opts ReaderOptions) (header []string, rows [][]string, err error) {
+	if n < 0 {
+		return nil, nil, fmt.Errorf("csv: ParseTail: negative record count %d", n)
+	}
+
+	data = bom.Strip(data)
+	if opts.AutoDetectDelimiter {
+		if delim, err := DetectDelimiter(data); err == nil {
+			opts.Comma = delim
+		}
+		opts.AutoDetectDelimiter = false
+	}
+
+	quote := []byte(string(opts.Quote))
+	if opts.Quote == 0 {
+		quote = []byte(`"`)
+	}
+	if !opts.tailScanSupported() || bytes.Count(data, quote)%2 != 0 {
+		return lastRecords(data, n, opts)
+	}
+
+	start := 0
+	if opts.HasHeader {
+		start = firstRecordEnd(data, quote)
+		header, _, err = lastRecords(data[:start], 0, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	opts.HasHeader = false
+	_, rows, err = lastRecords(data[tailStart(data, start, n, quote):], n, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, rows, nil
+}
+
+// tailScanSupported reports whether record boundaries in input read with o
+// can be found by ParseTail's backward scan.
+func (o ReaderOptions) tailScanSupported() bool {
+	return o.Comment == 0 &&
+		o.InlineComment == 0 &&
+		!o.LazyQuotes &&
+		o.EscapeMode == EscapeModeRFC4180 &&
+		o.UnbalancedQuoteMode == UnbalancedQuoteModeError &&
+		o.SkipRows == 0 &&
+		o.RecordPrefix == "" &&
+		o.RecordSuffix == "" &&
+		o.TerminatorLine == ""
+}
+
+// firstRecordEnd returns the offset just past the line break ending the first
+// non-blank record in data, or len(data) if it is not terminated.
+func firstRecordEnd(data, quote []byte) int {
+	inQuotes, blank := false, true
+	for i := 0; i < len(data); i++ {
+		switch {
+		case bytes.HasPrefix(data[i:], quote):
+			inQuotes = !inQuotes
+			blank = false
+			i += len(quote) - 1
+		case data[i] == '\n' && !inQuotes:
+			if !blank {
+				return i + 1
+			}
+		case data[i] != '\r':
+			blank = false
+		}
+	}
+	return len(data)
+}
+
+// tailStart returns the offset in data of the n-th last non-blank record that
+// starts at or after min, or min if there are fewer. A line start is a record
+// boundary when the quotes from there to the end of data are balanced.
+func tailStart(data []byte, min, n int, quote []byte) int {
+	if n == 0 {
+		return len(data)
+	}
+
+	quotes, found := 0, 0
+	for i := len(data) - 1; i >= min; i-- {
+		if bytes.HasPrefix(data[i:], quote) {
+			quotes++
+		}
+		if (i > min && data[i-1] != '\n') || quotes%2 != 0 {
+			continue
+		}
+		if data[i] == '\n' || bytes.HasPrefix(data[i:], []byte("\r\n")) {
+			// Blank lines are skipped by the parser
+			continue
+		}
+		if found++; found == n {
+			return i
+		}
+	}
+	return min
+}
+
+// lastRecords parses data forwards and returns the last n records, keeping
+// at most n in memory. With opts.HasHeader, the first record is returned
+// separately as the header.
+func lastRecords(data []byte, n int, opts ReaderOptions) ([]string, [][]string, error) {
+	input, opts := prepareInput(string(data), opts)
+	p := parser.NewParserWithOptions(input, opts.parserOptions())
+
+	var header []string
+	if opts.HasHeader {
+		record, err := p.NextRecord()
+		if errors.Is(err, io.EOF) {
+			return nil, [][]string{}, nil
+		}
+		if err != nil {
+			return nil, nil, toParseError(err)
+		}
+		header = record
+	}
+
+	// Ring buffer of the last n records; next is the oldest once full
+	ring := make([][]string, 0, n)
+	next := 0
+	for {
+		record, err := p.NextRecord()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, toParseError(err)
+		}
+		switch {
+		case n == 0:
+		case len(ring) < n:
+			ring = append(ring, record)
+		default:
+			ring[next] = record
+			next = (next + 1) % n
+		}
+	}
+
+	rows := make([][]string, 0, len(ring))
+	rows = append(rows, ring[next:]...)
+	rows = append(rows, ring[:next]...)
+	return header, rows, nil
+}
@@ -0,0 +1,98 @@
+package csv_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestParseTail(t *testing.T) {
+	var log strings.Builder
+	log.WriteString("time,level,message\n")
+	for i := 0; i < 50; i++ {
+		switch i % 4 {
+		case 0:
+			fmt.Fprintf(&log, "%d,info,\"multi\nline %d\"\n", i, i)
+		case 1:
+			fmt.Fprintf(&log, "%d,warn,\"quoted \"\"%d\"\", with comma\"\r\n", i, i)
+		case 2:
+			fmt.Fprintf(&log, "\n%d,error,\"\n\"\n", i)
+		default:
+			fmt.Fprintf(&log, "%d,debug,plain %d\n", i, i)
+		}
+	}
+
+	inputs := []struct {
+		name  string
+		input string
+	}{
+		{"log", log.String()},
+		{"no trailing newline", "h\n1\n2\n3"},
+		{"quoted line that looks like a record", "h,v\n1,\"a\n2,b\n3,c\"\n4,d\n"},
+		{"byte order mark", "\ufeffh\n1\n2\n"},
+		{"header only", "h1,h2\n"},
+		{"empty", ""},
+	}
+
+	for _, in := range inputs {
+		for _, hasHeader := range []bool{true, false} {
+			opts := csv.DefaultReaderOptions()
+			opts.HasHeader = hasHeader
+
+			node, err := csv.ParseWithOptions(in.input, csv.DefaultReaderOptions())
+			if err != nil {
+				t.Fatalf("%s: ParseWithOptions() error = %v", in.name, err)
+			}
+			all := csv.NodeToRecords(node)
+			var wantHeader []string
+			if hasHeader && len(all) > 0 {
+				wantHeader, all = all[0], all[1:]
+			}
+
+			for _, n := range []int{0, 1, 3, 10, 1000} {
+				t.Run(fmt.Sprintf("%s/header=%v/n=%d", in.name, hasHeader, n), func(t *testing.T) {
+					header, rows, err := csv.ParseTail([]byte(in.input), n, opts)
+					if err != nil {
+						t.Fatalf("ParseTail() error = %v", err)
+					}
+					want := all[len(all)-min(n, len(all)):]
+					if !reflect.DeepEqual(header, wantHeader) {
+						t.Errorf("ParseTail() header = %q, want %q", header, wantHeader)
+					}
+					if len(rows) != len(want) || (len(want) > 0 && !reflect.DeepEqual(rows, want)) {
+						t.Errorf("ParseTail() rows = %q, want %q", rows, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestParseTailFallback(t *testing.T) {
+	t.Run("comment lines", func(t *testing.T) {
+		opts := csv.DefaultReaderOptions()
+		opts.Comment = '#'
+		_, rows, err := csv.ParseTail([]byte("a\n# \"odd\nb\nc\n# end\n"), 2, opts)
+		if err != nil {
+			t.Fatalf("ParseTail() error = %v", err)
+		}
+		if want := [][]string{{"b"}, {"c"}}; !reflect.DeepEqual(rows, want) {
+			t.Errorf("ParseTail() rows = %q, want %q", rows, want)
+		}
+	})
+
+	t.Run("unbalanced quotes", func(t *testing.T) {
+		if _, _, err := csv.ParseTail([]byte("a\n\"open\nb\nc\n"), 1, csv.DefaultReaderOptions()); err == nil {
+			t.Error("ParseTail() with an unclosed quote: expected error")
+		}
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		if _, _, err := csv.ParseTail([]byte("a\n"), -1, csv.DefaultReaderOptions()); err == nil {
+			t.Error("ParseTail(-1): expected error")
+		}
+	})
+}
@@ -34,11 +34,28 @@ import (
 //
 // Unmarshal will only set exported struct fields.
 //
+// The fields of an embedded struct, or pointer to struct, are matched as if
+// they were declared in the outer struct, at any depth; a nil embedded
+// pointer is allocated when one of its fields is set, so pointers to
+// unexported struct types are skipped. When an outer field
+// and an embedded one map to the same column, the shallower field wins. An
+// embedded struct with a csv name tag is treated as a single field.
+//
 // The csv tag format is:
 //
 //	Field int `csv:"column_name"`           // Map to CSV column "column_name"
 //	Field int `csv:"column_name,omitempty"` // Map to CSV column, omit if empty when marshaling
 //	Field int `csv:"-"`                      // Always ignore this field
+//	Rate float64 `csv:"rate,percent"`        // "45%" decodes to 0.45
+//	Cost float64 `csv:"cost,currency"`       // "$1,234.56" decodes to 1234.56
+//	On   bool    `csv:"on,intbool"`          // Any integer; nonzero decodes to true
+//	Pos  int64   `csv:",offset"`             // Byte offset where the record starts
+//	Line int     `csv:",line"`               // Line number where the record starts
+//	Raw  string  `csv:",raw"`                // Source text of the record ([]byte also works)
+//	Addr Address `csv:"address,recurse"`     // Nested struct from columns such as "address.city"
+//	Tags []string `csv:"tags,split=;"`       // "a;b" decodes to []string{"a", "b"}
+//	Mail string `csv:"email,alias=e-mail;email_address"` // Also read from "e-mail" or "email_address"
+//	ID   int     `csv:"id,required"`         // Error if the column is missing or a cell is empty or null
 //	Field int                                // Use struct field name as column name
 //
 // Supported field types:
@@ -47,11 +64,57 @@ import (
 //   - uint, uint8, uint16, uint32, uint64
 //   - float32, float64
 //   - bool (accepts: true/false, 1/0, t/f, T/F, TRUE/FALSE)
-//   - pointers to any of the above (nil for empty values)
+//   - pointers to any of the above (nil for empty cells; a quoted "" gives a
+//     non-nil pointer, see MarshalWithOptions and QuoteEmptyFields)
+//   - slices of any of the above except []byte, split on the "split=" tag
+//     option or MultiValueSeparator; an empty cell gives an empty, non-nil slice
 //
 // If a CSV column is not found in the struct, it is ignored.
 // If a struct field is not found in the CSV, it is left with its zero value.
+// If several columns match a field, through its name or an alias, the first
+// one in the header is used. A value that cannot be converted to its field's
+// type is reported as a *DecodeError giving its row, column and header.
 func Unmarshal(data []byte, v interface{}) error {
 	// Fast path: Direct parsing without AST construction (4-5x faster)
-	return fastparser.Unmarshal(data, v)
+	return toDecodeError(fastparser.Unmarshal(data, v))
+}
+
+// UnmarshalWithOptions is like Unmarshal but parses data using the dialect in
+// opts, such as Comma, Comment, LazyQuotes and TrimLeadingSpace, and applies
+// its number formatting and null options when decoding struct fields. A zero
+// Comma defaults to ','.
+// Fields tagged ",offset", ",line" or ",raw" are left zero.
+//
+// Example (European formatting):
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.Comma = ';'
+//	opts.DecimalSeparator = ','
+//	opts.ThousandsSeparator = '.'
+//	err := csv.UnmarshalWithOptions(data, &rows, opts)
+func UnmarshalWithOptions(data []byte, v interface{}, opts ReaderOptions) error {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	node, err := ParseWithOptions(string(data), opts)
+	if err != nil {
+		return err
+	}
+	err = fastparser.UnmarshalRecords(NodeToRecords(node), v, opts.decodeOptions())
+	return toDecodeError(err)
+}
+
+// decodeOptions converts the reader options to the options used to decode
+// struct fields.
+func (o ReaderOptions) decodeOptions() fastparser.DecodeOptions {
+	return fastparser.DecodeOptions{
+		DecimalSeparator:     o.DecimalSeparator,
+		ThousandsSeparator:   o.ThousandsSeparator,
+		CurrencySymbol:       o.CurrencySymbol,
+		StrictNumeric:        o.StrictNumeric,
+		FallbackTag:          o.FallbackTag,
+		NullValues:           o.NullValues,
+		NullValuesIgnoreCase: o.NullValuesIgnoreCase,
+		CaseSensitiveHeaders: o.CaseSensitiveHeaders,
+	}
 }
@@ -1,6 +1,8 @@
 package csv
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -348,3 +350,514 @@ func TestUnmarshalRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+// TestQuoteCharacterRoundTrip tests that fields containing the quote
+// character anywhere are quoted by every writer and read back unchanged
+func TestQuoteCharacterRoundTrip(t *testing.T) {
+	type Quote struct {
+		Text string `csv:"text"`
+		Note string `csv:"note"`
+	}
+
+	original := []Quote{
+		{Text: `a"b`, Note: "interior"},
+		{Text: `"leading`, Note: "leading"},
+		{Text: `trailing"`, Note: "trailing"},
+		{Text: `"both"`, Note: `""`},
+		{Text: `say "hi", then leave`, Note: "with delimiter"},
+	}
+
+	data, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `interior,"a""b"`) {
+		t.Errorf("Marshal() = %q, want interior quote doubled inside a quoted field", data)
+	}
+
+	var result []Quote
+	if err := Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal(%q) error = %v", data, err)
+	}
+	if len(result) != len(original) {
+		t.Fatalf("Round trip: got %d records, want %d", len(result), len(original))
+	}
+	for i := range result {
+		if result[i] != original[i] {
+			t.Errorf("Round trip: record %d = %+v, want %+v", i, result[i], original[i])
+		}
+	}
+
+	// Parse, render and document output must quote the same fields
+	node, err := Parse(string(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	rendered, err := RenderWithOptions(node, DefaultWriterOptions())
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	if string(rendered) != string(data) {
+		t.Errorf("RenderWithOptions() = %q, want %q", rendered, data)
+	}
+
+	doc, err := ParseDocument(string(data))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	out, err := doc.CSV()
+	if err != nil {
+		t.Fatalf("CSV() error = %v", err)
+	}
+	if out != string(data) {
+		t.Errorf("CSV() = %q, want %q", out, data)
+	}
+}
+
+// Embedded types for TestUnmarshalEmbedded
+type embeddedBase struct {
+	ID   int    `csv:"id"`
+	Note string `csv:"note"`
+}
+
+type embeddedAudit struct {
+	embeddedBase
+	CreatedBy string `csv:"created_by"`
+}
+
+type EmbeddedMeta struct {
+	Source string `csv:"source"`
+}
+
+// TestUnmarshalEmbedded tests that fields of embedded structs map to columns
+func TestUnmarshalEmbedded(t *testing.T) {
+	t.Run("one level", func(t *testing.T) {
+		type User struct {
+			embeddedBase
+			Name string `csv:"name"`
+		}
+
+		var got []User
+		if err := Unmarshal([]byte("id,name,note\n1,Alice,admin\n2,Bob,\n"), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		want := []User{
+			{embeddedBase: embeddedBase{ID: 1, Note: "admin"}, Name: "Alice"},
+			{embeddedBase: embeddedBase{ID: 2}, Name: "Bob"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("two levels and pointer", func(t *testing.T) {
+		type Order struct {
+			embeddedAudit
+			*EmbeddedMeta
+			Total float64 `csv:"total"`
+		}
+
+		var got []Order
+		input := "total,created_by,id,source\n9.5,ops,7,web\n"
+		if err := Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("Unmarshal() got %d records, want 1", len(got))
+		}
+		o := got[0]
+		if o.ID != 7 || o.CreatedBy != "ops" || o.Total != 9.5 {
+			t.Errorf("Unmarshal() = %+v, want id 7, created_by ops, total 9.5", o)
+		}
+		if o.EmbeddedMeta == nil || o.Source != "web" {
+			t.Errorf("Unmarshal() embedded pointer = %+v, want source web", o.EmbeddedMeta)
+		}
+	})
+
+	t.Run("outer field wins a name collision", func(t *testing.T) {
+		type Doc struct {
+			embeddedAudit
+			Comment string `csv:"note"`
+		}
+
+		var got []Doc
+		if err := Unmarshal([]byte("id,note\n3,outer\n"), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Comment != "outer" || got[0].Note != "" || got[0].ID != 3 {
+			t.Errorf("Unmarshal() = %+v, want note in the outer field only", got)
+		}
+	})
+
+	t.Run("tagged embedded struct is a single field", func(t *testing.T) {
+		type Row struct {
+			embeddedBase `csv:"-"`
+			Name         string `csv:"name"`
+		}
+
+		var got []Row
+		if err := Unmarshal([]byte("id,name\n1,x\n"), &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != 0 || got[0].Name != "x" {
+			t.Errorf("Unmarshal() = %+v, want the ignored embedded struct left zero", got)
+		}
+	})
+}
+
+// TestUnmarshalWithOptions tests dialect and number formatting options
+func TestUnmarshalWithOptions(t *testing.T) {
+	type Line struct {
+		Item   string  `csv:"item"`
+		Amount float64 `csv:"amount,currency"`
+		Weight float64 `csv:"weight"`
+	}
+
+	opts := DefaultReaderOptions()
+	opts.Comma = ';'
+	opts.DecimalSeparator = ','
+	opts.ThousandsSeparator = '.'
+
+	var got []Line
+	input := "item;amount;weight\nBolt;€1.234,56;2,5\n"
+	if err := UnmarshalWithOptions([]byte(input), &got, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Amount != 1234.56 || got[0].Weight != 2.5 {
+		t.Errorf("UnmarshalWithOptions() = %+v, want amount 1234.56 and weight 2.5", got)
+	}
+}
+
+// TestUnmarshalWithOptionsDialect tests that the configured parser runs
+// before struct mapping
+func TestUnmarshalWithOptionsDialect(t *testing.T) {
+	type Row struct {
+		Name  string `csv:"name"`
+		Count int    `csv:"count"`
+		Note  string `csv:"note"`
+	}
+	want := []Row{{Name: "a", Count: 1, Note: `say "hi"`}, {Name: "b", Count: 2, Note: "x;y"}}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  ReaderOptions
+	}{
+		{
+			name:  "semicolon delimited",
+			input: "name;count;note\na;1;\"say \"\"hi\"\"\"\nb;2;\"x;y\"\n",
+			opts:  ReaderOptions{Comma: ';'},
+		},
+		{
+			name:  "comment lines",
+			input: "# exported 2024-01-01\nname,count,note\na,1,\"say \"\"hi\"\"\"\n# subtotal\nb,2,x;y\n",
+			opts:  ReaderOptions{Comment: '#'},
+		},
+		{
+			name:  "lazy quotes and leading space",
+			input: "name, count, note\na, 1, say \"hi\"\nb, 2, x;y\n",
+			opts:  ReaderOptions{Comma: ',', LazyQuotes: true, TrimLeadingSpace: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Row
+			if err := UnmarshalWithOptions([]byte(tt.input), &got, tt.opts); err != nil {
+				t.Fatalf("UnmarshalWithOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("UnmarshalWithOptions() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestUnmarshalStrictNumeric tests whitespace handling in numeric cells
+func TestUnmarshalStrictNumeric(t *testing.T) {
+	type Row struct {
+		Name string `csv:"name"`
+		N    int    `csv:"n"`
+	}
+	input := []byte("name,n\nA, 42 \n")
+
+	// Without trimming, padded numbers fail to parse as they always have
+	var plain []Row
+	if err := Unmarshal(input, &plain); err == nil {
+		t.Error("Unmarshal() should fail on \" 42 \" without trimming")
+	}
+
+	lenient := DefaultReaderOptions()
+	lenient.TrimLeadingSpace = true
+	lenient.TrimTrailingSpace = true
+	var got []Row
+	if err := UnmarshalWithOptions(input, &got, lenient); err != nil {
+		t.Fatalf("UnmarshalWithOptions() lenient error = %v", err)
+	}
+	if len(got) != 1 || got[0].N != 42 {
+		t.Errorf("UnmarshalWithOptions() lenient = %+v, want N = 42", got)
+	}
+
+	strict := DefaultReaderOptions()
+	strict.StrictNumeric = true
+	var bad []Row
+	if err := UnmarshalWithOptions(input, &bad, strict); err == nil {
+		t.Error("UnmarshalWithOptions() strict should fail on \" 42 \"")
+	}
+
+	// Strict mode also covers currency fields, which otherwise drop spaces
+	type Price struct {
+		Amount float64 `csv:"amount,currency"`
+	}
+	euros := []byte("amount\n\"1.234,56 €\"\n")
+	strict.DecimalSeparator = ','
+	var price []Price
+	if err := UnmarshalWithOptions(euros, &price, strict); err == nil {
+		t.Error("UnmarshalWithOptions() strict should fail on \"1.234,56 €\"")
+	}
+	strict.StrictNumeric = false
+	if err := UnmarshalWithOptions(euros, &price, strict); err != nil || len(price) != 1 || price[0].Amount != 1234.56 {
+		t.Errorf("UnmarshalWithOptions() lenient currency = %+v, %v, want 1234.56", price, err)
+	}
+}
+
+// TestUnmarshalFallbackTag tests decoding structs tagged only for JSON
+func TestUnmarshalFallbackTag(t *testing.T) {
+	type User struct {
+		ID       int    `json:"id"`
+		Email    string `json:"email_address,omitempty"`
+		Password string `json:"-"`
+		Role     string `csv:"user_role" json:"role"`
+		Note     string
+	}
+
+	input := []byte("id,email_address,password,user_role,note\n7,a@example.com,secret,admin,hi\n")
+	opts := DefaultReaderOptions()
+	opts.FallbackTag = "json"
+
+	var got []User
+	if err := UnmarshalWithOptions(input, &got, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+
+	want := User{ID: 7, Email: "a@example.com", Role: "admin", Note: "hi"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("UnmarshalWithOptions() = %+v, want %+v", got, want)
+	}
+
+	// Without the fallback, json names are not used
+	var plain []User
+	if err := UnmarshalWithOptions(input, &plain, DefaultReaderOptions()); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if len(plain) != 1 || plain[0].Email != "" || plain[0].Password != "secret" {
+		t.Errorf("UnmarshalWithOptions() without fallback = %+v", plain)
+	}
+}
+
+// TestUnmarshalCaseSensitiveHeaders tests exact header matching against the
+// default case-insensitive matching
+func TestUnmarshalCaseSensitiveHeaders(t *testing.T) {
+	type Quote struct {
+		ID        string  `csv:"ID"`
+		Price     float64 `csv:"Price"`
+		LowerCase float64 `csv:"price"`
+	}
+
+	input := []byte("id,Price,price\nq1,9.5,7.25\n")
+
+	opts := DefaultReaderOptions()
+	opts.CaseSensitiveHeaders = true
+	var exact []Quote
+	if err := UnmarshalWithOptions(input, &exact, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	want := Quote{Price: 9.5, LowerCase: 7.25}
+	if len(exact) != 1 || exact[0] != want {
+		t.Errorf("UnmarshalWithOptions() case-sensitive = %+v, want %+v", exact, want)
+	}
+
+	// By default "ID" matches "id", and both price columns match one field
+	var folded []Quote
+	if err := UnmarshalWithOptions(input, &folded, DefaultReaderOptions()); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if len(folded) != 1 || folded[0].ID != "q1" {
+		t.Errorf("UnmarshalWithOptions() default = %+v, want ID q1", folded)
+	}
+}
+
+// TestUnmarshalAliases tests that vendor spellings of a column all decode
+// into the aliased field
+func TestUnmarshalAliases(t *testing.T) {
+	type Contact struct {
+		Name  string `csv:"name"`
+		Email string `csv:"email,alias=e-mail;email_address"`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "tag name", input: "name,email\nAlice,a@example.com\n", want: "a@example.com"},
+		{name: "alias", input: "name,e-mail\nAlice,a@example.com\n", want: "a@example.com"},
+		{name: "alias in other case", input: "Email_Address,Name\na@example.com,Alice\n", want: "a@example.com"},
+		{name: "first matching header wins", input: "name,email_address,Email\nAlice,first@example.com,second@example.com\n", want: "first@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Contact
+			if err := Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if len(got) != 1 || got[0].Name != "Alice" || got[0].Email != tt.want {
+				t.Errorf("Unmarshal() = %+v, want Email %q", got, tt.want)
+			}
+			if err := ValidateHeader([]byte(tt.input), &got, DefaultReaderOptions()); err != nil {
+				t.Errorf("ValidateHeader() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestUnmarshalRequired tests that a required field fails on a missing
+// header column or an empty cell
+func TestUnmarshalRequired(t *testing.T) {
+	type Order struct {
+		ID    int    `csv:"id,required"`
+		Notes string `csv:"notes"`
+	}
+
+	var got []Order
+	if err := Unmarshal([]byte("id,notes\n1,\n2,rush\n"), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	err := Unmarshal([]byte("notes\nrush\n"), &got)
+	if err == nil || !strings.Contains(err.Error(), `"id"`) {
+		t.Errorf("Unmarshal() missing column error = %v, want it to name \"id\"", err)
+	}
+
+	err = UnmarshalWithOptions([]byte("id;notes\n1;a\n;b\n"), &got, ReaderOptions{Comma: ';'})
+	if err == nil || !strings.Contains(err.Error(), "ID is empty at row 2") {
+		t.Errorf("UnmarshalWithOptions() empty cell error = %v, want empty ID at row 2", err)
+	}
+
+	// A null token is a missing value
+	opts := DefaultReaderOptions()
+	opts.NullValues = []string{"NULL"}
+	err = UnmarshalWithOptions([]byte("id,notes\n1,a\nNULL,b\n"), &got, opts)
+	if err == nil || !strings.Contains(err.Error(), "ID is null at row 2") {
+		t.Errorf("UnmarshalWithOptions() null cell error = %v, want null ID at row 2", err)
+	}
+}
+
+// TestUnmarshalSkipTag tests that fields tagged "-" are never decoded
+func TestUnmarshalSkipTag(t *testing.T) {
+	type Row struct {
+		Name   string `csv:"name"`
+		Secret string `csv:"-"`
+	}
+
+	var got []Row
+	if err := Unmarshal([]byte("name,secret\nAlice,hidden\n"), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Secret != "" {
+		t.Errorf("Unmarshal() = %+v, want Secret left empty", got)
+	}
+}
+
+// TestUnmarshalBOM tests that a leading byte order mark is skipped
+func TestUnmarshalBOM(t *testing.T) {
+	type Person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+
+	var got []Person
+	if err := Unmarshal([]byte("\ufeffname,age\nAlice,30"), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "Alice" || got[0].Age != 30 {
+		t.Errorf("Unmarshal() = %+v, want [{Alice 30}]", got)
+	}
+
+	// A BOM in the middle of the data is part of the field value
+	if err := Unmarshal([]byte("name,age\n\ufeffBob,25"), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "\ufeffBob" {
+		t.Errorf("Unmarshal() = %+v, want name with embedded BOM", got)
+	}
+
+	for name, parse := range map[string]func(string) ([][]string, error){
+		"Parse": func(s string) ([][]string, error) {
+			node, err := Parse(s)
+			return NodeToRecords(node), err
+		},
+		"ParseReader": func(s string) ([][]string, error) {
+			node, err := ParseReader(strings.NewReader(s))
+			return NodeToRecords(node), err
+		},
+	} {
+		records, err := parse("\ufeffname,age\nAlice,30")
+		if err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		if records[0][0] != "name" {
+			t.Errorf("%s() header = %q, want \"name\"", name, records[0][0])
+		}
+	}
+}
+
+// TestUnmarshalNullValues tests that null tokens decode to zero values and nil pointers
+func TestUnmarshalNullValues(t *testing.T) {
+	type Row struct {
+		Name  string  `csv:"name"`
+		Count int     `csv:"count"`
+		Score *int    `csv:"score"`
+		Ratio float64 `csv:"ratio"`
+	}
+	input := []byte("name,count,score,ratio\nA,NA,NA,-\nB,3,7,0.5\nna,NULL,NULL,1\n")
+
+	opts := DefaultReaderOptions()
+	opts.NullValues = []string{"NA", "NULL", "-"}
+
+	var got []Row
+	if err := UnmarshalWithOptions(input, &got, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("UnmarshalWithOptions() got %d rows, want 3", len(got))
+	}
+	if got[0].Count != 0 || got[0].Score != nil || got[0].Ratio != 0 {
+		t.Errorf("row 0 = %+v, want zero count, nil score, zero ratio", got[0])
+	}
+	if got[1].Count != 3 || got[1].Score == nil || *got[1].Score != 7 {
+		t.Errorf("row 1 = %+v, want count 3 and score 7", got[1])
+	}
+	if got[2].Name != "na" {
+		t.Errorf("row 2 name = %q, want \"na\" (match is case-sensitive)", got[2].Name)
+	}
+
+	// "n/a" is not a null token, so the score fails to parse
+	input = []byte("name,score\nA,n/a\n")
+	var bad []Row
+	if err := UnmarshalWithOptions(input, &bad, opts); err == nil {
+		t.Error("UnmarshalWithOptions() should fail on an unlisted token")
+	}
+
+	opts.NullValues = []string{"n/a"}
+	opts.NullValuesIgnoreCase = true
+	input = []byte("name,count,score\nN/A,N/A,n/A\n")
+	var folded []Row
+	if err := UnmarshalWithOptions(input, &folded, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() ignore case error = %v", err)
+	}
+	if folded[0].Name != "" || folded[0].Count != 0 || folded[0].Score != nil {
+		t.Errorf("UnmarshalWithOptions() ignore case = %+v, want all fields empty", folded[0])
+	}
+}
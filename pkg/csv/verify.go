@@ -0,0 +1,59 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shapestone/shape-core/pkg/ast"
+)
+
+// RowCountError reports a data record count that differs from the count the
+// producer declared, as returned by VerifyRowCount.
+type RowCountError struct {
+	Expected int // declared number of data records
+	Actual   int // data records found
+}
+
+func (e *RowCountError) Error() string {
+	return fmt.Sprintf("csv: row count mismatch: expected %d data records, found %d", e.Expected, e.Actual)
+}
+
+// VerifyRowCount parses data with opts and checks that it holds exactly expected
+// data records, catching truncated or padded transfers when the producer
+// declares the count in a manifest or footer. With opts.HasHeader the first
+// record is the header and is not counted. Blank and comment lines are not
+// records.
+//
+// Returns a *RowCountError if the counts differ, or the parse error if data is
+// not valid CSV.
+//
+// Example:
+//
+//	opts := csv.DefaultReaderOptions()
+//	opts.HasHeader = true
+//	if err := csv.VerifyRowCount(data, manifest.Rows, opts); err != nil {
+//	    return err // e.g. expected 1000 data records, found 998
+//	}
+func VerifyRowCount(data []byte, expected int, opts ReaderOptions) error {
+	if expected < 0 {
+		return errors.New("csv: VerifyRowCount: negative expected count")
+	}
+
+	node, err := ParseWithOptions(string(data), opts)
+	if err != nil {
+		return err
+	}
+
+	actual := 0
+	if records, ok := node.(*ast.ArrayDataNode); ok {
+		actual = records.Len()
+	}
+	if opts.HasHeader && actual > 0 {
+		actual--
+	}
+
+	if actual != expected {
+		return &RowCountError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package csv_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestVerifyRowCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		hasHeader  bool
+		expected   int
+		wantActual int // -1 when the counts match
+	}{
+		{name: "header match", data: "name,age\nAlice,30\nBob,25\n", hasHeader: true, expected: 2, wantActual: -1},
+		{name: "header truncated", data: "name,age\nAlice,30\n", hasHeader: true, expected: 2, wantActual: 1},
+		{name: "header only", data: "name,age\n", hasHeader: true, expected: 0, wantActual: -1},
+		{name: "no header match", data: "Alice,30\nBob,25\n", expected: 2, wantActual: -1},
+		{name: "no header extra", data: "Alice,30\nBob,25\nCarol,41\n", expected: 2, wantActual: 3},
+		{name: "blank lines ignored", data: "Alice,30\n\nBob,25\n\n", expected: 2, wantActual: -1},
+		{name: "empty", data: "", hasHeader: true, expected: 1, wantActual: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := csv.DefaultReaderOptions()
+			opts.HasHeader = tt.hasHeader
+
+			err := csv.VerifyRowCount([]byte(tt.data), tt.expected, opts)
+			if tt.wantActual < 0 {
+				if err != nil {
+					t.Errorf("VerifyRowCount() error = %v", err)
+				}
+				return
+			}
+
+			var countErr *csv.RowCountError
+			if !errors.As(err, &countErr) {
+				t.Fatalf("VerifyRowCount() error = %v, want *csv.RowCountError", err)
+			}
+			if countErr.Expected != tt.expected || countErr.Actual != tt.wantActual {
+				t.Errorf("RowCountError = {Expected: %d, Actual: %d}, want {Expected: %d, Actual: %d}",
+					countErr.Expected, countErr.Actual, tt.expected, tt.wantActual)
+			}
+		})
+	}
+}
+
+func TestVerifyRowCountParseError(t *testing.T) {
+	err := csv.VerifyRowCount([]byte("a,\"open\n"), 1, csv.DefaultReaderOptions())
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("VerifyRowCount() error = %v, want *csv.ParseError", err)
+	}
+}
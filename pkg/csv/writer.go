@@ -0,0 +1,391 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Writer writes CSV records to an io.Writer.
+// Output is buffered; call Flush to guarantee that all records have been
+// written to the underlying io.Writer.
+//
+// Example usage:
+//
+//	w := csv.NewWriter(os.Stdout, csv.DefaultWriterOptions())
+//	w.Write([]string{"name", "age"})
+//	w.Write([]string{"Alice", "30"})
+//	if err := w.Flush(); err != nil {
+//	    // handle error
+//	}
+type Writer struct {
+	w       *bufio.Writer
+	opts    WriterOptions
+	line    bytes.Buffer // scratch buffer for the record being encoded
+	pending int          // records written since the last flush
+
+	structType   reflect.Type // struct type written by WriteStruct, once known
+	structFields []fieldEntry // column order for structType
+
+	headerSeen bool   // the header row is known
+	force      []bool // columns quoted by ForceQuoteColumns
+
+	mapColumns map[string]int      // column index by key for WriteMap, once used
+	mapHeader  []string            // union of WriteMap keys in column order
+	mapRows    []map[string]string // WriteMap rows buffered until Flush
+}
+
+// NewWriter creates a new Writer that writes CSV to w using the given options.
+// A zero Comma defaults to ','. With ForceQuoteColumns, the header row is the
+// struct columns for WriteStruct, the header passed to SetHeader, or else the
+// first record passed to Write unless OmitHeader is set.
+func NewWriter(w io.Writer, opts WriterOptions) *Writer {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	return &Writer{
+		w:    bufio.NewWriter(w),
+		opts: opts,
+	}
+}
+
+// Write writes a single CSV record along with any necessary quoting.
+// When FlushEveryN is positive, the buffer is flushed after every N records.
+func (w *Writer) Write(record []string) error {
+	if w.mapColumns != nil {
+		return errors.New("csv: Write cannot be mixed with WriteMap")
+	}
+	if err := w.encode(record); err != nil {
+		return err
+	}
+	return w.writeLine()
+}
+
+// SetHeader declares the header row that ForceQuoteColumns matches columns
+// against, without writing it. Use it with OmitHeader when appending records
+// to a file that already has a header row. It must be called before the first
+// record is written.
+//
+// Example:
+//
+//	opts := csv.DefaultWriterOptions()
+//	opts.OmitHeader = true
+//	opts.ForceQuoteColumns = []string{"zip"}
+//	w := csv.NewWriter(f, opts)
+//	w.SetHeader([]string{"name", "zip"})
+//	w.Write([]string{"Alice", "02134"}) // Alice,"02134"
+func (w *Writer) SetHeader(header []string) error {
+	if w.headerSeen || w.mapColumns != nil {
+		return errors.New("csv: SetHeader called after records were written")
+	}
+	force, err := forceQuoteMask(header, w.opts)
+	if err != nil {
+		return err
+	}
+	w.force = force
+	w.headerSeen = true
+	return nil
+}
+
+// encode encodes record into the scratch buffer. Unless OmitHeader is set,
+// the first record is taken as the header row for ForceQuoteColumns.
+func (w *Writer) encode(record []string) error {
+	if !w.headerSeen {
+		if w.opts.OmitHeader && len(w.opts.ForceQuoteColumns) > 0 {
+			return errors.New("csv: ForceQuoteColumns with OmitHeader requires SetHeader")
+		}
+		force, err := forceQuoteMask(record, w.opts)
+		if err != nil {
+			return err
+		}
+		w.force = force
+		w.headerSeen = true
+	}
+
+	w.line.Reset()
+	writeRecordWithOptions(&w.line, record, w.opts, w.force)
+	return nil
+}
+
+// writeLine writes the encoded record in the scratch buffer and applies
+// FlushEveryN.
+func (w *Writer) writeLine() error {
+	if _, err := w.w.Write(w.line.Bytes()); err != nil {
+		return err
+	}
+
+	w.pending++
+	if w.opts.FlushEveryN > 0 && w.pending >= w.opts.FlushEveryN {
+		return w.Flush()
+	}
+	return nil
+}
+
+// WriteStruct writes v, a struct or pointer to struct, as a single CSV record.
+// Columns follow the same names and order as Marshal. Unless OmitHeader is
+// set, a header row is written before the first struct, and it is an error to
+// call WriteStruct after Write or SetHeader. Every call must pass the
+// same struct type; omitempty fields are written as empty cells so that all
+// rows keep the same columns.
+func (w *Writer) WriteStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("csv: WriteStruct(nil %s)", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		if !rv.IsValid() {
+			return fmt.Errorf("csv: WriteStruct(nil)")
+		}
+		return fmt.Errorf("csv: WriteStruct expects struct, got %s", rv.Type())
+	}
+	if w.mapColumns != nil {
+		return errors.New("csv: WriteStruct cannot be mixed with WriteMap")
+	}
+
+	if w.structType == nil {
+		if w.headerSeen && !w.opts.OmitHeader {
+			return errors.New("csv: WriteStruct header would follow other records; set OmitHeader")
+		}
+		fields := marshalFields(rv.Type())
+		force, err := markForceQuote(fields, w.opts)
+		if err != nil {
+			return err
+		}
+		if !w.headerSeen {
+			w.force = force
+			w.headerSeen = true
+		}
+		w.structType = rv.Type()
+		w.structFields = fields
+
+		if !w.opts.OmitHeader {
+			header := make([]string, len(fields))
+			for i, field := range fields {
+				header[i] = field.name
+			}
+			if err := w.Write(header); err != nil {
+				return err
+			}
+		}
+	} else if rv.Type() != w.structType {
+		return fmt.Errorf("csv: WriteStruct expects %s, got %s", w.structType, rv.Type())
+	}
+
+	w.line.Reset()
+	w.line.WriteString(w.opts.RecordPrefix)
+	if err := writeStructRow(&w.line, rv, w.structFields, w.opts); err != nil {
+		return err
+	}
+	writeRecordEnd(&w.line, w.opts)
+	return w.writeLine()
+}
+
+// WriteMap buffers row, a record keyed by column name, until the next Flush.
+// The columns are the union of the keys of all rows, in the order they are
+// first seen; keys that first appear in the same row are ordered by name.
+// Flush writes the header row, unless OmitHeader is set, followed by every
+// buffered row, with empty cells for the columns a row lacks. This suits
+// sparse data whose columns are not known until every record has been seen.
+//
+// Every row is held in memory until Flush, so memory grows with the number of
+// rows written, and FlushEveryN does not apply. If Flush fails, the rows stay
+// buffered. After the first successful Flush the header is fixed: a later row
+// with a key outside it is an error. WriteMap cannot be mixed with Write or
+// WriteStruct on the same Writer.
+//
+// Example:
+//
+//	w := csv.NewWriter(os.Stdout, csv.DefaultWriterOptions())
+//	w.WriteMap(map[string]string{"name": "Alice"})
+//	w.WriteMap(map[string]string{"name": "Bob", "email": "bob@example.com"})
+//	w.Flush()
+//	// name,email
+//	// Alice,
+//	// Bob,bob@example.com
+func (w *Writer) WriteMap(row map[string]string) error {
+	if w.mapColumns == nil {
+		if w.headerSeen {
+			return errors.New("csv: WriteMap cannot be mixed with Write or WriteStruct")
+		}
+		w.mapColumns = make(map[string]int)
+	}
+
+	var added []string
+	for key := range row {
+		if _, ok := w.mapColumns[key]; ok {
+			continue
+		}
+		if w.headerSeen {
+			return fmt.Errorf("csv: WriteMap: column %q is not in the header fixed by Flush", key)
+		}
+		added = append(added, key)
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		w.mapColumns[key] = len(w.mapHeader)
+		w.mapHeader = append(w.mapHeader, key)
+	}
+
+	w.mapRows = append(w.mapRows, row)
+	return nil
+}
+
+// WriteAll writes records to w as a Writer with opts would, quoting fields
+// as needed and ending each record with the configured line terminator, then
+// flushes. An empty records slice writes nothing. It mirrors
+// encoding/csv.Writer.WriteAll for callers holding plain [][]string rather
+// than an AST; see RenderWithOptions for the latter.
+//
+// Example:
+//
+//	opts := csv.DefaultWriterOptions()
+//	opts.UseCRLF = true
+//	err := csv.WriteAll(os.Stdout, [][]string{{"name", "note"}, {"Alice", "a, b"}}, opts)
+func WriteAll(w io.Writer, records [][]string, opts WriterOptions) error {
+	cw := NewWriter(w, opts)
+	for _, record := range records {
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer, including the
+// header and rows buffered by WriteMap.
+func (w *Writer) Flush() error {
+	if err := w.flushMaps(); err != nil {
+		return err
+	}
+	w.pending = 0
+	return w.w.Flush()
+}
+
+// flushMaps encodes the rows buffered by WriteMap, preceded on the first call
+// by the header row unless OmitHeader is set. The rows are dropped only once
+// they have been written.
+func (w *Writer) flushMaps() error {
+	if w.mapColumns == nil || (w.headerSeen && len(w.mapRows) == 0) {
+		return nil
+	}
+
+	force := w.force
+	if !w.headerSeen {
+		var err error
+		if force, err = forceQuoteMask(w.mapHeader, w.opts); err != nil {
+			return err
+		}
+	}
+
+	w.line.Reset()
+	if !w.headerSeen && !w.opts.OmitHeader {
+		writeRecordWithOptions(&w.line, w.mapHeader, w.opts, force)
+	}
+	fields := make([]string, len(w.mapHeader))
+	for _, row := range w.mapRows {
+		clear(fields)
+		for key, value := range row {
+			fields[w.mapColumns[key]] = value
+		}
+		writeRecordWithOptions(&w.line, fields, w.opts, force)
+	}
+	if _, err := w.w.Write(w.line.Bytes()); err != nil {
+		return err
+	}
+
+	w.force = force
+	w.headerSeen = true
+	w.mapRows = nil
+	return nil
+}
+
+// Buffered returns the number of bytes that have been written to the Writer
+// but not yet flushed to the underlying io.Writer.
+func (w *Writer) Buffered() int {
+	return w.w.Buffered()
+}
+
+// writeRecordWithOptions encodes a single record, including any record prefix
+// and suffix and its line terminator, into buf using the given writer options.
+// Fields whose force entry is true are always quoted; force may be nil.
+func writeRecordWithOptions(buf *bytes.Buffer, fields []string, opts WriterOptions, force []bool) {
+	buf.WriteString(opts.RecordPrefix)
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteRune(opts.Comma)
+		}
+		writeFieldWithOptions(buf, field, opts, i < len(force) && force[i])
+	}
+	writeRecordEnd(buf, opts)
+}
+
+// writeRecordEnd writes the record suffix and line terminator that end a record.
+func writeRecordEnd(buf *bytes.Buffer, opts WriterOptions) {
+	buf.WriteString(opts.RecordSuffix)
+	if opts.UseCRLF {
+		buf.WriteString("\r\n")
+	} else {
+		buf.WriteByte('\n')
+	}
+}
+
+// QuoteField returns value encoded as a single CSV field exactly as a Writer
+// with opts would write it: quoted with opts.Quote when it contains the
+// delimiter, the quote character, or a line break, with embedded quotes
+// doubled, and written as "" when empty if QuoteEmptyFields is set. A zero
+// Comma defaults to ','. ForceQuoteColumns, RecordPrefix and RecordSuffix
+// apply to whole records and are ignored.
+//
+// Example:
+//
+//	line := csv.QuoteField("Smith, Jane", opts) + "," + csv.QuoteField(note, opts)
+//	// "Smith, Jane",...
+func QuoteField(value string, opts WriterOptions) string {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	var buf bytes.Buffer
+	writeFieldWithOptions(&buf, value, opts, false)
+	return buf.String()
+}
+
+// writeFieldWithOptions writes a single field, quoting it with opts.Quote when
+// required or forced. With QuoteEmptyFields an empty string is written as "".
+func writeFieldWithOptions(buf *bytes.Buffer, value string, opts WriterOptions, force bool) {
+	quote := opts.quoteChar()
+	if force || (value == "" && opts.QuoteEmptyFields) {
+		writeQuotedField(buf, value, quote)
+		return
+	}
+	writeCSVFieldWithQuote(buf, value, opts.Comma, quote)
+}
+
+// forceQuoteMask reports, for each column in headers, whether it is listed in
+// opts.ForceQuoteColumns. It returns nil if no columns are listed and an error
+// if a listed column is not in headers.
+func forceQuoteMask(headers []string, opts WriterOptions) ([]bool, error) {
+	if len(opts.ForceQuoteColumns) == 0 {
+		return nil, nil
+	}
+	mask := make([]bool, len(headers))
+	for _, name := range opts.ForceQuoteColumns {
+		found := false
+		for i, header := range headers {
+			if header == name {
+				mask[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("csv: ForceQuoteColumns: unknown column %q", name)
+		}
+	}
+	return mask, nil
+}
@@ -0,0 +1,646 @@
+package csv_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shapestone/shape-csv/pkg/csv"
+)
+
+func TestWriter_Write(t *testing.T) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out, csv.DefaultWriterOptions())
+
+	records := [][]string{
+		{"name", "note"},
+		{"Alice", "says \"hi\""},
+		{"Bob", "a,b"},
+	}
+	for _, r := range records {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "name,note\nAlice,\"says \"\"hi\"\"\"\nBob,\"a,b\"\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriter_FlushEveryN(t *testing.T) {
+	var out bytes.Buffer
+	opts := csv.DefaultWriterOptions()
+	opts.FlushEveryN = 2
+	w := csv.NewWriter(&out, opts)
+
+	line := "a,b\n"
+
+	if err := w.Write([]string{"a", "b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("after 1 record, sink has %d bytes, want 0", out.Len())
+	}
+	if w.Buffered() != len(line) {
+		t.Errorf("after 1 record, Buffered() = %d, want %d", w.Buffered(), len(line))
+	}
+
+	if err := w.Write([]string{"a", "b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.String() != line+line {
+		t.Errorf("after 2 records, sink = %q, want %q", out.String(), line+line)
+	}
+	if w.Buffered() != 0 {
+		t.Errorf("after 2 records, Buffered() = %d, want 0", w.Buffered())
+	}
+
+	if err := w.Write([]string{"a", "b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.Len() != 2*len(line) {
+		t.Errorf("after 3 records, sink has %d bytes, want %d", out.Len(), 2*len(line))
+	}
+	if w.Buffered() != len(line) {
+		t.Errorf("after 3 records, Buffered() = %d, want %d", w.Buffered(), len(line))
+	}
+}
+
+func TestWriter_Options(t *testing.T) {
+	var out bytes.Buffer
+	opts := csv.DefaultWriterOptions()
+	opts.Comma = ';'
+	opts.UseCRLF = true
+	w := csv.NewWriter(&out, opts)
+
+	if err := w.Write([]string{"a;b", "c"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "\"a;b\";c\r\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+type writerPerson struct {
+	Name  string `csv:"name"`
+	Age   int    `csv:"age"`
+	Email string `csv:"email,omitempty"`
+}
+
+func TestWriter_WriteStruct(t *testing.T) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out, csv.DefaultWriterOptions())
+
+	if err := w.WriteStruct(writerPerson{Name: "Alice", Age: 30, Email: "a@example.com"}); err != nil {
+		t.Fatalf("WriteStruct() error = %v", err)
+	}
+	if err := w.Write([]string{"# raw", "row", ""}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteStruct(&writerPerson{Name: "Bob, Jr.", Age: 25}); err != nil {
+		t.Fatalf("WriteStruct() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output written before Flush: %q", out.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "age,email,name\n30,a@example.com,Alice\n# raw,row,\n25,,\"Bob, Jr.\"\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	// A struct header cannot follow rows written with Write
+	out.Reset()
+	w = csv.NewWriter(&out, csv.DefaultWriterOptions())
+	if err := w.Write([]string{"x", "y"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteStruct(writerPerson{Name: "Alice", Age: 30}); err == nil {
+		t.Error("WriteStruct() after Write expected error")
+	}
+
+	// With OmitHeader, a header written with Write is followed by struct rows
+	out.Reset()
+	opts := csv.DefaultWriterOptions()
+	opts.OmitHeader = true
+	w = csv.NewWriter(&out, opts)
+	if err := w.Write([]string{"age", "email", "name"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteStruct(writerPerson{Name: "Alice", Age: 30}); err != nil {
+		t.Fatalf("WriteStruct() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := out.String(), "age,email,name\n30,,Alice\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestWriter_WriteStructNoHeader(t *testing.T) {
+	var out bytes.Buffer
+	opts := csv.DefaultWriterOptions()
+	opts.OmitHeader = true
+	w := csv.NewWriter(&out, opts)
+
+	if err := w.WriteStruct(writerPerson{Name: "Alice", Age: 30}); err != nil {
+		t.Fatalf("WriteStruct() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := out.String(), "30,,Alice\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestWriter_WriteStructErrors(t *testing.T) {
+	w := csv.NewWriter(&bytes.Buffer{}, csv.DefaultWriterOptions())
+
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"nil", nil},
+		{"nil pointer", (*writerPerson)(nil)},
+		{"not a struct", []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := w.WriteStruct(tt.v); err == nil {
+				t.Error("WriteStruct() expected error")
+			}
+		})
+	}
+
+	if err := w.WriteStruct(writerPerson{Name: "Alice"}); err != nil {
+		t.Fatalf("WriteStruct() error = %v", err)
+	}
+	type other struct{ ID int }
+	if err := w.WriteStruct(other{ID: 1}); err == nil {
+		t.Error("WriteStruct() with a different struct type expected error")
+	}
+}
+
+func TestWriter_WriteMap(t *testing.T) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out, csv.DefaultWriterOptions())
+
+	rows := []map[string]string{
+		{"name": "Alice", "age": "30"},
+		{"name": "Bob"},
+		{"name": "Carol", "email": "carol@example.com", "city": "Oslo"},
+	}
+	for _, row := range rows {
+		if err := w.WriteMap(row); err != nil {
+			t.Fatalf("WriteMap() error = %v", err)
+		}
+	}
+	if out.Len() != 0 || w.Buffered() != 0 {
+		t.Errorf("WriteMap wrote %q before Flush", out.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "age,name,city,email\n" +
+		"30,Alice,,\n" +
+		",Bob,,\n" +
+		",Carol,Oslo,carol@example.com\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	// The header is fixed by the first Flush
+	if err := w.WriteMap(map[string]string{"name": "Dave"}); err != nil {
+		t.Fatalf("WriteMap() error = %v", err)
+	}
+	if err := w.WriteMap(map[string]string{"phone": "555"}); err == nil {
+		t.Error("WriteMap() with a key outside the flushed header expected error")
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := out.String(); got != want+",Dave,,\n" {
+		t.Errorf("output after second Flush = %q, want %q", got, want+",Dave,,\n")
+	}
+}
+
+func TestWriter_WriteMapOptions(t *testing.T) {
+	t.Run("OmitHeader", func(t *testing.T) {
+		opts := csv.DefaultWriterOptions()
+		opts.OmitHeader = true
+		opts.ForceQuoteColumns = []string{"zip"}
+
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		if err := w.WriteMap(map[string]string{"name": "Alice", "zip": "02134"}); err != nil {
+			t.Fatalf("WriteMap() error = %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		if got, want := out.String(), "Alice,\"02134\"\n"; got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("rows kept after failed Flush", func(t *testing.T) {
+		opts := csv.DefaultWriterOptions()
+		opts.ForceQuoteColumns = []string{"zip"}
+
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		if err := w.WriteMap(map[string]string{"name": "Alice"}); err != nil {
+			t.Fatalf("WriteMap() error = %v", err)
+		}
+		if err := w.Flush(); err == nil {
+			t.Fatal("Flush() expected error for unknown ForceQuoteColumns column")
+		}
+		if err := w.WriteMap(map[string]string{"name": "Bob", "zip": "10001"}); err != nil {
+			t.Fatalf("WriteMap() error = %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		want := "name,\"zip\"\nAlice,\"\"\nBob,\"10001\"\n"
+		if got := out.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestWriter_WriteMapMixed(t *testing.T) {
+	w := csv.NewWriter(&bytes.Buffer{}, csv.DefaultWriterOptions())
+	if err := w.Write([]string{"a"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteMap(map[string]string{"a": "1"}); err == nil {
+		t.Error("WriteMap() after Write expected error")
+	}
+
+	w = csv.NewWriter(&bytes.Buffer{}, csv.DefaultWriterOptions())
+	if err := w.WriteMap(map[string]string{"a": "1"}); err != nil {
+		t.Fatalf("WriteMap() error = %v", err)
+	}
+	if err := w.Write([]string{"a"}); err == nil {
+		t.Error("Write() after WriteMap expected error")
+	}
+	if err := w.WriteStruct(writerPerson{Name: "Alice"}); err == nil {
+		t.Error("WriteStruct() after WriteMap expected error")
+	}
+}
+
+func TestWriterOptions_ForceQuoteColumns(t *testing.T) {
+	opts := csv.DefaultWriterOptions()
+	opts.ForceQuoteColumns = []string{"zip", "note"}
+
+	t.Run("Writer", func(t *testing.T) {
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		for _, r := range [][]string{{"name", "zip", "note"}, {"Alice", "02134", "a,b"}, {"Bob", "", "ok"}} {
+			if err := w.Write(r); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		want := "name,\"zip\",\"note\"\nAlice,\"02134\",\"a,b\"\nBob,\"\",\"ok\"\n"
+		if got := out.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		doc := csv.NewDocument().
+			SetHeaders([]string{"name", "zip", "note"}).
+			AddRecord([]string{"Alice", "02134", "hi"})
+		got, err := io.ReadAll(doc.Reader(opts))
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		want := "name,\"zip\",\"note\"\nAlice,\"02134\",\"hi\"\n"
+		if string(got) != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("RenderWithOptions", func(t *testing.T) {
+		node, err := csv.RecordsToNode([][]string{{"zip", "note", "name"}, {"02134", "hi", "Alice"}})
+		if err != nil {
+			t.Fatalf("RecordsToNode() error = %v", err)
+		}
+		got, err := csv.RenderWithOptions(node, opts)
+		if err != nil {
+			t.Fatalf("RenderWithOptions() error = %v", err)
+		}
+		want := "\"zip\",\"note\",name\n\"02134\",\"hi\",Alice\n"
+		if string(got) != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		type row struct {
+			Name string `csv:"name"`
+			Zip  string `csv:"zip"`
+			Note string `csv:"note"`
+		}
+		got, err := csv.MarshalWithOptions([]row{{"Alice", "02134", "hi"}}, opts)
+		if err != nil {
+			t.Fatalf("MarshalWithOptions() error = %v", err)
+		}
+		want := "name,\"note\",\"zip\"\nAlice,\"hi\",\"02134\"\n"
+		if string(got) != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestWriterOptions_ForceQuoteColumnsOmitHeader(t *testing.T) {
+	opts := csv.DefaultWriterOptions()
+	opts.OmitHeader = true
+	opts.ForceQuoteColumns = []string{"zip"}
+
+	t.Run("SetHeader", func(t *testing.T) {
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		if err := w.SetHeader([]string{"name", "zip"}); err != nil {
+			t.Fatalf("SetHeader() error = %v", err)
+		}
+		for _, r := range [][]string{{"Alice", "02134"}, {"Bob", "10001"}} {
+			if err := w.Write(r); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		want := "Alice,\"02134\"\nBob,\"10001\"\n"
+		if got := out.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		w := csv.NewWriter(&bytes.Buffer{}, opts)
+		if err := w.Write([]string{"Alice", "zip"}); err == nil {
+			t.Error("Write() expected error without SetHeader")
+		}
+	})
+
+	t.Run("WriteStruct", func(t *testing.T) {
+		type row struct {
+			Name string `csv:"name"`
+			Zip  string `csv:"zip"`
+		}
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		if err := w.WriteStruct(row{"Alice", "02134"}); err != nil {
+			t.Fatalf("WriteStruct() error = %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		want := "Alice,\"02134\"\n"
+		if got := out.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("SetHeader after Write", func(t *testing.T) {
+		w := csv.NewWriter(&bytes.Buffer{}, csv.DefaultWriterOptions())
+		if err := w.Write([]string{"name"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := w.SetHeader([]string{"name"}); err == nil {
+			t.Error("SetHeader() after Write expected error")
+		}
+	})
+}
+
+func TestWriterOptions_ForceQuoteColumnsUnknown(t *testing.T) {
+	opts := csv.DefaultWriterOptions()
+	opts.ForceQuoteColumns = []string{"phone"}
+
+	w := csv.NewWriter(&bytes.Buffer{}, opts)
+	if err := w.Write([]string{"name", "zip"}); err == nil {
+		t.Error("Write() expected error for unknown column")
+	}
+
+	doc := csv.NewDocument().SetHeaders([]string{"name"}).AddRecord([]string{"Alice"})
+	if _, err := io.ReadAll(doc.Reader(opts)); err == nil {
+		t.Error("Document.Reader() expected error for unknown column")
+	}
+
+	node, _ := csv.RecordsToNode([][]string{{"name"}, {"Alice"}})
+	if _, err := csv.RenderWithOptions(node, opts); err == nil {
+		t.Error("RenderWithOptions() expected error for unknown column")
+	}
+
+	if _, err := csv.MarshalWithOptions([]writerPerson{{Name: "Alice"}}, opts); err == nil {
+		t.Error("MarshalWithOptions() expected error for unknown column")
+	}
+}
+
+func TestWriterOptions_RecordFraming(t *testing.T) {
+	type event struct {
+		ID   int    `csv:"id"`
+		Body string `csv:"body"`
+	}
+	events := []event{{1, "hello, world"}, {2, "two\nlines"}}
+
+	t.Run("Writer", func(t *testing.T) {
+		opts := csv.DefaultWriterOptions()
+		opts.RecordPrefix = "data: "
+
+		var out bytes.Buffer
+		w := csv.NewWriter(&out, opts)
+		if err := w.Write([]string{"a", "b", "c"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		if got, want := out.String(), "data: a,b,c\n"; got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Marshal round trip", func(t *testing.T) {
+		wopts := csv.DefaultWriterOptions()
+		wopts.RecordPrefix = "data: "
+		wopts.RecordSuffix = "\n" // blank line ends each server-sent event
+		data, err := csv.MarshalWithOptions(events, wopts)
+		if err != nil {
+			t.Fatalf("MarshalWithOptions() error = %v", err)
+		}
+		want := "data: body,id\n\ndata: \"hello, world\",1\n\ndata: \"two\nlines\",2\n\n"
+		if string(data) != want {
+			t.Fatalf("output = %q, want %q", data, want)
+		}
+
+		ropts := csv.DefaultReaderOptions()
+		ropts.RecordPrefix = "data: "
+		var got []event
+		if err := csv.UnmarshalWithOptions(data, &got, ropts); err != nil {
+			t.Fatalf("UnmarshalWithOptions() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, events) {
+			t.Errorf("UnmarshalWithOptions() = %+v, want %+v", got, events)
+		}
+	})
+
+	t.Run("RenderWithOptions suffix", func(t *testing.T) {
+		opts := csv.DefaultWriterOptions()
+		opts.RecordPrefix = "["
+		opts.RecordSuffix = "]"
+		node, _ := csv.RecordsToNode([][]string{{"a", "b"}, {"c", "d"}})
+		got, err := csv.RenderWithOptions(node, opts)
+		if err != nil {
+			t.Fatalf("RenderWithOptions() error = %v", err)
+		}
+		if want := "[a,b]\n[c,d]\n"; string(got) != want {
+			t.Fatalf("output = %q, want %q", got, want)
+		}
+
+		ropts := csv.DefaultReaderOptions()
+		ropts.RecordPrefix = "["
+		ropts.RecordSuffix = "]"
+		parsed, err := csv.ParseReaderWithOptions(bytes.NewReader(got), ropts)
+		if err != nil {
+			t.Fatalf("ParseReaderWithOptions() error = %v", err)
+		}
+		want := [][]string{{"a", "b"}, {"c", "d"}}
+		if records := csv.NodeToRecords(parsed); !reflect.DeepEqual(records, want) {
+			t.Errorf("ParseReaderWithOptions() = %q, want %q", records, want)
+		}
+	})
+}
+
+func TestWriteAll(t *testing.T) {
+	crlf := csv.DefaultWriterOptions()
+	crlf.UseCRLF = true
+
+	tests := []struct {
+		name    string
+		records [][]string
+		opts    csv.WriterOptions
+		want    string
+	}{
+		{
+			name:    "fields needing quoting",
+			records: [][]string{{"name", "note"}, {"Smith, Jane", `say "hi"`}, {"two\nlines", ""}},
+			opts:    csv.DefaultWriterOptions(),
+			want:    "name,note\n\"Smith, Jane\",\"say \"\"hi\"\"\"\n\"two\nlines\",\n",
+		},
+		{
+			name:    "CRLF",
+			records: [][]string{{"a", "b"}, {"1", "2"}},
+			opts:    crlf,
+			want:    "a,b\r\n1,2\r\n",
+		},
+		{
+			name:    "zero options",
+			records: [][]string{{"a", "b"}},
+			want:    "a,b\n",
+		},
+		{
+			name:    "empty records",
+			records: [][]string{},
+			opts:    csv.DefaultWriterOptions(),
+			want:    "",
+		},
+		{
+			name: "nil records",
+			opts: csv.DefaultWriterOptions(),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := csv.WriteAll(&buf, tt.records, tt.opts); err != nil {
+				t.Fatalf("WriteAll() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteAll() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		records := [][]string{{"a", "b,c"}, {`"q"`, "x\r\ny"}}
+		var buf bytes.Buffer
+		if err := csv.WriteAll(&buf, records, csv.DefaultWriterOptions()); err != nil {
+			t.Fatalf("WriteAll() error = %v", err)
+		}
+		node, err := csv.Parse(buf.String())
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if got := csv.NodeToRecords(node); !reflect.DeepEqual(got, records) {
+			t.Errorf("Parse(WriteAll()) = %q, want %q", got, records)
+		}
+	})
+}
+
+func TestQuoteField(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		opts  csv.WriterOptions
+		want  string
+	}{
+		{name: "plain", value: "Alice", want: "Alice"},
+		{name: "comma", value: "Smith, Jane", want: `"Smith, Jane"`},
+		{name: "quote", value: `say "hi"`, want: `"say ""hi"""`},
+		{name: "newline", value: "two\nlines", want: "\"two\nlines\""},
+		{name: "carriage return", value: "a\rb", want: "\"a\rb\""},
+		{name: "empty", value: "", want: ""},
+		{name: "quote empty", value: "", opts: csv.WriterOptions{QuoteEmptyFields: true}, want: `""`},
+		{name: "tab delimiter leaves comma", value: "a,b", opts: csv.WriterOptions{Comma: '\t'}, want: "a,b"},
+		{name: "tab delimiter", value: "a\tb", opts: csv.WriterOptions{Comma: '\t'}, want: "\"a\tb\""},
+		{name: "single quote", value: "it's", opts: csv.WriterOptions{Quote: '\''}, want: "'it''s'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := csv.QuoteField(tt.value, tt.opts)
+			if got != tt.want {
+				t.Errorf("QuoteField(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+
+			// The result must match what a Writer emits for the same field
+			var buf bytes.Buffer
+			w := csv.NewWriter(&buf, tt.opts)
+			if err := w.Write([]string{tt.value}); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if line := strings.TrimSuffix(buf.String(), "\n"); line != got {
+				t.Errorf("Writer wrote %q, QuoteField returned %q", line, got)
+			}
+		})
+	}
+}